@@ -0,0 +1,79 @@
+package logger_test
+
+import (
+    "path/filepath"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+// newBenchLogger creates a logger writing entries at INFO and above to a temporary file.
+func newBenchLogger(t testing.TB, config logger.LogConfig) *logger.Logger {
+    config.FilePath = filepath.Join(t.TempDir(), "app.log")
+    config.FileLevel = "info"
+    log, err := logger.NewLogger(config)
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    return log
+}
+
+func TestTextOutputZeroAllocs(t *testing.T) {
+    log := newBenchLogger(t, logger.LogConfig{Format: "standard"})
+    log.Info("Warm up")
+    if allocs := testing.AllocsPerRun(100, func() { log.Info("Steady state") }); allocs != 0 {
+        t.Errorf("Expected no allocations per entry, got %.1f", allocs)
+    }
+}
+
+func TestTextOutputFieldsZeroAllocs(t *testing.T) {
+    log := newBenchLogger(t, logger.LogConfig{Format: "standard"}).WithFields(logger.Fields{"user": "bob", "attempt": 3, "cached": true})
+    log.Info("Warm up")
+    if allocs := testing.AllocsPerRun(100, func() { log.Info("Steady state") }); allocs != 0 {
+        t.Errorf("Expected no allocations per entry with fields, got %.1f", allocs)
+    }
+}
+
+func TestEntryFieldsPooled(t *testing.T) {
+    if raceEnabled {
+        t.Skip("sync.Pool drops values under the race detector")
+    }
+    log := newBenchLogger(t, logger.LogConfig{Format: "standard", EntryIDs: true})
+    log.Info("Warm up")
+    // The entry ID and its interface value are the only allocations left
+    if allocs := testing.AllocsPerRun(100, func() { log.Info("Steady state") }); allocs > 2 {
+        t.Errorf("Expected the fields map of the entries to be pooled, got %.1f allocations per entry", allocs)
+    }
+}
+
+func TestEntryFieldsRetained(t *testing.T) {
+    log := newBenchLogger(t, logger.LogConfig{EntryIDs: true, RingBufferSize: 10})
+    entries, cancel := log.Subscribe(nil)
+    defer cancel()
+    var hooked []logger.Entry
+    log.AddHook(nil, func(e logger.Entry) { hooked = append(hooked, e) })
+    log.Info("First")
+    log.Info("Second")
+
+    for name, kept := range map[string][]logger.Entry{"ring buffer": log.RecentEntries(), "hook": hooked, "subscriber": {<-entries, <-entries}} {
+        if len(kept) != 2 || kept[0].Fields[logger.EntryIDField] == kept[1].Fields[logger.EntryIDField] || kept[0].Fields[logger.EntryIDField] == nil {
+            t.Errorf("Expected the %s to keep the fields of each entry, got %v", name, kept)
+        }
+    }
+}
+
+func BenchmarkInfoText(b *testing.B) {
+    log := newBenchLogger(b, logger.LogConfig{Format: "standard"})
+    b.ReportAllocs()
+    for i := 0; i < b.N; i++ {
+        log.Info("Benchmark entry")
+    }
+}
+
+func BenchmarkInfoJSON(b *testing.B) {
+    log := newBenchLogger(b, logger.LogConfig{Format: "json"})
+    b.ReportAllocs()
+    for i := 0; i < b.N; i++ {
+        log.Info("Benchmark entry")
+    }
+}
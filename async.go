@@ -0,0 +1,146 @@
+package logger
+
+import "context"
+
+// DefaultQueueSize is the number of entries queued for the background writer when
+// LogConfig.Async is set and QueueSize is not.
+const DefaultQueueSize = 1024
+
+// asyncWriter writes entries to the outputs of a logger from a background goroutine, so that
+// logging calls do not wait for file and console writes. ERROR entries and more severe ones have a
+// priority lane: they are written before the backlog of less severe entries and do not wait for
+// room in its queue, so that they survive overloads caused by verbose traffic.
+type asyncWriter struct {
+    queue   chan asyncItem
+    urgent  chan asyncItem // Priority lane of the ERROR entries and more severe ones.
+    stopped chan struct{}  // Closed when the background goroutine has exited.
+}
+
+// asyncItem is a queued entry, or a flush marker if flushed is set.
+type asyncItem struct {
+    l        *Logger
+    entry    Entry
+    level    string
+    msgLevel int
+    sampling bool
+    flushed  chan struct{} // Closed by the writer when all earlier items are written.
+}
+
+// newAsyncWriter starts the background writer of the logger. It writes queued entries until the
+// logger is stopped, then writes the remaining ones and exits.
+func (l *Logger) newAsyncWriter(size int) *asyncWriter {
+    if size <= 0 {
+        size = DefaultQueueSize
+    }
+    w := &asyncWriter{
+        queue:   make(chan asyncItem, size),
+        urgent:  make(chan asyncItem, size),
+        stopped: make(chan struct{}),
+    }
+    go func() {
+        defer close(w.stopped)
+        for {
+            // The priority lane is emptied before each entry of the queue
+            select {
+            case item := <-w.urgent:
+                w.process(item)
+                continue
+            default:
+            }
+            select {
+            case item := <-w.urgent:
+                w.process(item)
+            case item := <-w.queue:
+                w.process(item)
+            case <-l.stop.ch:
+                w.drain(w.urgent)
+                w.drain(w.queue)
+                return
+            }
+        }
+    }()
+    return w
+}
+
+// drain writes the items left in a queue.
+func (w *asyncWriter) drain(queue chan asyncItem) {
+    for {
+        select {
+        case item := <-queue:
+            w.process(item)
+        default:
+            return
+        }
+    }
+}
+
+// process writes a queued entry or acknowledges a flush marker.
+func (w *asyncWriter) process(item asyncItem) {
+    if item.flushed != nil {
+        close(item.flushed)
+        return
+    }
+    item.l.writeOutputs(item.entry, item.level, item.msgLevel, item.sampling)
+}
+
+// enqueue queues the entry for the background writer, in the priority lane for ERROR entries and
+// more severe ones, waiting while the queue is full. It reports false if the writer has exited, in
+// which case the caller writes the entry itself.
+func (w *asyncWriter) enqueue(l *Logger, entry Entry, level string, msgLevel int, sampling bool) bool {
+    select {
+    case <-w.stopped:
+        return false
+    default:
+    }
+    queue := w.queue
+    if level != "print" && msgLevel <= int(ErrorLevel) {
+        queue = w.urgent
+    }
+    select {
+    case queue <- asyncItem{l: l, entry: entry, level: level, msgLevel: msgLevel, sampling: sampling}:
+        return true
+    case <-w.stopped:
+        return false
+    }
+}
+
+// flush waits until all entries queued before the call are written.
+func (w *asyncWriter) flush() {
+    w.flushContext(context.Background())
+}
+
+// flushContext waits until all entries queued before the call are written, or the context is done.
+func (w *asyncWriter) flushContext(ctx context.Context) error {
+    flushed := make(chan struct{})
+    select {
+    case w.queue <- asyncItem{flushed: flushed}:
+    case <-w.stopped:
+        return nil
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+    select {
+    case <-flushed:
+    case <-w.stopped:
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+    return nil
+}
+
+// Flush waits until the entries queued by the global logger in async mode are written.
+func Flush() {
+    ensureLoggerInitialized()
+    if logInstance != nil {
+        logInstance.Flush()
+    }
+}
+
+// Flush waits until the entries queued in async mode (see LogConfig.Async) are written to the outputs.
+// Call it before the application exits to avoid losing entries. It returns immediately for
+// synchronous loggers.
+func (l *Logger) Flush() {
+    if l.async != nil {
+        l.async.flush()
+    }
+}
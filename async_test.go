@@ -0,0 +1,115 @@
+package logger_test
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+func TestAsyncFlush(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info", Async: true, QueueSize: 8})
+    for i := 0; i < 100; i++ {
+        log.Infof("Async entry %d", i)
+    }
+    log.Flush()
+
+    lines := strings.Split(strings.TrimSpace(read()), "\n")
+    if len(lines) != 100 {
+        t.Fatalf("Expected 100 entries after Flush, got %d", len(lines))
+    }
+    for i, line := range lines {
+        if !strings.HasSuffix(line, fmt.Sprintf("Async entry %d", i)) {
+            t.Fatalf("Expected entries in order, got '%s' at %d", line, i)
+        }
+    }
+}
+
+func TestAsyncDrainOnReset(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "app.log")
+    if err := logger.InitLogger(logger.LogConfig{FilePath: path, FileLevel: "info", Async: true}); err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+    logger.Info("Queued before reset")
+    logger.ResetLogger()
+
+    data, err := os.ReadFile(path)
+    if err != nil || !strings.Contains(string(data), "Queued before reset") {
+        t.Errorf("Expected queued entries to be written on reset, got '%s', %v", data, err)
+    }
+}
+
+func BenchmarkInfoAsync(b *testing.B) {
+    log := newBenchLogger(b, logger.LogConfig{Async: true})
+    b.ReportAllocs()
+    for i := 0; i < b.N; i++ {
+        log.Info("Benchmark entry")
+    }
+    log.Flush()
+}
+
+// gatedSink is a custom sink blocking its first write until released, recording the messages.
+type gatedSink struct {
+    started  chan struct{}
+    release  chan struct{}
+    once     sync.Once
+    mu       sync.Mutex
+    messages []string
+}
+
+func (s *gatedSink) Name() string                         { return "gated" }
+func (s *gatedSink) Enabled(level string, value int) bool { return true }
+func (s *gatedSink) WriteEntry(e logger.Entry) error {
+    s.once.Do(func() {
+        close(s.started)
+        <-s.release
+    })
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.messages = append(s.messages, e.Message)
+    return nil
+}
+
+func TestAsyncPriorityLane(t *testing.T) {
+    sink := &gatedSink{started: make(chan struct{}), release: make(chan struct{})}
+    log, err := logger.NewLogger(logger.LogConfig{
+        Async:           true,
+        QueueSize:       4,
+        StackTraceLevel: logger.StackTraceNone,
+        Sinks:           []logger.SinkConfig{{Sink: sink, Default: true}},
+    })
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    defer log.Close()
+
+    log.Debug("Blocked debug")
+    <-sink.started
+    // Fill the queue while the writer is blocked
+    for i := 0; i < 4; i++ {
+        log.Debugf("Backlog %d", i)
+    }
+    done := make(chan struct{})
+    go func() {
+        log.Error("Critical error")
+        close(done)
+    }()
+    select {
+    case <-done:
+    case <-time.After(time.Second):
+        t.Fatalf("Expected the error not to wait for room in the full queue")
+    }
+    close(sink.release)
+    log.Flush()
+
+    sink.mu.Lock()
+    defer sink.mu.Unlock()
+    if len(sink.messages) != 6 || sink.messages[1] != "Critical error" {
+        t.Errorf("Expected the error right after the blocked entry, got %q", sink.messages)
+    }
+}
@@ -0,0 +1,89 @@
+package logger
+
+import (
+    "context"
+    "errors"
+    "strings"
+)
+
+// DurableSink is implemented by sinks that can make the entries they accepted durable, such as sinks
+// shipping to remote collectors that wait for the acknowledgments of the sent entries. Barrier calls
+// Sync on them.
+type DurableSink interface {
+    Sink
+    // Sync returns once the entries written so far are durable, or the context is done.
+    Sync(ctx context.Context) error
+}
+
+// Barrier waits until every entry logged through the global logger before the call is durably
+// written, see (*Logger).Barrier.
+//
+// Arguments:
+//   - ctx (context.Context): Context bounding the wait.
+//
+// Returns:
+//   - error: Errors of the sinks, or the error of the context.
+func Barrier(ctx context.Context) error {
+    l := currentLogger()
+    if l == nil {
+        return nil
+    }
+    return l.Barrier(ctx)
+}
+
+// Barrier returns once every entry logged before the call has been durably written by all sinks:
+// the async queue is flushed, the log files and the files written by sinks are synced to stable
+// storage, and sinks implementing DurableSink are synced, e.g. until a remote collector has
+// acknowledged the entries. It lets tests and transactional workflows rely on logged entries, such
+// as audit records written before a commit. Entries of other goroutines logged concurrently with
+// the call may or may not be covered.
+//
+// Arguments:
+//   - ctx (context.Context): Context bounding the wait.
+//
+// Returns:
+//   - error: Errors of the sinks, or the error of the context if it is done first.
+func (l *Logger) Barrier(ctx context.Context) error {
+    if l.async != nil {
+        if err := l.async.flushContext(ctx); err != nil {
+            return err
+        }
+    }
+    var errs []error
+    for _, file := range l.files {
+        if err := ctx.Err(); err != nil {
+            return err
+        }
+        if err := file.Sync(); err != nil {
+            errs = append(errs, err)
+        }
+    }
+    for _, s := range l.sinks {
+        if err := ctx.Err(); err != nil {
+            return err
+        }
+        if err := syncSink(ctx, s); err != nil {
+            errs = append(errs, &SinkError{Sink: s.Name(), Err: err})
+        }
+    }
+    return errors.Join(errs...)
+}
+
+// syncSink syncs a sink implementing DurableSink, or the writer of a sink configured with a file.
+func syncSink(ctx context.Context, s Sink) error {
+    if named, ok := s.(*namedSink); ok {
+        s = named.Sink
+    }
+    switch s := s.(type) {
+    case DurableSink:
+        return s.Sync(ctx)
+    case *outputSink:
+        if s.name == destinationFile || strings.HasPrefix(s.name, levelFileSinkPrefix) {
+            return nil // Synced with the files of the logger
+        }
+        if syncer, ok := s.out.Writer().(interface{ Sync() error }); ok && s.closer != nil {
+            return syncer.Sync()
+        }
+    }
+    return nil
+}
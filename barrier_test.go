@@ -0,0 +1,71 @@
+package logger_test
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "sync"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+// ackSink is a custom durable sink acknowledging the entries written before each Sync.
+type ackSink struct {
+    mu      sync.Mutex
+    pending int
+    acked   int
+    err     error
+}
+
+func (s *ackSink) Name() string                         { return "remote" }
+func (s *ackSink) Enabled(level string, value int) bool { return true }
+func (s *ackSink) WriteEntry(e logger.Entry) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.pending++
+    return nil
+}
+func (s *ackSink) Sync(ctx context.Context) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.acked += s.pending
+    s.pending = 0
+    return s.err
+}
+
+func TestBarrier(t *testing.T) {
+    sink := &ackSink{}
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel: "info",
+        Async:     true,
+        Sinks:     []logger.SinkConfig{{Sink: sink, Default: true}},
+    })
+    defer log.Close()
+    for i := 0; i < 50; i++ {
+        log.Infof("Durable entry %d", i)
+    }
+    if err := log.Barrier(context.Background()); err != nil {
+        t.Fatalf("Expected the barrier to succeed, got %v", err)
+    }
+    if n := strings.Count(read(), "Durable entry"); n != 50 {
+        t.Errorf("Expected 50 entries in the file after the barrier, got %d", n)
+    }
+    sink.mu.Lock()
+    if sink.acked != 50 {
+        t.Errorf("Expected 50 entries acknowledged by the sink, got %d", sink.acked)
+    }
+    sink.mu.Unlock()
+
+    sink.err = errors.New("no ack")
+    var sinkErr *logger.SinkError
+    if err := log.Barrier(context.Background()); !errors.As(err, &sinkErr) || sinkErr.Sink != "remote" {
+        t.Errorf("Expected the error of the sink, got %v", err)
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    if err := log.Barrier(ctx); !errors.Is(err, context.Canceled) {
+        t.Errorf("Expected the error of the canceled context, got %v", err)
+    }
+}
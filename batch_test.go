@@ -0,0 +1,68 @@
+package logger_test
+
+import (
+    "fmt"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestBatch(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info"})
+    log.Batch(func(b *logger.BatchLogger) {
+        for i := 0; i < 3; i++ {
+            b.Infof("Imported record %d", i)
+        }
+        b.Debug("Filtered by level")
+        b.WithFields(logger.Fields{"record": 7}).Warning("Skipped record")
+        if content := read(); content != "" {
+            t.Errorf("Expected no output before the batch ends, got '%s'", content)
+        }
+    })
+
+    lines := strings.Split(strings.TrimSpace(read()), "\n")
+    expected := []string{"Imported record 0", "Imported record 1", "Imported record 2", "Skipped record record=7"}
+    if len(lines) != len(expected) {
+        t.Fatalf("Expected %d entries, got '%s'", len(expected), strings.Join(lines, "\n"))
+    }
+    for i, line := range lines {
+        if !strings.HasSuffix(line, expected[i]) {
+            t.Errorf("Expected entry '%s', got '%s'", expected[i], line)
+        }
+    }
+}
+
+func TestBatchLargerThanRotatedFile(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel:      "info",
+        EnableRotation: true,
+        RotationConfig: logger.RotationConfig{MaxBackups: 100},
+        FileSink: logger.FileSinkConfig{
+            RotationHooks: logger.RotationHooks{Now: newRotationClock(), MaxSizeBytes: 1024, Synchronous: true},
+        },
+    })
+    message := strings.Repeat("A", 300)
+    log.Batch(func(b *logger.BatchLogger) {
+        for i := 0; i < 10; i++ {
+            b.Infof("%s %d", message, i)
+        }
+    })
+
+    // The batch is split into files of two entries instead of being rejected
+    if lines := strings.Split(strings.TrimSpace(read()), "\n"); len(lines) != 2 || !strings.HasSuffix(lines[1], " 9") {
+        t.Errorf("Expected the last two entries in the current file, got '%s'", read())
+    }
+}
+
+func BenchmarkBatch(b *testing.B) {
+    log := newBenchLogger(b, logger.LogConfig{})
+    b.ReportAllocs()
+    for i := 0; i < b.N; i += 1000 {
+        log.Batch(func(batch *logger.BatchLogger) {
+            for j := 0; j < 1000; j++ {
+                batch.Info(fmt.Sprint("Record ", j))
+            }
+        })
+    }
+}
@@ -0,0 +1,217 @@
+package logger
+
+import (
+    "archive/zip"
+    "encoding/json"
+    "fmt"
+    "io"
+    "net/url"
+    "os"
+    "path/filepath"
+    "runtime"
+    "sort"
+    "strings"
+    "time"
+)
+
+// Limits of the log files included in a support bundle.
+const (
+    bundleMaxFiles     = 5                // Most recent log files included.
+    bundleMaxFileBytes = 16 * 1024 * 1024 // Bytes included from the end of each file.
+)
+
+// SupportBundle writes a zip archive for bug reports containing the most recent log files of the
+// global logger, the ring buffer, the effective configuration with secrets masked, the Doctor report
+// and runtime statistics.
+//
+// Arguments:
+//   - w (io.Writer): Writer receiving the zip archive.
+//
+// Returns:
+//   - error: Error if the archive cannot be written.
+func SupportBundle(w io.Writer) error {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return fmt.Errorf("logger is not initialized")
+    }
+    return logInstance.SupportBundle(w)
+}
+
+// SupportBundle writes a zip archive for bug reports, see the package-level SupportBundle.
+//
+// Arguments:
+//   - w (io.Writer): Writer receiving the zip archive.
+//
+// Returns:
+//   - error: Error if the archive cannot be written.
+func (l *Logger) SupportBundle(w io.Writer) error {
+    zw := zip.NewWriter(w)
+
+    config, err := json.MarshalIndent(maskSecrets(l.Config), "", "  ")
+    if err != nil {
+        return err
+    }
+    if err := writeZipFile(zw, "config.json", config); err != nil {
+        return err
+    }
+    if err := writeZipFile(zw, "doctor.txt", []byte(Doctor(l.Config).String())); err != nil {
+        return err
+    }
+    stats, err := json.MarshalIndent(runtimeStats(), "", "  ")
+    if err != nil {
+        return err
+    }
+    if err := writeZipFile(zw, "runtime.json", stats); err != nil {
+        return err
+    }
+
+    var recent strings.Builder
+    for _, entry := range l.RecentEntries() {
+        recent.WriteString(entry.formatJSON())
+        recent.WriteByte('\n')
+    }
+    if err := writeZipFile(zw, "recent.jsonl", []byte(recent.String())); err != nil {
+        return err
+    }
+
+    for _, path := range recentLogFiles(l.Config.FilePath, bundleMaxFiles) {
+        data, err := readTail(path, bundleMaxFileBytes)
+        if err != nil {
+            continue
+        }
+        if err := writeZipFile(zw, "logs/"+filepath.Base(path), data); err != nil {
+            return err
+        }
+    }
+    return zw.Close()
+}
+
+// writeZipFile adds a file with the given contents to the archive.
+func writeZipFile(zw *zip.Writer, name string, data []byte) error {
+    fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+    if err != nil {
+        return err
+    }
+    _, err = fw.Write(data)
+    return err
+}
+
+// recentLogFiles returns the log file and its rotated backups, newest first, at most max files.
+func recentLogFiles(path string, max int) []string {
+    if path == "" {
+        return nil
+    }
+    prefix := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+    matches, _ := filepath.Glob(filepath.Join(filepath.Dir(path), prefix+"*"))
+    type fileInfo struct {
+        path    string
+        modTime time.Time
+    }
+    var files []fileInfo
+    for _, match := range matches {
+        if info, err := os.Stat(match); err == nil && info.Mode().IsRegular() {
+            files = append(files, fileInfo{match, info.ModTime()})
+        }
+    }
+    sort.Slice(files, func(i, j int) bool { return files[i].modTime.After(files[j].modTime) })
+
+    var result []string
+    for i := 0; i < len(files) && i < max; i++ {
+        result = append(result, files[i].path)
+    }
+    return result
+}
+
+// readTail reads at most maxBytes from the end of the file.
+func readTail(path string, maxBytes int64) ([]byte, error) {
+    file, err := os.Open(path)
+    if err != nil {
+        return nil, err
+    }
+    defer file.Close()
+    info, err := file.Stat()
+    if err != nil {
+        return nil, err
+    }
+    if offset := info.Size() - maxBytes; offset > 0 {
+        if _, err := file.Seek(offset, io.SeekStart); err != nil {
+            return nil, err
+        }
+    }
+    return io.ReadAll(file)
+}
+
+// runtimeStats returns process and Go runtime statistics.
+func runtimeStats() map[string]interface{} {
+    var mem runtime.MemStats
+    runtime.ReadMemStats(&mem)
+    hostname, _ := os.Hostname()
+    return map[string]interface{}{
+        "time":        time.Now().Format(time.RFC3339),
+        "hostname":    hostname,
+        "pid":         os.Getpid(),
+        "go_version":  runtime.Version(),
+        "os":          runtime.GOOS,
+        "arch":        runtime.GOARCH,
+        "cpus":        runtime.NumCPU(),
+        "goroutines":  runtime.NumGoroutine(),
+        "heap_alloc":  mem.HeapAlloc,
+        "heap_sys":    mem.HeapSys,
+        "total_alloc": mem.TotalAlloc,
+        "num_gc":      mem.NumGC,
+    }
+}
+
+// bundleSecretKeys lists substrings of configuration keys whose values are masked in support bundles,
+// such as the Authorization header of LokiConfig.Headers and the salt of HashingConfig.
+var bundleSecretKeys = []string{"password", "passwd", "secret", "token", "apikey", "api-key", "credential", "authorization", "salt"}
+
+// maskSecrets converts a value to a generic JSON structure with the values of secret-looking keys
+// (see bundleSecretKeys) replaced and credentials removed from URLs.
+func maskSecrets(v interface{}) interface{} {
+    data, err := json.Marshal(v)
+    if err != nil {
+        return nil
+    }
+    var generic interface{}
+    json.Unmarshal(data, &generic)
+    return maskValue("", generic)
+}
+
+// maskValue masks a generic JSON value found under the given key.
+func maskValue(key string, v interface{}) interface{} {
+    switch value := v.(type) {
+    case map[string]interface{}:
+        for k, item := range value {
+            value[k] = maskValue(k, item)
+        }
+        return value
+    case []interface{}:
+        for i, item := range value {
+            value[i] = maskValue(key, item)
+        }
+        return value
+    case string:
+        if key != "" && isBundleSecret(key) && value != "" {
+            return "[REDACTED]"
+        }
+        if u, err := url.Parse(value); err == nil && u.User != nil {
+            u.User = url.User("[REDACTED]")
+            return u.String()
+        }
+        return value
+    default:
+        return value
+    }
+}
+
+// isBundleSecret reports whether the configuration key matches one of bundleSecretKeys.
+func isBundleSecret(key string) bool {
+    key = strings.ToLower(key)
+    for _, secret := range bundleSecretKeys {
+        if strings.Contains(key, secret) {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,97 @@
+package logger_test
+
+import (
+    "archive/zip"
+    "bytes"
+    "io"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestSupportBundle(t *testing.T) {
+    log, _ := newFileLogger(t, logger.LogConfig{FileLevel: "info", RingBufferSize: 10})
+    log.Info("Message for the bundle")
+
+    var buf bytes.Buffer
+    if err := log.SupportBundle(&buf); err != nil {
+        t.Fatalf("Failed to create support bundle: %v", err)
+    }
+
+    zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+    if err != nil {
+        t.Fatalf("Invalid zip archive: %v", err)
+    }
+    contents := map[string]string{}
+    for _, f := range zr.File {
+        rc, _ := f.Open()
+        data, _ := io.ReadAll(rc)
+        rc.Close()
+        contents[f.Name] = string(data)
+    }
+
+    for _, name := range []string{"config.json", "doctor.txt", "runtime.json", "recent.jsonl", "logs/app.log"} {
+        if _, ok := contents[name]; !ok {
+            t.Errorf("Expected %s in the bundle, got %v", name, zr.File)
+        }
+    }
+    if !strings.Contains(contents["logs/app.log"], "Message for the bundle") ||
+        !strings.Contains(contents["recent.jsonl"], "Message for the bundle") {
+        t.Errorf("Expected the message in the log file and ring buffer of the bundle")
+    }
+}
+
+func TestSupportBundleMasksSecrets(t *testing.T) {
+    log, _ := newFileLogger(t, logger.LogConfig{
+        FileLevel: "info",
+        Hashing:   &logger.HashingConfig{Fields: []string{"email"}, Salt: "TOPSECRETSALT"},
+        Loki: &logger.LokiConfig{
+            URL:     "http://loki:3100/loki/api/v1/push",
+            Headers: map[string]string{"Authorization": "Bearer TOPSECRETTOKEN", "X-Scope-OrgID": "tenant-1"},
+        },
+    })
+    defer log.Close()
+
+    var buf bytes.Buffer
+    if err := log.SupportBundle(&buf); err != nil {
+        t.Fatalf("Failed to create support bundle: %v", err)
+    }
+    zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+    if err != nil {
+        t.Fatalf("Invalid zip archive: %v", err)
+    }
+    for _, f := range zr.File {
+        if f.Name != "config.json" {
+            continue
+        }
+        rc, _ := f.Open()
+        data, _ := io.ReadAll(rc)
+        rc.Close()
+        config := string(data)
+        if strings.Contains(config, "TOPSECRET") {
+            t.Errorf("Expected the secrets to be masked, got %s", config)
+        }
+        if !strings.Contains(config, "tenant-1") {
+            t.Errorf("Expected other headers to be kept, got %s", config)
+        }
+        return
+    }
+    t.Fatal("Expected config.json in the bundle")
+}
+
+func TestDoctor(t *testing.T) {
+    report := logger.Doctor(logger.LogConfig{FilePath: "/nonexistent/dir/app.log", FileLevel: "loud", Format: "xml"})
+    if report.OK() {
+        t.Fatalf("Expected failed checks, got:\n%s", report)
+    }
+    output := report.String()
+    for _, expected := range []string{"invalid log level: loud", "unknown format", "mkdir -p /nonexistent/dir"} {
+        if !strings.Contains(output, expected) {
+            t.Errorf("Expected '%s' in report, got:\n%s", expected, output)
+        }
+    }
+    if err := logger.Validate(logger.LogConfig{}); err != nil {
+        t.Errorf("Expected default config to be valid: %v", err)
+    }
+}
@@ -0,0 +1,93 @@
+package logger
+
+import (
+    "sync"
+    "sync/atomic"
+    "time"
+)
+
+// BurstConfig configures burst capture: for a while after an error, the file output also records
+// verbose entries that its level filters out, so that the diagnostic detail around errors is kept
+// without permanent verbosity. Entries recorded by the ring buffer before the error (see
+// LogConfig.RingBufferSize and RingBufferLevel) are written to the file first, then entries logged
+// during the burst. The burst is extended by errors logged while it lasts.
+type BurstConfig struct {
+    Duration time.Duration // Time the file output records verbose entries after an error, 0 disables burst capture.
+    Level    interface{}   // Most verbose level recorded during a burst (default: "trace").
+    Trigger  interface{}   // Least severe level starting a burst (default: "error").
+}
+
+// burstCapture is the burst capture state of a logger, shared by its copies.
+type burstCapture struct {
+    duration time.Duration
+    level    int
+    trigger  int
+    until    atomic.Int64 // End of the current burst in Unix nanoseconds.
+
+    mu      sync.Mutex
+    covered time.Time // Entries logged up to this time were written by a previous burst.
+}
+
+// newBurstCapture validates the configuration and creates the burst capture state, nil if disabled.
+func (l *Logger) newBurstCapture(config BurstConfig) (*burstCapture, error) {
+    if config.Duration <= 0 {
+        return nil, nil
+    }
+    b := &burstCapture{duration: config.Duration}
+    var err error
+    if config.Level == nil {
+        config.Level = "trace"
+    }
+    if b.level, err = l.parseLevel(config.Level); err != nil {
+        return nil, err
+    }
+    if config.Trigger == nil {
+        config.Trigger = "error"
+    }
+    if b.trigger, err = l.parseLevel(config.Trigger); err != nil {
+        return nil, err
+    }
+    return b, nil
+}
+
+// allows reports whether a burst in progress records entries at the level value.
+func (b *burstCapture) allows(value int) bool {
+    return b != nil && value <= b.level && time.Now().UnixNano() < b.until.Load()
+}
+
+// triggers reports whether an entry at the level starts a burst.
+func (b *burstCapture) triggers(level string, value int) bool {
+    return b != nil && level != "print" && value <= b.trigger
+}
+
+// startBurst starts or extends a burst for an error logged at t, first writing the verbose entries of
+// the ring buffer the file output has not written yet.
+func (l *Logger) startBurst(t time.Time) {
+    b := l.burst
+    b.mu.Lock()
+    from := b.covered
+    b.covered = t.Add(b.duration)
+    b.until.Store(b.covered.UnixNano())
+    b.mu.Unlock()
+
+    index := l.sinkIndex(destinationFile)
+    if index < 0 || l.ring == nil {
+        return
+    }
+    if l.async != nil {
+        // Keep the file in order with the entries queued before the error
+        l.async.flush()
+    }
+    file := l.sinks[index]
+    fileLevel := int(l.levels.file.Load())
+    for _, e := range l.ring.snapshot() {
+        value, ok := l.LogLevelMap[e.Level]
+        if !ok || !e.Time.After(from) || value <= fileLevel || value > b.level {
+            // Written already, or not recorded by bursts
+            continue
+        }
+        if err := l.writeSink(file, e, e.Level, value); err != nil {
+            reportError("burst", err)
+        }
+    }
+}
@@ -0,0 +1,57 @@
+package logger_test
+
+import (
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+func TestBurstCapture(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel:       "info",
+        RingBufferSize:  10,
+        RingBufferLevel: "trace",
+        StackTraceLevel: logger.StackTraceNone,
+        Burst:           logger.BurstConfig{Duration: time.Hour, Level: "debug"},
+    })
+    log.Debug("Connecting")
+    log.Trace("Not recorded by bursts")
+    log.Info("Request received")
+    log.Error("Request failed")
+    log.Debug("Retrying")
+    log.Error("Request failed again")
+
+    var messages []string
+    for _, line := range strings.Split(strings.TrimSpace(read()), "\n") {
+        messages = append(messages, line[strings.LastIndex(line, "] ")+2:])
+    }
+    expected := []string{"Request received", "Connecting", "Request failed", "Retrying", "Request failed again"}
+    if strings.Join(messages, ",") != strings.Join(expected, ",") {
+        t.Errorf("Expected %v, got %v", expected, messages)
+    }
+}
+
+func TestBurstCaptureEnds(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel:       "info",
+        StackTraceLevel: logger.StackTraceNone,
+        Burst:           logger.BurstConfig{Duration: time.Nanosecond},
+    })
+    log.Error("Request failed")
+    time.Sleep(time.Millisecond)
+    log.Debug("After the burst")
+    if file := read(); strings.Contains(file, "After the burst") {
+        t.Errorf("Expected the file level to apply after the burst, got '%s'", file)
+    }
+
+    _, err := logger.NewLogger(logger.LogConfig{
+        FilePath: filepath.Join(t.TempDir(), "app.log"),
+        Burst:    logger.BurstConfig{Duration: time.Second, Level: "loud"},
+    })
+    if err == nil {
+        t.Errorf("Expected an invalid burst level to be rejected")
+    }
+}
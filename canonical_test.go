@@ -0,0 +1,53 @@
+package logger_test
+
+import (
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestCanonicalMiddleware(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info", StackTraceLevel: logger.StackTraceNone})
+    handler := log.CanonicalMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        line := logger.CanonicalFromContext(r.Context())
+        line.Set("user_id", "u-1")
+        line.Set("cache", "miss")
+        line.Set("cache", "hit")
+        w.WriteHeader(http.StatusBadGateway)
+        w.Write([]byte("upstream down"))
+    }))
+
+    handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/orders", nil))
+
+    content := read()
+    if strings.Count(content, "\n") != 1 {
+        t.Fatalf("Expected exactly one entry, got '%s'", content)
+    }
+    for _, expected := range []string{
+        "[ERROR] Request completed bytes=13 cache=hit duration=",
+        "method=GET path=/orders status=502 user_id=u-1",
+    } {
+        if !strings.Contains(content, expected) {
+            t.Errorf("Expected log to contain '%s', got '%s'", expected, content)
+        }
+    }
+}
+
+func TestCanonicalLineEmitOnce(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info"})
+    line := log.Canonical()
+    line.Set("job", "sync")
+    line.Emit("Job completed")
+    line.Emit("Job completed")
+
+    if count := strings.Count(read(), "Job completed"); count != 1 {
+        t.Errorf("Expected one summary entry, got %d", count)
+    }
+
+    var missing *logger.CanonicalLine
+    missing.Set("key", "value")
+    missing.Emit("Ignored")
+}
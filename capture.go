@@ -0,0 +1,183 @@
+package logger
+
+import (
+    "bufio"
+    "errors"
+    "fmt"
+    "io"
+    "os"
+    "strings"
+    "sync"
+)
+
+// Stdio capture state: the duplicated original stdout while the process output is captured.
+var (
+    captureMu      sync.Mutex
+    capturedStdout *os.File
+)
+
+// capturedStream is a standard stream of the process redirected into a pipe.
+type capturedStream struct {
+    name     string   // Stream name added to entries as the "stream" field.
+    fd       int      // File descriptor of the stream.
+    original *os.File // Duplicate of the original file descriptor.
+    reader   *os.File // Read end of the pipe now behind fd.
+}
+
+// CaptureStdio redirects the process's own stdout and stderr file descriptors into the global logger,
+// so that output written directly to them (by C libraries, by dependencies printing with fmt, or by
+// panics) is logged at the given level, one entry per line, with the "stream" field set to
+// "stdout" or "stderr". The console output of the logger keeps writing to the original stdout.
+// Capturing is supported on Linux; only one capture can be active at a time.
+//
+// Arguments:
+//   - level (string): Level of the captured lines; "fatal" is not allowed.
+//
+// Returns:
+//   - (func() error): Function restoring stdout and stderr after logging the remaining output.
+//   - error: Error if the level is invalid, capture is already active or not supported.
+func CaptureStdio(level string) (func() error, error) {
+    level = strings.ToLower(level)
+    if _, ok := levelMap()[level]; !ok || level == "fatal" {
+        return nil, fmt.Errorf("invalid capture log level: %s", level)
+    }
+
+    captureMu.Lock()
+    if capturedStdout != nil {
+        captureMu.Unlock()
+        return nil, errors.New("stdio is already captured")
+    }
+
+    var streams []*capturedStream
+    for _, s := range []struct {
+        name string
+        fd   int
+    }{{"stdout", 1}, {"stderr", 2}} {
+        stream, err := captureStream(s.name, s.fd)
+        if err != nil {
+            for _, stream := range streams {
+                stream.restore()
+            }
+            captureMu.Unlock()
+            return nil, fmt.Errorf("failed to capture %s: %v", s.name, err)
+        }
+        streams = append(streams, stream)
+    }
+    capturedStdout = streams[0].original
+    // The console writer and the error writer take captureMu under mu
+    captureMu.Unlock()
+
+    mu.Lock()
+    if logInstance != nil && logInstance.ConsoleLogger != nil && logInstance.Config.Output == nil {
+        logInstance.ConsoleLogger.SetOutput(capturedStdout)
+    }
+    mu.Unlock()
+
+    var wg sync.WaitGroup
+    for _, stream := range streams {
+        wg.Add(1)
+        go func(stream *capturedStream) {
+            defer wg.Done()
+            stream.forward(level)
+        }(stream)
+    }
+
+    var once sync.Once
+    var restoreErr error
+    return func() error {
+        once.Do(func() {
+            captureMu.Lock()
+            var errs []error
+            for _, stream := range streams {
+                errs = append(errs, stream.restore())
+            }
+            capturedStdout = nil
+            captureMu.Unlock()
+            wg.Wait()
+
+            mu.Lock()
+            if logInstance != nil && logInstance.ConsoleLogger != nil && logInstance.Config.Output == nil {
+                logInstance.ConsoleLogger.SetOutput(os.Stdout)
+            }
+            mu.Unlock()
+            restoreErr = errors.Join(errs...)
+        })
+        return restoreErr
+    }, nil
+}
+
+// consoleWriter returns the writer of the console output: the original stdout while stdio is captured,
+// os.Stdout otherwise.
+func consoleWriter() io.Writer {
+    captureMu.Lock()
+    defer captureMu.Unlock()
+    if capturedStdout != nil {
+        return capturedStdout
+    }
+    return os.Stdout
+}
+
+// captureStream redirects the file descriptor into a new pipe, keeping a duplicate of the original.
+func captureStream(name string, fd int) (*capturedStream, error) {
+    originalFD, err := dupFD(fd)
+    if err != nil {
+        return nil, err
+    }
+    original := os.NewFile(uintptr(originalFD), name)
+    r, w, err := os.Pipe()
+    if err != nil {
+        original.Close()
+        return nil, err
+    }
+    defer w.Close()
+    if err := redirectFD(int(w.Fd()), fd); err != nil {
+        original.Close()
+        r.Close()
+        return nil, err
+    }
+    return &capturedStream{name: name, fd: fd, original: original, reader: r}, nil
+}
+
+// captureMaxLine is the longest captured line logged; the rest of longer lines is dropped.
+const captureMaxLine = 1024 * 1024
+
+// captureTruncated marks the captured lines cut at captureMaxLine.
+const captureTruncated = " [truncated]"
+
+// forward logs every line read from the pipe until all its write ends are closed. Lines longer than
+// captureMaxLine are truncated, and the pipe is drained up to their end so that writers never block
+// or fail on it.
+func (s *capturedStream) forward(level string) {
+    defer s.reader.Close()
+    reader := bufio.NewReaderSize(s.reader, 64*1024)
+    var line []byte
+    truncated := false
+    for {
+        chunk, isPrefix, err := reader.ReadLine()
+        if err != nil {
+            return
+        }
+        if room := captureMaxLine - len(line); len(chunk) > room {
+            chunk, truncated = chunk[:room], true
+        }
+        line = append(line, chunk...)
+        if isPrefix {
+            continue
+        }
+        text := string(line)
+        if truncated {
+            text += captureTruncated
+        }
+        line, truncated = line[:0], false
+        ensureLoggerInitialized()
+        if logInstance != nil {
+            logInstance.WithField("stream", s.name).logSkip(2, level, text)
+        }
+    }
+}
+
+// restore points the file descriptor back to the original file, closing the write end of the pipe.
+func (s *capturedStream) restore() error {
+    defer s.original.Close()
+    return redirectFD(int(s.original.Fd()), s.fd)
+}
@@ -0,0 +1,13 @@
+package logger
+
+import "syscall"
+
+// dupFD duplicates the file descriptor.
+func dupFD(fd int) (int, error) {
+    return syscall.Dup(fd)
+}
+
+// redirectFD makes the file descriptor to refer to the same file as from.
+func redirectFD(from, to int) error {
+    return syscall.Dup3(from, to, 0)
+}
@@ -0,0 +1,84 @@
+package logger_test
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestCaptureStdio(t *testing.T) {
+    defer logger.ResetLogger()
+    path := filepath.Join(t.TempDir(), "app.log")
+    if err := logger.InitLogger(logger.LogConfig{FilePath: path, FileLevel: "info"}); err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+
+    restore, err := logger.CaptureStdio("warning")
+    if err != nil {
+        t.Fatalf("Failed to capture stdio: %v", err)
+    }
+    if _, err := logger.CaptureStdio("info"); err == nil {
+        t.Errorf("Expected an error when stdio is already captured")
+    }
+    fmt.Println("printed by a dependency")
+    fmt.Fprintln(os.Stderr, "written to stderr")
+    if err := restore(); err != nil {
+        t.Fatalf("Failed to restore stdio: %v", err)
+    }
+
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatalf("Failed to read log file: %v", err)
+    }
+    content := string(data)
+    for _, expected := range []string{
+        "[WARNING] printed by a dependency stream=stdout",
+        "[WARNING] written to stderr stream=stderr",
+    } {
+        if !strings.Contains(content, expected) {
+            t.Errorf("Expected log to contain '%s', got '%s'", expected, content)
+        }
+    }
+}
+
+func TestCaptureStdioInvalidLevel(t *testing.T) {
+    for _, level := range []string{"fatal", "unknown"} {
+        if _, err := logger.CaptureStdio(level); err == nil {
+            t.Errorf("Expected an error for level %q", level)
+        }
+    }
+}
+
+func TestCaptureStdioLongLine(t *testing.T) {
+    defer logger.ResetLogger()
+    path := filepath.Join(t.TempDir(), "app.log")
+    if err := logger.InitLogger(logger.LogConfig{FilePath: path, FileLevel: "info"}); err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+
+    restore, err := logger.CaptureStdio("info")
+    if err != nil {
+        t.Fatalf("Failed to capture stdio: %v", err)
+    }
+    fmt.Println(strings.Repeat("x", 3*1024*1024))
+    fmt.Println("after the long line")
+    if err := restore(); err != nil {
+        t.Fatalf("Failed to restore stdio: %v", err)
+    }
+
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatalf("Failed to read log file: %v", err)
+    }
+    content := string(data)
+    if !strings.Contains(content, "x [truncated] stream=stdout") || len(content) > 2*1024*1024 {
+        t.Errorf("Expected the long line to be truncated, got %d bytes", len(content))
+    }
+    if !strings.Contains(content, "[INFO] after the long line stream=stdout") {
+        t.Errorf("Expected the capture to go on after the long line")
+    }
+}
@@ -0,0 +1,18 @@
+//go:build !linux
+
+package logger
+
+import "errors"
+
+// errCaptureUnsupported is returned by CaptureStdio on platforms without support for it.
+var errCaptureUnsupported = errors.New("stdio capture is not supported on this platform")
+
+// dupFD returns errCaptureUnsupported.
+func dupFD(fd int) (int, error) {
+    return -1, errCaptureUnsupported
+}
+
+// redirectFD returns errCaptureUnsupported.
+func redirectFD(from, to int) error {
+    return errCaptureUnsupported
+}
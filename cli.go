@@ -0,0 +1,172 @@
+package logger
+
+import (
+    "fmt"
+    "strings"
+    "time"
+)
+
+// FlagSet is the subset of flag.FlagSet and pflag.FlagSet (used by cobra) needed by RegisterFlags,
+// so both the standard library and cobra/urfave-cli style applications can register log flags.
+type FlagSet interface {
+    StringVar(p *string, name string, value string, usage string)
+    BoolVar(p *bool, name string, value bool, usage string)
+}
+
+// RedactedFlags lists substrings of flag names whose values are replaced with "[REDACTED]"
+// when command arguments are logged by StartCommand.
+var RedactedFlags = []string{"password", "passwd", "secret", "token", "apikey", "api-key", "credential"}
+
+// CLIFlags holds the logger settings bound to command-line flags by RegisterFlags.
+type CLIFlags struct {
+    File         string // Value of --log-file.
+    Format       string // Value of --log-format.
+    FileLevel    string // Value of --log-level.
+    ConsoleLevel string // Value of --log-console-level.
+    Quiet        bool   // Value of --quiet: disables console output.
+}
+
+// RegisterFlags registers the standard log flags (--log-file, --log-format, --log-level,
+// --log-console-level and --quiet) on the given flag set, for example cmd.PersistentFlags() in cobra.
+//
+// Arguments:
+//   - fs (FlagSet): Flag set to register the flags on.
+//
+// Returns:
+//   - (*CLIFlags): Flag values, filled in when the flag set is parsed.
+func RegisterFlags(fs FlagSet) *CLIFlags {
+    f := &CLIFlags{}
+    fs.StringVar(&f.File, "log-file", "", "Path to the log file")
+    fs.StringVar(&f.Format, "log-format", "", "Log format: standard or json")
+    fs.StringVar(&f.FileLevel, "log-level", "", "Log level for file output")
+    fs.StringVar(&f.ConsoleLevel, "log-console-level", "", "Log level for console output")
+    fs.BoolVar(&f.Quiet, "quiet", false, "Disable console log output")
+    return f
+}
+
+// Config applies the flag values that were set on top of the base configuration.
+//
+// Arguments:
+//   - base (LogConfig): Configuration used for flags that were not set.
+//
+// Returns:
+//   - (LogConfig): Resulting logger configuration.
+func (f *CLIFlags) Config(base LogConfig) LogConfig {
+    if f.File != "" {
+        base.FilePath = f.File
+    }
+    if f.Format != "" {
+        base.Format = f.Format
+    }
+    if f.FileLevel != "" {
+        base.FileLevel = f.FileLevel
+    }
+    if f.ConsoleLevel != "" {
+        base.ConsoleLevel = f.ConsoleLevel
+    }
+    if f.Quiet {
+        base.ConsoleOutput = false
+    }
+    return base
+}
+
+// Init initializes the global logger from the flag values, typically in cobra's PersistentPreRunE.
+//
+// Arguments:
+//   - base (LogConfig): Configuration used for flags that were not set.
+//
+// Returns:
+//   - error: Error if initialization failed, otherwise nil.
+func (f *CLIFlags) Init(base LogConfig) error {
+    return InitLogger(f.Config(base))
+}
+
+// StartCommand logs the start of a command with its arguments, redacting values of flags
+// matching RedactedFlags, and returns a function that logs the completion of the command
+// with its duration and outcome.
+//
+// Example usage with cobra:
+//
+//	var done func(error)
+//	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+//	    if err := flags.Init(config); err != nil {
+//	        return err
+//	    }
+//	    done = logger.StartCommand(cmd.CommandPath(), os.Args[1:])
+//	    return nil
+//	}
+//	cmd.PersistentPostRun = func(cmd *cobra.Command, args []string) { done(nil) }
+//
+// Arguments:
+//   - name (string): Command name.
+//   - args ([]string): Command-line arguments.
+//
+// Returns:
+//   - (func(error)): Function to call when the command finishes, with its error or nil.
+func StartCommand(name string, args []string) func(err error) {
+    start := time.Now()
+    logCommand("info", fmt.Sprintf("Command %s started with args %v", name, RedactArgs(args)))
+    return func(err error) {
+        duration := time.Since(start)
+        if err != nil {
+            logCommand("error", fmt.Sprintf("Command %s failed after %s: %v", name, duration, err))
+            return
+        }
+        logCommand("info", fmt.Sprintf("Command %s finished in %s", name, duration))
+    }
+}
+
+// logCommand logs a command entry with the global logger, reporting the caller of StartCommand or of
+// the function it returned.
+func logCommand(level, msg string) {
+    ensureLoggerInitialized()
+    if logInstance != nil {
+        logInstance.logSkip(3, level, msg)
+    }
+}
+
+// RedactArgs returns a copy of the command-line arguments with values of flags matching
+// RedactedFlags replaced with "[REDACTED]". Both "--flag=value" and "--flag value" forms are handled.
+//
+// Arguments:
+//   - args ([]string): Command-line arguments.
+//
+// Returns:
+//   - ([]string): Redacted arguments.
+func RedactArgs(args []string) []string {
+    redacted := make([]string, len(args))
+    copy(redacted, args)
+    for i := 0; i < len(redacted); i++ {
+        arg := redacted[i]
+        if !strings.HasPrefix(arg, "-") {
+            continue
+        }
+        name := strings.TrimLeft(arg, "-")
+        value := ""
+        hasValue := false
+        if idx := strings.Index(name, "="); idx >= 0 {
+            name, value, hasValue = name[:idx], name[idx+1:], true
+        }
+        if !isRedactedFlag(name) {
+            continue
+        }
+        if hasValue {
+            redacted[i] = strings.TrimSuffix(arg, value) + "[REDACTED]"
+        } else if i+1 < len(redacted) && !strings.HasPrefix(redacted[i+1], "-") {
+            redacted[i+1] = "[REDACTED]"
+            i++
+        }
+    }
+    return redacted
+}
+
+// isRedactedFlag reports whether the flag name matches one of RedactedFlags.
+func isRedactedFlag(name string) bool {
+    name = strings.ToLower(name)
+    for _, r := range RedactedFlags {
+        if strings.Contains(name, r) {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,62 @@
+package logger_test
+
+import (
+    "errors"
+    "flag"
+    "os"
+    "path/filepath"
+    "reflect"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestRegisterFlags(t *testing.T) {
+    fs := flag.NewFlagSet("app", flag.ContinueOnError)
+    flags := logger.RegisterFlags(fs)
+    if err := fs.Parse([]string{"--log-level", "debug", "--log-format=json", "--quiet"}); err != nil {
+        t.Fatalf("Failed to parse flags: %v", err)
+    }
+
+    config := flags.Config(logger.LogConfig{Format: "standard", ConsoleOutput: true, FileLevel: "info"})
+    if config.FileLevel != "debug" || config.Format != "json" || config.ConsoleOutput {
+        t.Errorf("Flags were not applied to the configuration: %+v", config)
+    }
+}
+
+func TestRedactArgs(t *testing.T) {
+    args := []string{"deploy", "--password", "hunter2", "--api-key=abc", "--verbose", "--user", "bob"}
+    expected := []string{"deploy", "--password", "[REDACTED]", "--api-key=[REDACTED]", "--verbose", "--user", "bob"}
+
+    if got := logger.RedactArgs(args); !reflect.DeepEqual(got, expected) {
+        t.Errorf("Expected %v, got %v", expected, got)
+    }
+    if args[2] != "hunter2" {
+        t.Errorf("RedactArgs must not modify its input")
+    }
+}
+
+func TestStartCommand(t *testing.T) {
+    defer logger.ResetLogger()
+    path := filepath.Join(t.TempDir(), "app.log")
+    if err := logger.InitLogger(logger.LogConfig{FilePath: path, FileLevel: "info", Format: "json"}); err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+    done := logger.StartCommand("app sync", []string{"--token", "abc", "--verbose"})
+    done(errors.New("remote unreachable"))
+
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatalf("Failed to read log file: %v", err)
+    }
+    lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+    if len(lines) != 2 || !strings.Contains(lines[0], "[--token [REDACTED] --verbose]") || !strings.Contains(lines[1], "failed after") {
+        t.Fatalf("Unexpected command entries: %q", lines)
+    }
+    for _, line := range lines {
+        if !strings.Contains(line, `"file":"cli_test.go"`) {
+            t.Errorf("Expected the call site of the command to be reported, got %s", line)
+        }
+    }
+}
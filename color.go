@@ -0,0 +1,61 @@
+package logger
+
+import (
+    "os"
+    "strconv"
+)
+
+// Color is the console color of a log level, as an ANSI SGR foreground color code.
+type Color int
+
+// Console colors for RegisterLevel, with the same names and codes as in github.com/fatih/color.
+const (
+    FgBlack Color = iota + 30
+    FgRed
+    FgGreen
+    FgYellow
+    FgBlue
+    FgMagenta
+    FgCyan
+    FgWhite
+)
+
+// Bright console colors for RegisterLevel.
+const (
+    FgHiBlack Color = iota + 90
+    FgHiRed
+    FgHiGreen
+    FgHiYellow
+    FgHiBlue
+    FgHiMagenta
+    FgHiCyan
+    FgHiWhite
+)
+
+// NoColor disables colors in the console output. It is set at startup when the NO_COLOR environment
+// variable is set, TERM is "dumb" or stdout is not a terminal, and can be changed by the application.
+var NoColor = os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" || !isTerminal(os.Stdout)
+
+// isTerminal reports whether the file is a character device such as a terminal.
+func isTerminal(f *os.File) bool {
+    info, err := f.Stat()
+    return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// appendColorStart appends the escape sequence starting the color to buf, unless colors are disabled.
+func appendColorStart(buf []byte, c Color) []byte {
+    if NoColor {
+        return buf
+    }
+    buf = append(buf, "\x1b["...)
+    buf = strconv.AppendInt(buf, int64(c), 10)
+    return append(buf, 'm')
+}
+
+// appendColorEnd appends the escape sequence resetting the color to buf, unless colors are disabled.
+func appendColorEnd(buf []byte) []byte {
+    if NoColor {
+        return buf
+    }
+    return append(buf, "\x1b[0m"...)
+}
@@ -0,0 +1,285 @@
+package logger
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "io"
+    "os"
+    "strings"
+    "sync"
+)
+
+// ProfileEnv is the environment variable selecting the configuration profile when no profile
+// was chosen with UseProfile.
+const ProfileEnv = "LOGGER_PROFILE"
+
+// Configuration file state: the active profile and the last configuration loaded by InitFromFile.
+var (
+    profileMu     sync.Mutex
+    activeProfile string
+    loadedConfig  []byte
+)
+
+// configDocument is the layout of a configuration file: LogConfig fields at the top level and
+// named profiles whose fields override them.
+type configDocument struct {
+    Profiles map[string]json.RawMessage
+}
+
+// ParseConfig parses a JSON configuration document. Field names match LogConfig fields
+// case-insensitively (for example "filePath", "consoleLevel", "rotationConfig").
+// Sizes and durations can be given as numbers in the units of the LogConfig fields or as strings:
+// "maxSize" and "preallocate" accept sizes such as "100MB" or "1GiB" (see ParseSize), "maxAge" and
+// "thinAfter" whole days such as "7d" or "2w", and the sync and rotation "interval", the sampling
+// "tick", the repeats "window" and the level window "duration" durations such as "12h"
+// (see ParseDuration).
+// The optional "profiles" object holds named overrides, for example
+// {"consoleLevel": "info", "profiles": {"dev": {"consoleLevel": "trace"}}}; the profile selected with
+// UseProfile or the LOGGER_PROFILE environment variable is applied on top of the top-level fields.
+//
+// Arguments:
+//   - data ([]byte): JSON configuration document.
+//
+// Returns:
+//   - (LogConfig): Parsed configuration.
+//   - error: Error if the document is invalid or the selected profile does not exist.
+func ParseConfig(data []byte) (LogConfig, error) {
+    return parseConfig(data, selectedProfile())
+}
+
+// LoadConfig reads and parses a JSON configuration file, see ParseConfig.
+//
+// Arguments:
+//   - path (string): Path to the configuration file.
+//
+// Returns:
+//   - (LogConfig): Parsed configuration.
+//   - error: Error if the file cannot be read or parsed.
+func LoadConfig(path string) (LogConfig, error) {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return LogConfig{}, fmt.Errorf("failed to read config file: %v", err)
+    }
+    return ParseConfig(data)
+}
+
+// InitFromFile loads a JSON configuration file and initializes the global logger with it.
+// The configuration is remembered so that UseProfile can switch profiles later.
+//
+// Arguments:
+//   - path (string): Path to the configuration file.
+//
+// Returns:
+//   - error: Error if the file cannot be loaded or the logger cannot be initialized.
+func InitFromFile(path string) error {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return fmt.Errorf("failed to read config file: %v", err)
+    }
+    return initFromDocument(data)
+}
+
+// UseProfile selects the configuration profile of the configuration loaded with InitFromFile and
+// re-initializes the global logger with it. The profile is also applied by later calls of
+// ParseConfig, LoadConfig and InitFromFile, taking precedence over the LOGGER_PROFILE environment
+// variable. A programmatic InitLogger or ResetLogger forgets the loaded configuration.
+//
+// Arguments:
+//   - name (string): Profile name, empty to fall back to LOGGER_PROFILE.
+//
+// Returns:
+//   - error: Error if no configuration was loaded with InitFromFile or the profile does not exist in it.
+func UseProfile(name string) error {
+    profileMu.Lock()
+    data := loadedConfig
+    if data == nil && name != "" {
+        profileMu.Unlock()
+        return fmt.Errorf("no configuration loaded to select profile %s from", name)
+    }
+    previous := activeProfile
+    activeProfile = name
+    profileMu.Unlock()
+
+    if data == nil {
+        return nil
+    }
+    if err := initFromDocument(data); err != nil {
+        profileMu.Lock()
+        activeProfile = previous
+        profileMu.Unlock()
+        return err
+    }
+    return nil
+}
+
+// initFromDocument initializes the global logger from a JSON configuration document and remembers it.
+func initFromDocument(data []byte) error {
+    config, err := ParseConfig(data)
+    if err != nil {
+        return err
+    }
+    if err := InitLogger(config); err != nil {
+        return err
+    }
+    profileMu.Lock()
+    loadedConfig = data
+    profileMu.Unlock()
+    return nil
+}
+
+// forgetConfig forgets the loaded configuration, once the global logger no longer runs with it.
+// With reset, the profile chosen with UseProfile is forgotten too.
+func forgetConfig(reset bool) {
+    profileMu.Lock()
+    defer profileMu.Unlock()
+    loadedConfig = nil
+    if reset {
+        activeProfile = ""
+    }
+}
+
+// selectedProfile returns the profile chosen with UseProfile or, if none, from LOGGER_PROFILE.
+func selectedProfile() string {
+    profileMu.Lock()
+    defer profileMu.Unlock()
+    if activeProfile != "" {
+        return activeProfile
+    }
+    return os.Getenv(ProfileEnv)
+}
+
+// parseConfig parses a JSON configuration document and applies the named profile, if any.
+func parseConfig(data []byte, profile string) (LogConfig, error) {
+    var config LogConfig
+    if err := decodeStrict(data, &config); err != nil {
+        return LogConfig{}, fmt.Errorf("invalid logger config: %v", err)
+    }
+
+    if profile == "" {
+        return config, nil
+    }
+    var doc configDocument
+    if err := json.Unmarshal(data, &doc); err != nil {
+        return LogConfig{}, fmt.Errorf("invalid logger config: %v", err)
+    }
+    override, ok := doc.Profiles[profile]
+    if !ok {
+        return LogConfig{}, fmt.Errorf("unknown logger config profile: %s", profile)
+    }
+    if err := decodeStrict(override, &config); err != nil {
+        return LogConfig{}, fmt.Errorf("invalid logger config profile %s: %v", profile, err)
+    }
+    return config, nil
+}
+
+// decodeStrict decodes JSON into the configuration, rejecting unknown fields other than "profiles".
+func decodeStrict(data []byte, config *LogConfig) error {
+    var raw map[string]json.RawMessage
+    if err := json.Unmarshal(data, &raw); err != nil {
+        return err
+    }
+    for key := range raw {
+        if bytes.EqualFold([]byte(key), []byte("profiles")) {
+            delete(raw, key)
+        }
+    }
+    if err := convertUnits(raw, ""); err != nil {
+        return err
+    }
+    stripped, err := json.Marshal(raw)
+    if err != nil {
+        return err
+    }
+    dec := json.NewDecoder(bytes.NewReader(stripped))
+    dec.DisallowUnknownFields()
+    return dec.Decode(config)
+}
+
+// ConfigDecoder converts a configuration document in some format into a generic map
+// with the same layout as the JSON configuration, see ParseConfig.
+type ConfigDecoder func(data []byte) (map[string]interface{}, error)
+
+// Registered configuration decoders by format name, in addition to the built-in "json".
+var (
+    decodersMu sync.RWMutex
+    decoders   = map[string]ConfigDecoder{}
+)
+
+// RegisterConfigFormat registers a decoder for an additional configuration format (for example
+// "yaml" or "toml"), keeping the core free of third-party parsers. A decoder for a format that is
+// already registered is replaced.
+//
+// Arguments:
+//   - format (string): Format name used with InitFromReader and ParseConfigFormat.
+//   - decoder (ConfigDecoder): Function converting the document into a generic map.
+func RegisterConfigFormat(format string, decoder ConfigDecoder) {
+    decodersMu.Lock()
+    defer decodersMu.Unlock()
+    decoders[strings.ToLower(format)] = decoder
+}
+
+// ParseConfigFormat parses a configuration document in the given format, see ParseConfig.
+//
+// Arguments:
+//   - data ([]byte): Configuration document.
+//   - format (string): Document format: "json" or a format registered with RegisterConfigFormat.
+//
+// Returns:
+//   - (LogConfig): Parsed configuration.
+//   - error: Error if the format is unknown or the document is invalid.
+func ParseConfigFormat(data []byte, format string) (LogConfig, error) {
+    doc, err := toJSONDocument(data, format)
+    if err != nil {
+        return LogConfig{}, err
+    }
+    return ParseConfig(doc)
+}
+
+// InitFromReader reads a configuration document from r and initializes the global logger with it,
+// so configurations embedded with go:embed or fetched from a config service can be used without
+// touching the filesystem. The configuration is remembered so that UseProfile can switch profiles later.
+//
+// Example usage:
+//
+//	//go:embed logger.json
+//	var loggerConfig []byte
+//
+//	err := logger.InitFromReader(bytes.NewReader(loggerConfig), "json")
+//
+// Arguments:
+//   - r (io.Reader): Reader providing the configuration document.
+//   - format (string): Document format: "json" or a format registered with RegisterConfigFormat.
+//
+// Returns:
+//   - error: Error if the document cannot be read or parsed, or the logger cannot be initialized.
+func InitFromReader(r io.Reader, format string) error {
+    data, err := io.ReadAll(r)
+    if err != nil {
+        return fmt.Errorf("failed to read config: %v", err)
+    }
+    doc, err := toJSONDocument(data, format)
+    if err != nil {
+        return err
+    }
+    return initFromDocument(doc)
+}
+
+// toJSONDocument converts a configuration document in the given format to JSON.
+func toJSONDocument(data []byte, format string) ([]byte, error) {
+    format = strings.ToLower(format)
+    if format == "" || format == "json" {
+        return data, nil
+    }
+    decodersMu.RLock()
+    decoder, ok := decoders[format]
+    decodersMu.RUnlock()
+    if !ok {
+        return nil, fmt.Errorf("unsupported config format: %s", format)
+    }
+    m, err := decoder(data)
+    if err != nil {
+        return nil, fmt.Errorf("invalid logger config: %v", err)
+    }
+    return json.Marshal(m)
+}
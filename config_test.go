@@ -0,0 +1,113 @@
+package logger_test
+
+import (
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+const profileConfig = `{
+    "format": "standard",
+    "consoleLevel": "info",
+    "consoleOutput": true,
+    "profiles": {
+        "dev":  {"consoleLevel": "trace"},
+        "prod": {"format": "json", "consoleLevel": 2}
+    }
+}`
+
+func TestConfigProfiles(t *testing.T) {
+    defer logger.UseProfile("")
+
+    t.Setenv(logger.ProfileEnv, "")
+    config, err := logger.ParseConfig([]byte(profileConfig))
+    if err != nil {
+        t.Fatalf("Failed to parse config: %v", err)
+    }
+    if config.ConsoleLevel != "info" || config.Format != "standard" || !config.ConsoleOutput {
+        t.Errorf("Unexpected base config: %+v", config)
+    }
+
+    t.Setenv(logger.ProfileEnv, "prod")
+    config, err = logger.ParseConfig([]byte(profileConfig))
+    if err != nil {
+        t.Fatalf("Failed to parse config: %v", err)
+    }
+    if config.ConsoleLevel != float64(2) || config.Format != "json" || !config.ConsoleOutput {
+        t.Errorf("Profile from %s was not applied: %+v", logger.ProfileEnv, config)
+    }
+    if _, err := logger.NewLogger(config); err != nil {
+        t.Errorf("Numeric level from config was rejected: %v", err)
+    }
+
+    t.Setenv(logger.ProfileEnv, "")
+    if err := logger.UseProfile("dev"); err == nil {
+        t.Errorf("Expected error selecting a profile without a loaded configuration")
+    }
+
+    defer resetLogger()
+    path := filepath.Join(t.TempDir(), "logger.json")
+    if err := os.WriteFile(path, []byte(profileConfig), 0o644); err != nil {
+        t.Fatalf("Failed to write config: %v", err)
+    }
+    if err := logger.InitFromFile(path); err != nil {
+        t.Fatalf("Failed to initialize from file: %v", err)
+    }
+    if err := logger.UseProfile("dev"); err != nil {
+        t.Fatalf("Failed to select profile: %v", err)
+    }
+    if config := logger.GetLoggerConfig(); config.ConsoleLevel != "trace" || config.Format != "standard" {
+        t.Errorf("Profile from UseProfile was not applied: %+v", config)
+    }
+    config, err = logger.ParseConfig([]byte(profileConfig))
+    if err != nil || config.ConsoleLevel != "trace" {
+        t.Errorf("Expected ParseConfig to apply the selected profile, got %v, %v", config.ConsoleLevel, err)
+    }
+
+    if err := logger.UseProfile("staging"); err == nil {
+        t.Errorf("Expected error for unknown profile")
+    }
+
+    if err := logger.InitLogger(logger.LogConfig{ConsoleLevel: "error", ConsoleOutput: true}); err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+    if err := logger.UseProfile("prod"); err == nil {
+        t.Errorf("Expected error selecting a profile after a programmatic InitLogger")
+    }
+    if config := logger.GetLoggerConfig(); config.ConsoleLevel != "error" {
+        t.Errorf("Expected the programmatic configuration to be kept, got %+v", config.ConsoleLevel)
+    }
+}
+
+func TestParseConfigUnknownField(t *testing.T) {
+    if _, err := logger.ParseConfig([]byte(`{"fileLevl": "debug"}`)); err == nil {
+        t.Errorf("Expected error for unknown config field")
+    }
+}
+
+func TestInitFromReader(t *testing.T) {
+    defer resetLogger()
+    logger.RegisterConfigFormat("kv", func(data []byte) (map[string]interface{}, error) {
+        m := map[string]interface{}{}
+        for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+            key, value, _ := strings.Cut(line, "=")
+            m[key] = value
+        }
+        return m, nil
+    })
+
+    if err := logger.InitFromReader(strings.NewReader("format=json\nconsoleLevel=error"), "kv"); err != nil {
+        t.Fatalf("Failed to initialize from reader: %v", err)
+    }
+    config := logger.GetLoggerConfig()
+    if config.Format != "json" || config.ConsoleLevel != "error" {
+        t.Errorf("Config from reader was not applied: %+v", config)
+    }
+
+    if err := logger.InitFromReader(strings.NewReader("{}"), "xml"); err == nil {
+        t.Errorf("Expected error for unsupported format")
+    }
+}
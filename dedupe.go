@@ -0,0 +1,123 @@
+package logger
+
+import (
+    "fmt"
+    "hash/fnv"
+    "sort"
+    "strconv"
+    "sync"
+    "time"
+)
+
+// Fields of the entries written by sinks and remote outputs with Dedupe set.
+const (
+    ContentHashField = "content_hash" // Content hash of an entry or a batch that later records may reference.
+    DedupRefField    = "dedup_ref"    // Content hash of the entry or the batch a reference record replaces.
+)
+
+// dedupeFilter replaces consecutive payloads with identical content by reference records, for sinks
+// and remote outputs shipping to collectors where egress is paid per byte. A payload is an entry, or a
+// batch of entries for the Loki output. The content of an entry is its level, message, caller and
+// fields, not its time, its entry ID or its stack traces. The first payload of a run is sent with its
+// content hash and is sent again once the window since it has elapsed, so that collectors can resolve
+// the references even after losing data.
+type dedupeFilter struct {
+    window time.Duration
+
+    mu    sync.Mutex
+    hash  string    // Content hash of the last payload sent in full.
+    since time.Time // Time the last payload was sent in full.
+}
+
+// newDedupeFilter creates the deduplication state of a sink, nil if window is not positive.
+func newDedupeFilter(window time.Duration) *dedupeFilter {
+    if window <= 0 {
+        return nil
+    }
+    return &dedupeFilter{window: window}
+}
+
+// repeats reports whether a payload with the content hash, sent at t, repeats the last payload sent
+// in full within the window, and records it as sent in full otherwise.
+func (d *dedupeFilter) repeats(hash string, t time.Time) bool {
+    d.mu.Lock()
+    defer d.mu.Unlock()
+    if hash == d.hash && t.Sub(d.since) < d.window {
+        return true
+    }
+    d.hash, d.since = hash, t
+    return false
+}
+
+// apply returns the entry with its content hash, or the reference record replacing it if it repeats
+// the last entry written in full within the window.
+func (d *dedupeFilter) apply(e Entry) Entry {
+    if d == nil {
+        return e
+    }
+    hash := contentHash(e)
+    if d.repeats(hash, e.Time) {
+        return Entry{Time: e.Time, Level: e.Level, PID: e.PID, File: e.File, Line: e.Line,
+            Fields: Fields{DedupRefField: hash}}
+    }
+    fields := make(Fields, len(e.Fields)+1)
+    for key, value := range e.Fields {
+        fields[key] = value
+    }
+    fields[ContentHashField] = hash
+    e.Fields = fields
+    return e
+}
+
+// contentHash returns the FNV-1a hash of the level, message, caller and fields of the entry, without
+// the fields unique to each entry: its ID and stack traces.
+func contentHash(e Entry) string {
+    buf := make([]byte, 0, 256)
+    buf = append(buf, e.Level...)
+    buf = append(buf, 0)
+    buf = append(buf, e.Message...)
+    buf = append(buf, 0)
+    buf = append(buf, e.File...)
+    buf = append(buf, ':')
+    buf = strconv.AppendInt(buf, int64(e.Line), 10)
+    keys := make([]string, 0, len(e.Fields))
+    for key := range e.Fields {
+        if key != EntryIDField && key != StackTraceField && key != ErrorStackField {
+            keys = append(keys, key)
+        }
+    }
+    sort.Strings(keys)
+    for _, key := range keys {
+        buf = append(buf, 0)
+        buf = append(buf, key...)
+        buf = append(buf, '=')
+        buf = appendHashValue(buf, e.Fields[key])
+    }
+    h := fnv.New64a()
+    h.Write(buf)
+    return strconv.FormatUint(h.Sum64(), 16)
+}
+
+// appendHashValue appends the value of a field to the hashed content, without fmt for common types.
+func appendHashValue(buf []byte, value interface{}) []byte {
+    switch v := value.(type) {
+    case string:
+        return append(buf, v...)
+    case int:
+        return strconv.AppendInt(buf, int64(v), 10)
+    case int64:
+        return strconv.AppendInt(buf, v, 10)
+    case uint64:
+        return strconv.AppendUint(buf, v, 10)
+    case float64:
+        return strconv.AppendFloat(buf, v, 'g', -1, 64)
+    case bool:
+        return strconv.AppendBool(buf, v)
+    case error:
+        return append(buf, v.Error()...)
+    case fmt.Stringer:
+        return append(buf, v.String()...)
+    }
+    return fmt.Append(buf, value)
+}
+
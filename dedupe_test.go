@@ -0,0 +1,143 @@
+package logger_test
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+func TestSinkDedupe(t *testing.T) {
+    var remote bytes.Buffer
+    log, _ := newFileLogger(t, logger.LogConfig{
+        FileLevel:       "info",
+        StackTraceLevel: logger.StackTraceNone,
+        Sinks: []logger.SinkConfig{
+            {Name: "remote", Writer: &remote, Format: "json", Default: true, Dedupe: time.Minute},
+        },
+    })
+
+    health := log.WithField("check", "db")
+    for i := 0; i < 3; i++ {
+        health.Info("Health check passed")
+    }
+    health.Warning("Health check slow")
+
+    lines := strings.Split(strings.TrimSpace(remote.String()), "\n")
+    if len(lines) != 4 {
+        t.Fatalf("Expected 4 lines, got %q", remote.String())
+    }
+    var entries []map[string]interface{}
+    for _, line := range lines {
+        var entry map[string]interface{}
+        if err := json.Unmarshal([]byte(line), &entry); err != nil {
+            t.Fatalf("Invalid JSON line %q: %v", line, err)
+        }
+        entries = append(entries, entry)
+    }
+
+    hash, ok := entries[0][logger.ContentHashField].(string)
+    if !ok || hash == "" || entries[0]["message"] != "Health check passed" {
+        t.Fatalf("Expected the first entry in full with its content hash, got %v", entries[0])
+    }
+    for _, entry := range entries[1:3] {
+        if entry[logger.DedupRefField] != hash || entry["check"] != nil {
+            t.Errorf("Expected a reference record to %s, got %v", hash, entry)
+        }
+        if len(lines[1]) >= len(lines[0]) {
+            t.Errorf("Expected reference records to be shorter than the entry")
+        }
+    }
+    if entries[3]["message"] != "Health check slow" || entries[3][logger.ContentHashField] == hash {
+        t.Errorf("Expected a different entry in full, got %v", entries[3])
+    }
+}
+
+func TestSinkDedupeUniqueFields(t *testing.T) {
+    var remote bytes.Buffer
+    log, _ := newFileLogger(t, logger.LogConfig{
+        FileLevel:       "info",
+        EntryIDs:        true,
+        StackTraceLevel: "info",
+        Sinks: []logger.SinkConfig{
+            {Name: "remote", Writer: &remote, Format: "json", Default: true, Dedupe: time.Minute},
+        },
+    })
+    for i := 0; i < 2; i++ {
+        log.Info("Health check passed")
+    }
+    if count := strings.Count(remote.String(), logger.DedupRefField); count != 1 {
+        t.Errorf("Expected entry IDs and stack traces to be left out of the content, got %q", remote.String())
+    }
+}
+
+func TestLokiDedupe(t *testing.T) {
+    var mu sync.Mutex
+    var lines []map[string]interface{}
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        var push struct {
+            Streams []struct {
+                Values [][2]string `json:"values"`
+            } `json:"streams"`
+        }
+        json.NewDecoder(r.Body).Decode(&push)
+        mu.Lock()
+        defer mu.Unlock()
+        for _, stream := range push.Streams {
+            for _, value := range stream.Values {
+                var entry map[string]interface{}
+                json.Unmarshal([]byte(value[1]), &entry)
+                lines = append(lines, entry)
+            }
+        }
+        w.WriteHeader(http.StatusNoContent)
+    }))
+    defer server.Close()
+
+    log, err := logger.NewLogger(logger.LogConfig{
+        ConsoleLevel: "fatal",
+        EntryIDs:     true,
+        Loki: &logger.LokiConfig{
+            URL:           server.URL,
+            Format:        "json",
+            BatchSize:     2,
+            FlushInterval: time.Hour,
+            Dedupe:        time.Minute,
+        },
+    })
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    defer log.Close()
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+    for i := 0; i < 3; i++ {
+        log.Info("Polling queue")
+        log.Info("Queue empty")
+        if err := log.Barrier(ctx); err != nil {
+            t.Fatalf("Failed to wait for the push: %v", err)
+        }
+    }
+
+    mu.Lock()
+    defer mu.Unlock()
+    if len(lines) != 5 {
+        t.Fatalf("Expected a batch in full with its hash, then 2 reference records, got %v", lines)
+    }
+    hash, ok := lines[2][logger.ContentHashField].(string)
+    if lines[0]["message"] != "Polling queue" || !ok {
+        t.Fatalf("Expected the first batch in full with its content hash, got %v", lines[:3])
+    }
+    for _, line := range lines[3:] {
+        if line[logger.DedupRefField] != hash {
+            t.Errorf("Expected a reference record to %s, got %v", hash, line)
+        }
+    }
+}
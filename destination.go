@@ -0,0 +1,55 @@
+package logger
+
+// Output destinations of a logger restricted with File or Console.
+const (
+    destinationFile    = "file"
+    destinationConsole = "console"
+)
+
+// File returns a logger based on the global logger that writes only to the file output.
+//
+// Returns:
+//   - (*Logger): File-only logger.
+func File() *Logger {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return nil
+    }
+    return logInstance.File()
+}
+
+// Console returns a logger based on the global logger that writes only to the console output.
+//
+// Returns:
+//   - (*Logger): Console-only logger.
+func Console() *Logger {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return nil
+    }
+    return logInstance.Console()
+}
+
+// File returns a copy of the logger that writes only to the file output, for example for diagnostics
+// that should not clutter a user-facing console: l.File().Info("Cache warmed"). Output levels still apply;
+// subscribers and the ring buffer receive the entries as usual.
+//
+// Returns:
+//   - (*Logger): File-only logger.
+func (l *Logger) File() *Logger {
+    child := *l
+    child.destination = destinationFile
+    return &child
+}
+
+// Console returns a copy of the logger that writes only to the console output, for example for
+// user-facing prompts that do not belong in the log file: l.Console().Info("Enter password:").
+// Output levels still apply; subscribers and the ring buffer receive the entries as usual.
+//
+// Returns:
+//   - (*Logger): Console-only logger.
+func (l *Logger) Console() *Logger {
+    child := *l
+    child.destination = destinationConsole
+    return &child
+}
@@ -0,0 +1,28 @@
+package logger_test
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestDestinationOverride(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info", ConsoleLevel: "info", ConsoleOutput: true})
+    var console bytes.Buffer
+    log.ConsoleLogger.SetOutput(&console)
+
+    log.File().Info("Diagnostics only")
+    log.Console().Info("Prompt only")
+    log.Info("Everywhere")
+
+    file := read()
+    if !strings.Contains(file, "Diagnostics only") || strings.Contains(file, "Prompt only") || !strings.Contains(file, "Everywhere") {
+        t.Errorf("Unexpected file output: '%s'", file)
+    }
+    out := console.String()
+    if strings.Contains(out, "Diagnostics only") || !strings.Contains(out, "Prompt only") || !strings.Contains(out, "Everywhere") {
+        t.Errorf("Unexpected console output: '%s'", out)
+    }
+}
@@ -0,0 +1,107 @@
+package logger
+
+import (
+    "fmt"
+    "os"
+    "sync"
+    "time"
+)
+
+// DiagnosticsConfig configures the diagnostics file of the global logger. It receives the events of
+// the logger itself instead of the console: failures of sinks, files and processors reported to the
+// default error handler, dropped entries and reloads of the configuration, so that operational noise
+// about logging stays out of the application logs yet remains inspectable. The file has its own level
+// and is always rotated. A handler set with SetErrorHandler still receives the errors instead.
+type DiagnosticsConfig struct {
+    Path           string         // Path of the diagnostics file.
+    Level          interface{}    // Log level of the diagnostics: drops are logged at "debug", reloads at "info" and failures at "error" (default: "info").
+    Format         string         // Format of the diagnostics: "standard" or "json" (default: "standard").
+    RotationConfig RotationConfig // Rotation of the diagnostics file (default: 10 MB, 3 backups).
+}
+
+// Default rotation of the diagnostics file.
+const (
+    diagnosticsMaxSize    = 10
+    diagnosticsMaxBackups = 3
+)
+
+// diagnosticsFile writes the events of the logger itself to the diagnostics file. It renders entries
+// directly rather than through a Logger, so that failures of the diagnostics cannot report to itself.
+type diagnosticsFile struct {
+    level  int
+    format string
+    mu     sync.Mutex
+    file   *RotatingFile
+}
+
+// Diagnostics file of the global logger, nil to report errors to the console.
+var diagnostics *diagnosticsFile
+
+// newDiagnostics validates the configuration and creates the diagnostics file, opened on first write.
+func (l *Logger) newDiagnostics(config DiagnosticsConfig) (*diagnosticsFile, error) {
+    if config.Path == "" {
+        return nil, fmt.Errorf("path is required")
+    }
+    levelValue := config.Level
+    if levelValue == nil {
+        levelValue = "info"
+    }
+    level, err := l.parseLevel(levelValue)
+    if err != nil {
+        return nil, err
+    }
+    if _, err := config.RotationConfig.location(); err != nil {
+        return nil, fmt.Errorf("invalid time zone: %v", err)
+    }
+    rc := config.RotationConfig
+    if rc.MaxSize <= 0 {
+        rc.MaxSize = diagnosticsMaxSize
+    }
+    if rc.MaxBackups <= 0 {
+        rc.MaxBackups = diagnosticsMaxBackups
+    }
+    return &diagnosticsFile{level: level, format: config.Format, file: NewRotatingFile(config.Path, rc, RotationHooks{})}, nil
+}
+
+// setDiagnostics replaces the diagnostics file of the global logger, closing the previous one.
+func setDiagnostics(d *diagnosticsFile) {
+    errorHandlerMu.Lock()
+    old := diagnostics
+    diagnostics = d
+    errorHandlerMu.Unlock()
+    if old != nil && old != d {
+        old.close()
+    }
+}
+
+// diagnose writes an event of the component to the diagnostics file, with the caller skip frames
+// above the caller of diagnose, and reports whether a diagnostics file is configured.
+func diagnose(skip int, level Level, component, msg string) bool {
+    errorHandlerMu.RLock()
+    d := diagnostics
+    errorHandlerMu.RUnlock()
+    if d == nil {
+        return false
+    }
+    if int(level) > d.level {
+        return true
+    }
+    e := Entry{Time: time.Now(), Level: level.String(), Message: msg, PID: os.Getpid(), Fields: Fields{"component": component}}
+    e.File, e.Line, _ = caller(skip + 1)
+    line := append(e.appendFormat(nil, d.format, nil), '\n')
+
+    d.mu.Lock()
+    defer d.mu.Unlock()
+    if _, err := d.file.Write(line); err != nil {
+        // Printed, as reporting it would write to the failing file again
+        fmt.Fprintf(errorWriter(), "Logger diagnostics error: %v\n", err)
+    }
+    return true
+}
+
+// close closes the diagnostics file.
+func (d *diagnosticsFile) close() {
+    d.mu.Lock()
+    defer d.mu.Unlock()
+    d.file.Close()
+}
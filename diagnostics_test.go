@@ -0,0 +1,60 @@
+package logger_test
+
+import (
+    "bytes"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestDiagnostics(t *testing.T) {
+    defer logger.ResetLogger()
+    dir := t.TempDir()
+    appPath, diagPath := filepath.Join(dir, "app.log"), filepath.Join(dir, "logger.log")
+    var errorOutput bytes.Buffer
+    config := logger.LogConfig{
+        FilePath:    appPath,
+        FileLevel:   "info",
+        ErrorOutput: &errorOutput,
+        Sinks:       []logger.SinkConfig{{Name: "broken", Writer: failingWriter{}, Level: "error", Default: true}},
+        Diagnostics: &logger.DiagnosticsConfig{Path: diagPath, Format: "json"},
+    }
+    if err := logger.InitLogger(config); err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+    logger.Error("Message the broken sink fails")
+    if err := logger.InitLogger(config); err != nil {
+        t.Fatalf("Failed to re-initialize logger: %v", err)
+    }
+    logger.ResetLogger()
+
+    data, err := os.ReadFile(diagPath)
+    if err != nil {
+        t.Fatalf("Failed to read diagnostics file: %v", err)
+    }
+    diag := string(data)
+    if !strings.Contains(diag, `"component":"outputs"`) || !strings.Contains(diag, "disk full") {
+        t.Errorf("Expected the sink error in the diagnostics, got %q", diag)
+    }
+    if !strings.Contains(diag, "Logger reconfigured") {
+        t.Errorf("Expected the reload in the diagnostics, got %q", diag)
+    }
+    if errorOutput.Len() > 0 {
+        t.Errorf("Expected no errors printed with diagnostics, got %q", errorOutput.String())
+    }
+    app, _ := os.ReadFile(appPath)
+    if strings.Contains(string(app), "disk full") || strings.Contains(string(app), "reconfigured") {
+        t.Errorf("Expected no diagnostics in the application log, got %q", app)
+    }
+
+    err = logger.InitLogger(logger.LogConfig{ErrorOutput: &errorOutput, Diagnostics: &logger.DiagnosticsConfig{Path: diagPath, Level: "loud"}})
+    if err == nil || !strings.Contains(err.Error(), "invalid diagnostics") {
+        t.Errorf("Expected an invalid diagnostics level to be rejected, got %v", err)
+    }
+    if _, err := logger.NewLogger(logger.LogConfig{Diagnostics: &logger.DiagnosticsConfig{Path: diagPath}}); err == nil {
+        t.Errorf("Expected diagnostics to be rejected outside InitLogger")
+    }
+}
@@ -0,0 +1,211 @@
+package logger
+
+import (
+    "errors"
+    "fmt"
+    "io/fs"
+    "os"
+    "path/filepath"
+    "strings"
+    "syscall"
+)
+
+// DoctorCheck is the result of a single diagnostic check performed by Doctor.
+type DoctorCheck struct {
+    Name    string // Short name of the check.
+    OK      bool   // Whether the check passed.
+    Message string // Details or remediation hint.
+}
+
+// DoctorReport is the result of Doctor.
+type DoctorReport struct {
+    Checks []DoctorCheck
+}
+
+// OK reports whether all checks passed.
+func (r DoctorReport) OK() bool {
+    for _, c := range r.Checks {
+        if !c.OK {
+            return false
+        }
+    }
+    return true
+}
+
+// Err returns an error describing the first failed check, or nil if all checks passed.
+func (r DoctorReport) Err() error {
+    for _, c := range r.Checks {
+        if !c.OK {
+            return fmt.Errorf("%s: %s", c.Name, c.Message)
+        }
+    }
+    return nil
+}
+
+// String renders the report with one line per check.
+func (r DoctorReport) String() string {
+    var b strings.Builder
+    for _, c := range r.Checks {
+        status := "OK"
+        if !c.OK {
+            status = "FAIL"
+        }
+        fmt.Fprintf(&b, "[%s] %s: %s\n", status, c.Name, c.Message)
+    }
+    return b.String()
+}
+
+// Validate checks a logger configuration without creating a logger, see Doctor.
+//
+// Arguments:
+//   - config (LogConfig): Configuration to check.
+//
+// Returns:
+//   - error: Error describing the first problem found, otherwise nil.
+func Validate(config LogConfig) error {
+    return Doctor(config).Err()
+}
+
+// Doctor diagnoses a logger configuration: log levels, formats, the log file location and
+// rotation settings, reporting each check with a hint on how to fix it. The log directory is probed
+// by creating, writing and removing a temporary file, so that permission, SELinux and AppArmor
+// denials are reported with a remediation hint instead of failing on the first entry.
+//
+// Arguments:
+//   - config (LogConfig): Configuration to diagnose.
+//
+// Returns:
+//   - (DoctorReport): Results of the checks.
+func Doctor(config LogConfig) DoctorReport {
+    setDefaults(&config)
+    l := &Logger{LogLevelMap: levelMap()}
+    var report DoctorReport
+    add := func(name string, err error, okMessage string) {
+        if err != nil {
+            report.Checks = append(report.Checks, DoctorCheck{Name: name, Message: err.Error()})
+            return
+        }
+        report.Checks = append(report.Checks, DoctorCheck{Name: name, OK: true, Message: okMessage})
+    }
+
+    _, err := l.parseLevel(config.FileLevel)
+    add("file level", err, fmt.Sprint(config.FileLevel))
+    _, err = l.parseLevel(config.ConsoleLevel)
+    add("console level", err, fmt.Sprint(config.ConsoleLevel))
+
+    formats := []struct{ name, format string }{
+        {"format", config.Format},
+        {"file format", config.FileFormat},
+        {"console format", config.ConsoleFormat},
+    }
+    for _, f := range formats {
+        if f.format == "" && f.name != "format" {
+            continue
+        }
+        err = nil
+        if format := strings.ToLower(f.format); format != "standard" && format != "json" {
+            err = fmt.Errorf("unknown format %q, use \"standard\" or \"json\"", f.format)
+        }
+        add(f.name, err, f.format)
+    }
+
+    if config.FilePath == "" {
+        add("log file", nil, "file output disabled")
+    } else {
+        path, err := ExpandPath(config.FilePath, config.RelativeToExe)
+        if err == nil {
+            err = checkLogFile(path)
+        } else {
+            path = config.FilePath
+        }
+        add("log file", err, path)
+        if err == nil {
+            dir := filepath.Dir(path)
+            add("write probe", probeWrite(dir), "created a file in "+dir)
+        }
+    }
+
+    if len(config.Transforms) > 0 {
+        _, err := compileTransforms(config.Transforms)
+        add("transforms", err, fmt.Sprintf("%d transforms", len(config.Transforms)))
+    }
+
+    if config.EnableRotation {
+        var err error
+        rc := config.RotationConfig
+        if rc.MaxSize < 0 || rc.MaxBackups < 0 || rc.MaxAge < 0 {
+            err = fmt.Errorf("rotation limits must not be negative: %+v", rc)
+        } else if rc.Interval < 0 {
+            err = fmt.Errorf("rotation interval must not be negative: %v", rc.Interval)
+        } else if _, zerr := rc.location(); zerr != nil {
+            err = fmt.Errorf("invalid rotation time zone: %v", zerr)
+        }
+        add("rotation", err, fmt.Sprintf("max size %d MB, %d backups, %d days", rc.MaxSize, rc.MaxBackups, rc.MaxAge))
+    }
+    return report
+}
+
+// checkLogFile checks that the log directory exists and that an existing log file is a writable regular file.
+func checkLogFile(path string) error {
+    dir := filepath.Dir(path)
+    info, err := os.Stat(dir)
+    if os.IsNotExist(err) {
+        return fmt.Errorf("log directory does not exist: %s (create it with: mkdir -p %s)", dir, dir)
+    } else if err != nil {
+        return fmt.Errorf("cannot access log directory %s: %v", dir, err)
+    }
+    if !info.IsDir() {
+        return fmt.Errorf("log directory is not a directory: %s", dir)
+    }
+
+    info, err = os.Stat(path)
+    if os.IsNotExist(err) {
+        return nil
+    } else if err != nil {
+        return fmt.Errorf("cannot access log file %s: %v", path, err)
+    }
+    if !info.Mode().IsRegular() {
+        return fmt.Errorf("log file is not a regular file: %s", path)
+    }
+    file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+    if err != nil {
+        return writeError("log file is not writable", path, false, err)
+    }
+    return file.Close()
+}
+
+// probeWrite creates, writes, syncs and removes a temporary file in the directory, as the logger
+// does when it opens and rotates log files.
+func probeWrite(dir string) error {
+    file, err := os.CreateTemp(dir, ".logger-probe-*")
+    if err != nil {
+        return writeError("cannot create files in the log directory", dir, true, err)
+    }
+    defer os.Remove(file.Name())
+    _, err = file.Write([]byte("probe\n"))
+    if err == nil {
+        err = file.Sync()
+    }
+    if cerr := file.Close(); err == nil {
+        err = cerr
+    }
+    if err != nil {
+        return writeError("cannot write to the log directory", dir, true, err)
+    }
+    return nil
+}
+
+// writeError describes a failed write to the path with a remediation hint for its cause.
+func writeError(message, path string, dir bool, err error) error {
+    switch {
+    case errors.Is(err, fs.ErrPermission):
+        if hint := accessDeniedHint(path, dir); hint != "" {
+            return fmt.Errorf("%s: %v (%s)", message, err, hint)
+        }
+    case errors.Is(err, syscall.EROFS):
+        return fmt.Errorf("%s: %v (the file system is mounted read-only; use a writable path or remount it)", message, err)
+    case errors.Is(err, syscall.ENOSPC):
+        return fmt.Errorf("%s: %v (the disk is full; free space or use another path)", message, err)
+    }
+    return fmt.Errorf("%s: %v", message, err)
+}
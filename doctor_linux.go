@@ -0,0 +1,72 @@
+package logger
+
+import (
+    "fmt"
+    "os"
+    "strings"
+    "syscall"
+)
+
+// accessDeniedHint explains why writing to the path was denied: the owner and mode of the path when
+// they deny the process, otherwise an active SELinux or AppArmor policy, which denies writes that
+// the mode allows.
+func accessDeniedHint(path string, dir bool) string {
+    info, err := os.Stat(path)
+    if err != nil {
+        return ""
+    }
+    st, ok := info.Sys().(*syscall.Stat_t)
+    if !ok {
+        return ""
+    }
+    if !modeAllowsWrite(st, dir) {
+        return fmt.Sprintf("%s is owned by uid %d gid %d with mode %v and the process runs as uid %d; "+
+            "change the owner with chown or the mode with chmod", path, st.Uid, st.Gid, info.Mode().Perm(), os.Geteuid())
+    }
+    if enforce, _ := os.ReadFile("/sys/fs/selinux/enforce"); strings.TrimSpace(string(enforce)) == "1" {
+        return fmt.Sprintf("SELinux is enforcing and likely denied the write; check \"ausearch -m avc -ts recent\" and label "+
+            "the path, e.g. semanage fcontext -a -t var_log_t '%s(/.*)?' && restorecon -Rv %s", path, path)
+    }
+    if profile, _ := os.ReadFile("/proc/self/attr/current"); len(profile) > 0 {
+        if p := strings.TrimRight(string(profile), "\x00\n"); p != "unconfined" && !strings.Contains(p, ":") {
+            return fmt.Sprintf("the process is confined by the AppArmor profile %q, which likely denied the write; "+
+                "check the kernel log for apparmor=\"DENIED\" and allow \"%s/** rw,\" in the profile", p, strings.TrimSuffix(path, "/"))
+        }
+    }
+    return ""
+}
+
+// modeAllowsWrite reports whether the owner and mode allow the process to write the file, or to
+// create files in the directory.
+func modeAllowsWrite(st *syscall.Stat_t, dir bool) bool {
+    uid := os.Geteuid()
+    if uid == 0 {
+        return true
+    }
+    need := uint32(0o2)
+    if dir {
+        need |= 0o1
+    }
+    var shift uint
+    switch {
+    case uint32(uid) == st.Uid:
+        shift = 6
+    case inGroup(st.Gid):
+        shift = 3
+    }
+    return st.Mode>>shift&need == need
+}
+
+// inGroup reports whether the process belongs to the group.
+func inGroup(gid uint32) bool {
+    if uint32(os.Getegid()) == gid {
+        return true
+    }
+    groups, _ := os.Getgroups()
+    for _, g := range groups {
+        if uint32(g) == gid {
+            return true
+        }
+    }
+    return false
+}
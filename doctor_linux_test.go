@@ -0,0 +1,33 @@
+package logger_test
+
+import (
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestDoctorWriteProbe(t *testing.T) {
+    dir := t.TempDir()
+    report := logger.Doctor(logger.LogConfig{FilePath: filepath.Join(dir, "app.log")})
+    if !report.OK() || !strings.Contains(report.String(), "[OK] write probe") {
+        t.Errorf("Expected the write probe to pass, got:\n%s", report)
+    }
+    if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+        t.Errorf("Expected the probe file to be removed, got %v", entries)
+    }
+
+    if os.Geteuid() == 0 {
+        t.Skip("Permissions are not enforced for root")
+    }
+    if err := os.Chmod(dir, 0o500); err != nil {
+        t.Fatalf("Failed to change mode: %v", err)
+    }
+    defer os.Chmod(dir, 0o700)
+    err := logger.Validate(logger.LogConfig{FilePath: filepath.Join(dir, "app.log")})
+    if err == nil || !strings.Contains(err.Error(), "with mode -r-x------") || !strings.Contains(err.Error(), "chmod") {
+        t.Errorf("Expected a permission hint, got %v", err)
+    }
+}
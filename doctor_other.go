@@ -0,0 +1,9 @@
+//go:build !linux
+
+package logger
+
+// accessDeniedHint returns a generic hint: the owner, mode and security policies of the path are
+// not inspected on this platform.
+func accessDeniedHint(path string, dir bool) string {
+    return "check the owner and permissions of " + path
+}
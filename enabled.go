@@ -0,0 +1,93 @@
+package logger
+
+import "strings"
+
+// IsLevelEnabled reports whether an entry at the level would be recorded by the global logger,
+// see (*Logger).IsLevelEnabled.
+//
+// Arguments:
+//   - level (string): Level name.
+//
+// Returns:
+//   - (bool): Whether entries at the level are recorded.
+func IsLevelEnabled(level string) bool {
+    ensureLoggerInitialized()
+    return logInstance != nil && logInstance.IsLevelEnabled(level)
+}
+
+// TraceEnabled reports whether TRACE entries would be recorded by the global logger.
+func TraceEnabled() bool {
+    return IsLevelEnabled("trace")
+}
+
+// DebugEnabled reports whether DEBUG entries would be recorded by the global logger.
+func DebugEnabled() bool {
+    return IsLevelEnabled("debug")
+}
+
+// InfoEnabled reports whether INFO entries would be recorded by the global logger.
+func InfoEnabled() bool {
+    return IsLevelEnabled("info")
+}
+
+// WarningEnabled reports whether WARNING entries would be recorded by the global logger.
+func WarningEnabled() bool {
+    return IsLevelEnabled("warning")
+}
+
+// ErrorEnabled reports whether ERROR entries would be recorded by the global logger.
+func ErrorEnabled() bool {
+    return IsLevelEnabled("error")
+}
+
+// IsLevelEnabled reports whether an entry at the level would be recorded by the logger: written to
+// an output or routed by the sampler. It lets callers skip building expensive arguments:
+//
+//	if log.DebugEnabled() {
+//	    log.Debug("state: ", dump(state))
+//	}
+//
+// Entries that pass are still subject to message sampling, duplicate suppression and processors,
+// and severity escalation rules are not applied since they depend on the message. The ring buffer
+// is left out, since it records every level by default: it keeps the entries logged at disabled
+// levels, but not those the guard skipped.
+//
+// Arguments:
+//   - level (string): Level name, including custom levels.
+//
+// Returns:
+//   - (bool): Whether entries at the level are recorded, false for unknown levels.
+func (l *Logger) IsLevelEnabled(level string) bool {
+    level = strings.ToLower(level)
+    msgLevel, ok := l.LogLevelMap[level]
+    if l.nop || (!ok && level != "print") || l.suppressed() {
+        return false
+    }
+    passes, _, _, sampling := l.admit(level, msgLevel)
+    return passes || sampling
+}
+
+// TraceEnabled reports whether TRACE entries would be recorded by the logger.
+func (l *Logger) TraceEnabled() bool {
+    return l.IsLevelEnabled("trace")
+}
+
+// DebugEnabled reports whether DEBUG entries would be recorded by the logger.
+func (l *Logger) DebugEnabled() bool {
+    return l.IsLevelEnabled("debug")
+}
+
+// InfoEnabled reports whether INFO entries would be recorded by the logger.
+func (l *Logger) InfoEnabled() bool {
+    return l.IsLevelEnabled("info")
+}
+
+// WarningEnabled reports whether WARNING entries would be recorded by the logger.
+func (l *Logger) WarningEnabled() bool {
+    return l.IsLevelEnabled("warning")
+}
+
+// ErrorEnabled reports whether ERROR entries would be recorded by the logger.
+func (l *Logger) ErrorEnabled() bool {
+    return l.IsLevelEnabled("error")
+}
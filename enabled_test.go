@@ -0,0 +1,51 @@
+package logger_test
+
+import (
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestIsLevelEnabled(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info", ConsoleLevel: "warning"})
+
+    if !log.InfoEnabled() || !log.ErrorEnabled() || !log.IsLevelEnabled("WARNING") {
+        t.Errorf("Expected INFO and more severe levels to be enabled")
+    }
+    if log.DebugEnabled() || log.TraceEnabled() {
+        t.Errorf("Expected DEBUG and TRACE to be disabled with the file level INFO")
+    }
+    if log.IsLevelEnabled("verbose") {
+        t.Errorf("Expected an unknown level to be disabled")
+    }
+
+    built := false
+    if log.DebugEnabled() {
+        built = true
+        log.Debug("Expensive payload")
+    }
+    if built || strings.Contains(read(), "Expensive payload") {
+        t.Errorf("Expected the debug payload to be skipped")
+    }
+
+    if err := log.SetFileLevel(logger.DebugLevel); err != nil {
+        t.Fatalf("Failed to set the file level: %v", err)
+    }
+    if !log.DebugEnabled() {
+        t.Errorf("Expected DEBUG to be enabled after SetFileLevel")
+    }
+}
+
+func TestIsLevelEnabledRingBuffer(t *testing.T) {
+    log, _ := newFileLogger(t, logger.LogConfig{FileLevel: "info", RingBufferSize: 10})
+
+    if log.DebugEnabled() || log.TraceEnabled() {
+        t.Errorf("Expected the ring buffer to leave DEBUG and TRACE disabled")
+    }
+    log.Debugw("Sugared", "key", "value")
+    entries := log.RecentEntries()
+    if len(entries) != 1 || entries[0].Message != "Sugared" || entries[0].Fields["key"] != "value" {
+        t.Errorf("Expected the sugared entry in the ring buffer, got %v", entries)
+    }
+}
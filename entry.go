@@ -0,0 +1,257 @@
+package logger
+
+import (
+    "encoding/json"
+    "fmt"
+    "sort"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+)
+
+// Entry is a single log event. It is built once per call and rendered separately
+// for each output, so the file and the console can use different formats.
+type Entry struct {
+    Time    time.Time // Time the entry was logged.
+    Level   string    // Lowercase level name, or "print".
+    Message string    // Log message.
+    PID     int       // Process ID.
+    File    string    // Caller file, relative to the project directory.
+    Line    int       // Caller line.
+    Fields  Fields    // Structured fields.
+    pooled  bool      // Whether Fields is a pooled map, returned to the pool once the entry is written.
+}
+
+// entryFieldsSize is the largest number of fields the logger adds to an entry: the thread fields,
+// the entry ID, the sampling fields and the stack trace.
+const entryFieldsSize = 7
+
+// maxPooledLine is the capacity above which line buffers are not returned to the pool.
+const maxPooledLine = 64 * 1024
+
+// maxPooledFields is the number of fields above which entry field maps are not returned to the pool.
+const maxPooledFields = 64
+
+// lineBuffers pools the buffers entries are rendered into. Rendered lines never leave the logger:
+// outputs copy them on write, so a buffer is reused as soon as the writes return.
+var lineBuffers = sync.Pool{
+    New: func() interface{} {
+        buf := make([]byte, 0, 512)
+        return &buf
+    },
+}
+
+// entryFields pools the maps of the fields added to each entry. A pooled map is returned to the pool
+// once the entry is written; the ring buffer, hooks, subscribers, the async queue, batches and custom
+// sinks keep entries longer, and get a copy of the fields from retain.
+var entryFields = sync.Pool{
+    New: func() interface{} {
+        return make(Fields, entryFieldsSize)
+    },
+}
+
+// getEntryFields returns an empty fields map from the pool.
+func getEntryFields() Fields {
+    return entryFields.Get().(Fields)
+}
+
+// putEntryFields empties the fields map and returns it to the pool, unless it grew too large.
+func putEntryFields(fields Fields) {
+    if len(fields) <= maxPooledFields {
+        clear(fields)
+        entryFields.Put(fields)
+    }
+}
+
+// retain returns the entry with its own copy of pooled fields, for keeping it after it is written.
+func (e Entry) retain() Entry {
+    if e.pooled {
+        e.Fields = e.CloneFields()
+        e.pooled = false
+    }
+    return e
+}
+
+// getLineBuffer returns an empty buffer from the pool.
+func getLineBuffer() *[]byte {
+    buf := lineBuffers.Get().(*[]byte)
+    *buf = (*buf)[:0]
+    return buf
+}
+
+// putLineBuffer returns the buffer to the pool, unless it grew too large.
+func putLineBuffer(buf *[]byte) {
+    if cap(*buf) <= maxPooledLine {
+        lineBuffers.Put(buf)
+    }
+}
+
+// FormatPrettyJSON is the format rendering entries as indented JSON objects with sorted keys and
+// colored keys, one key per line, for reading JSON entries in a terminal. It is meant for ConsoleFormat
+// while the file keeps single-line JSON.
+const FormatPrettyJSON = "json-pretty"
+
+// appendFormat appends the entry rendered in the given format, "json", "json-pretty" or "standard", to buf.
+// The standard format shows the level names of names instead of the upper-case levels they replace.
+func (e Entry) appendFormat(buf []byte, format string, names map[string]string) []byte {
+    if strings.EqualFold(format, "json") {
+        return append(buf, e.formatJSON()...)
+    }
+    if strings.EqualFold(format, FormatPrettyJSON) {
+        return e.appendPrettyJSON(buf, nil)
+    }
+    return e.appendStandard(buf, names)
+}
+
+// appendStandard appends the entry rendered as a human-readable line to buf, without allocating
+// for entries without fields.
+func (e Entry) appendStandard(buf []byte, names map[string]string) []byte {
+    buf = append(buf, '[')
+    buf = e.Time.AppendFormat(buf, time.RFC3339)
+    buf = append(buf, "] [PID: "...)
+    buf = strconv.AppendInt(buf, int64(e.PID), 10)
+    buf = append(buf, "] ["...)
+    buf = append(buf, e.File...)
+    buf = append(buf, ':')
+    buf = strconv.AppendInt(buf, int64(e.Line), 10)
+    buf = append(buf, "] ["...)
+    if name, ok := names[e.Level]; ok {
+        buf = append(buf, name...)
+    } else {
+        buf = appendUpper(buf, e.Level)
+    }
+    buf = append(buf, "] "...)
+    buf = append(buf, e.Message...)
+    if len(e.Fields) == 0 {
+        return buf
+    }
+    errorStack, hasErrorStack := e.Fields[ErrorStackField].(string)
+    stack, hasStack := e.Fields[StackTraceField].(string)
+    // Stack traces follow the fields as indented blocks
+    var skip [2]string
+    if hasStack {
+        skip[0] = StackTraceField
+    }
+    if hasErrorStack {
+        skip[1] = ErrorStackField
+    }
+    buf = appendFields(buf, e.Fields, skip[:]...)
+    if hasErrorStack {
+        buf = appendStackTrace(buf, errorStack)
+    }
+    if hasStack {
+        buf = appendStackTrace(buf, stack)
+    }
+    return buf
+}
+
+// appendUpper appends the upper-case form of s to buf.
+func appendUpper(buf []byte, s string) []byte {
+    for i := 0; i < len(s); i++ {
+        if s[i] >= 0x80 {
+            return append(buf, strings.ToUpper(s)...)
+        }
+    }
+    for i := 0; i < len(s); i++ {
+        c := s[i]
+        if 'a' <= c && c <= 'z' {
+            c -= 'a' - 'A'
+        }
+        buf = append(buf, c)
+    }
+    return buf
+}
+
+// formatJSON renders the entry as a single JSON object. Fields never override the built-in keys.
+func (e Entry) formatJSON() string {
+    data := e.jsonData()
+    jsonBytes, err := json.Marshal(data)
+    if err != nil {
+        // Fields that cannot be encoded, such as NaN or channels, are written as strings
+        for key, value := range data {
+            if _, isField := e.Fields[key]; isField && !reservedJSONKey(key) {
+                data[key] = fmt.Sprint(value)
+            }
+        }
+        if jsonBytes, err = json.Marshal(data); err != nil {
+            jsonBytes, _ = json.Marshal(map[string]interface{}{"timestamp": data["timestamp"], "level": e.Level, "message": e.Message, "encode_error": err.Error()})
+        }
+    }
+    return string(jsonBytes)
+}
+
+// reservedJSONKey reports whether the key is a built-in key of the JSON rendering.
+func reservedJSONKey(key string) bool {
+    switch key {
+    case "timestamp", "level", "pid", "file", "line", "message":
+        return true
+    }
+    return false
+}
+
+// appendPrettyJSON appends the entry rendered as an indented JSON object with sorted keys to buf.
+// With a theme, keys are styled and the level is styled by severity.
+func (e Entry) appendPrettyJSON(buf []byte, theme *consoleTheme) []byte {
+    data := e.jsonData()
+    keys := make([]string, 0, len(data))
+    for key := range data {
+        keys = append(keys, key)
+    }
+    sort.Strings(keys)
+
+    buf = append(buf, '{')
+    for i, key := range keys {
+        if i > 0 {
+            buf = append(buf, ',')
+        }
+        buf = append(buf, "\n  "...)
+        name, _ := json.Marshal(key)
+        value, err := json.MarshalIndent(data[key], "  ", "  ")
+        if err != nil {
+            value, _ = json.Marshal(fmt.Sprint(data[key]))
+        }
+        buf = theme.appendStyled(buf, e.Level, true, name)
+        buf = append(buf, ": "...)
+        if key == "level" {
+            buf = theme.appendStyled(buf, e.Level, false, value)
+        } else {
+            buf = append(buf, value...)
+        }
+    }
+    return append(buf, "\n}"...)
+}
+
+// jsonData returns the keys and values of the JSON rendering of the entry.
+func (e Entry) jsonData() map[string]interface{} {
+    logData := map[string]interface{}{
+        "timestamp": e.Time.Format(time.RFC3339),
+        "level":     e.Level,
+        "pid":       e.PID,
+        "file":      e.File,
+        "line":      e.Line,
+        "message":   e.Message,
+    }
+    for key, value := range e.Fields {
+        if !reservedJSONKey(key) {
+            logData[key] = value
+        }
+    }
+    return logData
+}
+
+// fileFormat returns the format used for file output.
+func (l *Logger) fileFormat() string {
+    if l.Config.FileFormat != "" {
+        return l.Config.FileFormat
+    }
+    return l.Config.Format
+}
+
+// consoleFormat returns the format used for console output.
+func (l *Logger) consoleFormat() string {
+    if l.Config.ConsoleFormat != "" {
+        return l.Config.ConsoleFormat
+    }
+    return l.Config.Format
+}
@@ -0,0 +1,93 @@
+package logger_test
+
+import (
+    "bytes"
+    "encoding/json"
+    "io"
+    "math"
+    "os"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestPerOutputFormats(t *testing.T) {
+    originalStdout := os.Stdout
+    r, w, _ := os.Pipe()
+    os.Stdout = w
+
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileFormat:    "json",
+        ConsoleFormat: "standard",
+        FileLevel:     "info",
+        ConsoleLevel:  "info",
+        ConsoleOutput: true,
+    })
+    log.WithField("user", "bob").Info("Hybrid message")
+
+    w.Close()
+    os.Stdout = originalStdout
+    var consoleOutput bytes.Buffer
+    io.Copy(&consoleOutput, r)
+
+    var entry map[string]interface{}
+    if err := json.Unmarshal([]byte(strings.TrimSpace(read())), &entry); err != nil {
+        t.Fatalf("Expected a JSON line in the file: %v", err)
+    }
+    if entry["message"] != "Hybrid message" || entry["user"] != "bob" {
+        t.Errorf("Unexpected JSON entry: %v", entry)
+    }
+
+    console := consoleOutput.String()
+    if !strings.Contains(console, "[INFO] Hybrid message user=bob") {
+        t.Errorf("Expected standard format on console, got '%s'", console)
+    }
+}
+
+func TestPrettyJSONConsole(t *testing.T) {
+    noColor := logger.NoColor
+    logger.NoColor = false
+    defer func() { logger.NoColor = noColor }()
+
+    log, read := newFileLogger(t, logger.LogConfig{
+        Format:        "json",
+        ConsoleFormat: logger.FormatPrettyJSON,
+        FileLevel:     "info",
+        ConsoleLevel:  "info",
+        ConsoleOutput: true,
+    })
+    var console bytes.Buffer
+    log.ConsoleLogger.SetOutput(&console)
+    log.WithField("tags", []string{"a", "b"}).Warning("Pretty message")
+
+    if file := strings.TrimSpace(read()); strings.Contains(file, "\n") || strings.Contains(file, "\x1b[") {
+        t.Errorf("Expected single-line JSON without colors in the file, got '%s'", file)
+    }
+
+    out := console.String()
+    for _, expected := range []string{
+        "{\n  \x1b[36m\"file\"\x1b[0m: ",
+        "\x1b[36m\"level\"\x1b[0m: \x1b[33m\"warning\"\x1b[0m,\n",
+        "\x1b[36m\"message\"\x1b[0m: \"Pretty message\",\n",
+        "\x1b[36m\"tags\"\x1b[0m: [\n    \"a\",\n    \"b\"\n  ],\n",
+        "\n}\n",
+    } {
+        if !strings.Contains(out, expected) {
+            t.Errorf("Expected %q in the console output, got %q", expected, out)
+        }
+    }
+}
+
+func TestJSONUnencodableField(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{Format: "json", FileLevel: "info"})
+    log.WithFields(logger.Fields{"ratio": math.NaN(), "done": make(chan int), "user": "bob"}).Info("Odd fields")
+
+    var entry map[string]interface{}
+    if err := json.Unmarshal([]byte(strings.TrimSpace(read())), &entry); err != nil {
+        t.Fatalf("Expected a JSON line in the file: %v", err)
+    }
+    if entry["message"] != "Odd fields" || entry["ratio"] != "NaN" || entry["user"] != "bob" {
+        t.Errorf("Expected the fields that cannot be encoded as strings, got %v", entry)
+    }
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+    "errors"
+    "fmt"
+    "os"
+)
+
+// ErrorStackField is the name of the field holding the detailed form of an error with a stack trace,
+// such as the errors of github.com/pkg/errors, see (*Logger).WithError.
+const ErrorStackField = "error_stack"
+
+// WithError returns a logger based on the global logger that adds the error to every entry,
+// see (*Logger).WithError.
+//
+// Arguments:
+//   - err (error): Error to add.
+//
+// Returns:
+//   - (*Logger): Logger with the error fields.
+func WithError(err error) *Logger {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return nil
+    }
+    return logInstance.WithError(err)
+}
+
+// WithError returns a copy of the logger that adds the error to every entry: its message as the
+// "error" field, the messages of its chain down to the root cause as the "causes" array and its type
+// as the "error_type" field. If an error of the chain formats itself with %+v differently than its
+// message, like the errors of github.com/pkg/errors recording a stack trace, that form is added as the
+// "error_stack" field, shown as an indented block in the standard format. A nil error adds no fields.
+//
+// Example usage:
+//
+//	log.WithError(err).Error("Failed to load the configuration")
+//
+// Arguments:
+//   - err (error): Error to add.
+//
+// Returns:
+//   - (*Logger): Logger with the error fields.
+func (l *Logger) WithError(err error) *Logger {
+    if err == nil {
+        return l.WithFields(nil)
+    }
+    fields := Fields{
+        "error":      err.Error(),
+        "causes":     errorChain(err),
+        "error_type": fmt.Sprintf("%T", err),
+    }
+    // The outermost error of the chain with a detailed form, e.g. inside errors wrapped with fmt.Errorf
+    for e := err; e != nil; e = errors.Unwrap(e) {
+        if _, ok := e.(fmt.Formatter); !ok {
+            continue
+        }
+        if detailed := fmt.Sprintf("%+v", e); detailed != e.Error() {
+            fields[ErrorStackField] = detailed
+            break
+        }
+    }
+    return l.WithFields(fields)
+}
+
+// FatalErr logs a message at the FATAL level with the error chain of err and terminates the application.
+// See (*Logger).FatalErr.
+//
+// Arguments:
+//   - err (error): Error that caused the termination.
+//   - msg (string): Message to log.
+func FatalErr(err error, msg string) {
+    ensureLoggerInitialized()
+    if logInstance != nil {
+        logInstance.fatalErr(4, err, msg)
+    }
+    os.Exit(1)
+}
+
+// FatalErr logs a message at the FATAL level and terminates the application. The error is added
+// to the entry as the "error" field, and every error of its chain, from err itself down to the
+// root cause, as the "causes" array, so the whole causal story is recorded by a single call.
+//
+// Arguments:
+//   - err (error): Error that caused the termination.
+//   - msg (string): Message to log.
+func (l *Logger) FatalErr(err error, msg string) {
+    l.fatalErr(3, err, msg)
+    os.Exit(1)
+}
+
+// fatalErr logs the FATAL entry of FatalErr, reporting the caller found skip frames above logSkip.
+func (l *Logger) fatalErr(skip int, err error, msg string) {
+    fields := Fields{}
+    if err != nil {
+        fields["error"] = err.Error()
+        fields["causes"] = errorChain(err)
+    }
+    l.WithFields(fields).logSkip(skip, "fatal", msg)
+}
+
+// errorChain returns the messages of err and of all errors it wraps, depth first.
+// Errors joined with errors.Join or wrapping several errors with fmt.Errorf are all included.
+func errorChain(err error) []string {
+    var chain []string
+    var walk func(err error)
+    walk = func(err error) {
+        if err == nil {
+            return
+        }
+        chain = append(chain, err.Error())
+        switch e := err.(type) {
+        case interface{ Unwrap() []error }:
+            for _, inner := range e.Unwrap() {
+                walk(inner)
+            }
+        default:
+            walk(errors.Unwrap(err))
+        }
+    }
+    walk(err)
+    return chain
+}
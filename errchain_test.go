@@ -0,0 +1,108 @@
+package logger_test
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestFatalErrCauseChain(t *testing.T) {
+    if path := os.Getenv("LOGGER_FATAL_ERR_FILE"); path != "" {
+        logger.InitLogger(logger.LogConfig{FilePath: path, FileFormat: "json", FileLevel: "info"})
+        root := errors.New("connection refused")
+        err := fmt.Errorf("load config: %w", fmt.Errorf("fetch remote: %w", root))
+        logger.FatalErr(err, "Startup failed")
+        return
+    }
+
+    path := filepath.Join(t.TempDir(), "fatal.log")
+    cmd := exec.Command(os.Args[0], "-test.run=^TestFatalErrCauseChain$")
+    cmd.Env = append(os.Environ(), "LOGGER_FATAL_ERR_FILE="+path)
+    if err := cmd.Run(); err == nil {
+        t.Fatalf("Expected the process to exit with an error")
+    }
+
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatalf("Failed to read log file: %v", err)
+    }
+    var record struct {
+        Level   string   `json:"level"`
+        Message string   `json:"message"`
+        Error   string   `json:"error"`
+        Causes  []string `json:"causes"`
+    }
+    if err := json.Unmarshal(data, &record); err != nil {
+        t.Fatalf("Failed to parse entry '%s': %v", data, err)
+    }
+    expected := []string{
+        "load config: fetch remote: connection refused",
+        "fetch remote: connection refused",
+        "connection refused",
+    }
+    if record.Level != "fatal" || record.Message != "Startup failed" || record.Error != expected[0] {
+        t.Errorf("Unexpected entry: %+v", record)
+    }
+    if fmt.Sprint(record.Causes) != fmt.Sprint(expected) {
+        t.Errorf("Expected causes %q, got %q", expected, record.Causes)
+    }
+}
+
+// stackError formats itself with a stack trace like the errors of github.com/pkg/errors.
+type stackError struct {
+    msg string
+}
+
+func (e *stackError) Error() string {
+    return e.msg
+}
+
+func (e *stackError) Format(s fmt.State, verb rune) {
+    if verb == 'v' && s.Flag('+') {
+        fmt.Fprintf(s, "%s\nmain.load\n\t/app/main.go:42", e.msg)
+        return
+    }
+    fmt.Fprint(s, e.msg)
+}
+
+func TestWithError(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileFormat: "json", FileLevel: "info", StackTraceLevel: logger.StackTraceNone})
+    err := fmt.Errorf("load config: %w", &stackError{msg: "file not found"})
+    log.WithError(err).Error("Startup failed")
+
+    var entry map[string]interface{}
+    if err := json.Unmarshal([]byte(read()), &entry); err != nil {
+        t.Fatalf("Failed to parse entry: %v", err)
+    }
+    causes, _ := json.Marshal(entry["causes"])
+    if entry["error"] != "load config: file not found" || entry["error_type"] != "*fmt.wrapError" ||
+        string(causes) != `["load config: file not found","file not found"]` {
+        t.Errorf("Unexpected error fields: %v", entry)
+    }
+    // The detailed form is found inside the wrapping error
+    if entry[logger.ErrorStackField] != "file not found\nmain.load\n\t/app/main.go:42" {
+        t.Errorf("Expected the error stack of the wrapped error, got %q", entry[logger.ErrorStackField])
+    }
+}
+
+func TestWithErrorStack(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info", StackTraceLevel: logger.StackTraceNone})
+    log.WithError(&stackError{msg: "file not found"}).Error("Startup failed")
+    log.WithError(nil).Info("No error")
+
+    lines := strings.Split(strings.TrimSpace(read()), "\n")
+    if len(lines) != 5 || !strings.Contains(lines[0], "error_type=*logger_test.stackError") ||
+        lines[1] != "\tfile not found" || lines[2] != "\tmain.load" || lines[3] != "\t\t/app/main.go:42" {
+        t.Errorf("Expected the error stack as an indented block, got %q", lines)
+    }
+    if !strings.HasSuffix(lines[4], "[INFO] No error") {
+        t.Errorf("Expected no error fields for a nil error, got %q", lines[4])
+    }
+}
@@ -0,0 +1,149 @@
+package logger
+
+import (
+    "fmt"
+    "io"
+    "runtime/debug"
+    "strings"
+    "sync"
+)
+
+// ErrorHandler receives errors of the logger itself, such as failed syncs or rotations of the log
+// file and panics recovered from processors, samplers, formatters and outputs, with the name of the
+// component that failed.
+type ErrorHandler func(component string, err error)
+
+// PanicError is the error reported to the ErrorHandler for a recovered panic.
+type PanicError struct {
+    Value interface{} // Value passed to panic.
+    Stack []byte      // Stack trace of the panicking goroutine.
+}
+
+// Error returns the panic value.
+func (e *PanicError) Error() string {
+    return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// SinkError is the error of a sink failing to write an entry.
+type SinkError struct {
+    Sink string // Name of the sink.
+    Err  error  // Write error of the sink.
+}
+
+// Error returns the name of the sink with its error.
+func (e *SinkError) Error() string {
+    return e.Sink + ": " + e.Err.Error()
+}
+
+// Unwrap returns the write error of the sink.
+func (e *SinkError) Unwrap() error {
+    return e.Err
+}
+
+// OutputError aggregates the errors of the sinks that failed to write an entry, or the entries
+// of a batch. It is reported to the ErrorHandler as the "outputs" component, once per entry or
+// batch, so that a failure of several sinks is seen as one event with the identity of each sink.
+type OutputError struct {
+    Errors []*SinkError // Errors by sink, in the order of the sinks.
+}
+
+// Error lists the errors of the sinks.
+func (e *OutputError) Error() string {
+    parts := make([]string, len(e.Errors))
+    for i, err := range e.Errors {
+        parts[i] = err.Error()
+    }
+    return fmt.Sprintf("failed to write to %d sink(s): %s", len(e.Errors), strings.Join(parts, "; "))
+}
+
+// Unwrap returns the errors of the sinks, for errors.Is and errors.As.
+func (e *OutputError) Unwrap() []error {
+    errs := make([]error, len(e.Errors))
+    for i, err := range e.Errors {
+        errs[i] = err
+    }
+    return errs
+}
+
+// outputErrors collects the write errors of the sinks of an entry or a batch.
+type outputErrors []*SinkError
+
+// add records the error of the sink, ignoring nil.
+func (o *outputErrors) add(s Sink, err error) {
+    if err != nil {
+        *o = append(*o, &SinkError{Sink: s.Name(), Err: err})
+    }
+}
+
+// report passes the collected errors to the error handler as a single OutputError.
+func (o outputErrors) report() {
+    if len(o) > 0 {
+        reportError("outputs", &OutputError{Errors: o})
+    }
+}
+
+// Registered error handler, nil for the default one printing errors to the console, and the writer
+// of the default handler, nil for stdout.
+var (
+    errorHandlerMu sync.RWMutex
+    errorHandler   ErrorHandler
+    errorOutput    io.Writer
+)
+
+// setErrorOutput sets the writer of the default error handler, nil for stdout.
+func setErrorOutput(w io.Writer) {
+    errorHandlerMu.Lock()
+    defer errorHandlerMu.Unlock()
+    errorOutput = w
+}
+
+// errorWriter returns the writer of the default error handler: ErrorOutput if set, otherwise the
+// console writer, so that errors are not fed back into the logger while stdio is captured.
+func errorWriter() io.Writer {
+    errorHandlerMu.RLock()
+    w := errorOutput
+    errorHandlerMu.RUnlock()
+    if w == nil {
+        return consoleWriter()
+    }
+    return w
+}
+
+// SetErrorHandler sets the handler receiving errors of the logger itself. By default they are
+// printed to the console, or written to the diagnostics file if LogConfig.Diagnostics is set.
+//
+// Arguments:
+//   - h (ErrorHandler): Error handler, nil to restore the default.
+func SetErrorHandler(h ErrorHandler) {
+    errorHandlerMu.Lock()
+    defer errorHandlerMu.Unlock()
+    errorHandler = h
+}
+
+// reportError passes an error of the component to the error handler.
+func reportError(component string, err error) {
+    errorHandlerMu.RLock()
+    h := errorHandler
+    errorHandlerMu.RUnlock()
+    if h == nil {
+        if diagnose(1, ErrorLevel, component, err.Error()) {
+            return
+        }
+        fmt.Fprintf(errorWriter(), "Logger %s error: %v\n", component, err)
+        return
+    }
+    h(component, err)
+}
+
+// guard runs fn, recovering a panic and reporting it as a PanicError of the component, so that a
+// buggy processor or output never crashes the application. It reports whether fn completed.
+func guard(component string, fn func()) (ok bool) {
+    defer func() {
+        if r := recover(); r != nil {
+            reportError(component, &PanicError{Value: r, Stack: debug.Stack()})
+            ok = false
+        }
+    }()
+    fn()
+    return true
+}
@@ -0,0 +1,102 @@
+package logger_test
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "sync"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+type panickingValue struct{}
+
+func (panickingValue) MarshalJSON() ([]byte, error) {
+    panic("broken marshaler")
+}
+
+func TestPanicIsolation(t *testing.T) {
+    var mu sync.Mutex
+    var components []string
+    logger.SetErrorHandler(func(component string, err error) {
+        var panicErr *logger.PanicError
+        if !errors.As(err, &panicErr) {
+            t.Errorf("Expected a PanicError, got %v", err)
+        }
+        mu.Lock()
+        components = append(components, component)
+        mu.Unlock()
+    })
+    defer logger.SetErrorHandler(nil)
+
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel:  "info",
+        FileFormat: "json",
+        Processors: []logger.Processor{
+            logger.ReplaceMessage(nil, ""), // Panics on the nil pattern.
+        },
+        Sampler: func(ctx context.Context, e logger.Entry) bool {
+            panic("broken sampler")
+        },
+    })
+
+    log.Info("Survives the processor")
+    log.WithField("value", panickingValue{}).Info("Broken field")
+    log.WithContext(context.Background()).Info("Dropped by the sampler")
+
+    content := read()
+    if !strings.Contains(content, "Survives the processor") {
+        t.Errorf("Expected the entry to be logged despite the panicking processor, got '%s'", content)
+    }
+    if strings.Contains(content, "Broken field") || strings.Contains(content, "Dropped by the sampler") {
+        t.Errorf("Expected entries failing to format or sample to be dropped, got '%s'", content)
+    }
+    expected := "processor 1,processor 1,file formatter,processor 1,sampler"
+    if got := strings.Join(components, ","); got != expected {
+        t.Errorf("Expected panics of %s, got %s", expected, got)
+    }
+}
+
+// brokenSink is a custom sink failing every write.
+type brokenSink struct{}
+
+func (brokenSink) Name() string                         { return "siem" }
+func (brokenSink) Enabled(level string, value int) bool { return true }
+func (brokenSink) WriteEntry(e logger.Entry) error      { return errors.New("connection refused") }
+
+func TestOutputErrorAggregation(t *testing.T) {
+    var reported []error
+    var components []string
+    logger.SetErrorHandler(func(component string, err error) {
+        components = append(components, component)
+        reported = append(reported, err)
+    })
+    defer logger.SetErrorHandler(nil)
+
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel: "info",
+        Sinks: []logger.SinkConfig{
+            {Name: "archive", Writer: failingWriter{}, Level: "info", Default: true},
+            {Name: "siem", Sink: brokenSink{}, Default: true},
+        },
+    })
+    log.Info("Payment accepted")
+
+    if !strings.Contains(read(), "Payment accepted") {
+        t.Errorf("Expected the working file output to be written")
+    }
+    if len(reported) != 1 || components[0] != "outputs" {
+        t.Fatalf("Expected a single report of the outputs, got %v %v", components, reported)
+    }
+    var outputErr *logger.OutputError
+    if !errors.As(reported[0], &outputErr) || len(outputErr.Errors) != 2 {
+        t.Fatalf("Expected an OutputError with two sinks, got %v", reported[0])
+    }
+    if outputErr.Errors[0].Sink != "archive" || outputErr.Errors[1].Sink != "siem" {
+        t.Errorf("Expected the errors of archive and siem, got %v", outputErr)
+    }
+    if msg := outputErr.Error(); !strings.Contains(msg, "archive: disk full") || !strings.Contains(msg, "siem: connection refused") {
+        t.Errorf("Unexpected error message: %s", msg)
+    }
+}
@@ -0,0 +1,137 @@
+package logger
+
+import (
+    "fmt"
+    "regexp"
+    "strconv"
+    "strings"
+)
+
+// EscalationRule raises the severity of entries matching a field condition and/or a message pattern,
+// centralizing escalation policy instead of scattering it across call sites. For example
+// {Condition: "retry_count>5", Level: "error"} turns any entry with a retry_count field above 5 into an error.
+// Rules never lower the severity of an entry, and they are evaluated before level filtering,
+// so an escalated entry reaches outputs whose level would have filtered it out.
+type EscalationRule struct {
+    // Condition compares a field with a value as "field<op>value", where op is one of
+    // ==, !=, >, >=, < and <=. Values are compared as numbers when both are numeric, otherwise
+    // as strings (only == and != apply). Empty to match entries regardless of their fields.
+    Condition string
+    Pattern   string // Regular expression matched against the message, empty to match any message.
+    Level     string // Level the matching entries are escalated to, other than "fatal".
+}
+
+// escalation is a compiled EscalationRule.
+type escalation struct {
+    condition
+    pattern *regexp.Regexp
+    level   string
+    slot    int
+}
+
+// Comparison operators of escalation conditions, two-character operators first.
+var conditionOps = []string{"==", "!=", ">=", "<=", ">", "<"}
+
+// compileEscalations validates and compiles the rules against the level map of the logger.
+func compileEscalations(rules []EscalationRule, levelMap map[string]int) ([]escalation, error) {
+    var compiled []escalation
+    for i, rule := range rules {
+        level := strings.ToLower(rule.Level)
+        slot, ok := levelMap[level]
+        if !ok || level == "fatal" {
+            return nil, fmt.Errorf("escalation rule %d: invalid level %q", i+1, rule.Level)
+        }
+        e := escalation{level: level, slot: slot}
+        if rule.Condition == "" && rule.Pattern == "" {
+            return nil, fmt.Errorf("escalation rule %d: condition or pattern is required", i+1)
+        }
+        if rule.Condition != "" {
+            c, err := parseCondition(rule.Condition)
+            if err != nil {
+                return nil, fmt.Errorf("escalation rule %d: %v", i+1, err)
+            }
+            e.condition = c
+        }
+        if rule.Pattern != "" {
+            pattern, err := regexp.Compile(rule.Pattern)
+            if err != nil {
+                return nil, fmt.Errorf("escalation rule %d: invalid pattern: %v", i+1, err)
+            }
+            e.pattern = pattern
+        }
+        compiled = append(compiled, e)
+    }
+    return compiled, nil
+}
+
+// matches reports whether an entry with the message and fields matches the rule.
+func (e escalation) matches(message string, fields Fields) bool {
+    if e.pattern != nil && !e.pattern.MatchString(message) {
+        return false
+    }
+    return e.condition.matches(fields)
+}
+
+// condition is a parsed "field<op>value" field condition.
+type condition struct {
+    field string
+    op    string
+    value string
+}
+
+// parseCondition parses a field condition such as "retry_count>5".
+func parseCondition(s string) (condition, error) {
+    for _, op := range conditionOps {
+        if field, value, found := strings.Cut(s, op); found && strings.TrimSpace(field) != "" {
+            return condition{field: strings.TrimSpace(field), op: op, value: strings.TrimSpace(value)}, nil
+        }
+    }
+    return condition{}, fmt.Errorf("invalid condition %q, expected e.g. \"retry_count>5\"", s)
+}
+
+// matches reports whether the fields satisfy the condition. The zero condition matches any fields.
+func (c condition) matches(fields Fields) bool {
+    if c.field == "" {
+        return true
+    }
+    value, ok := fields[c.field]
+    if !ok {
+        return false
+    }
+    actual := fmt.Sprint(value)
+    a, errA := strconv.ParseFloat(actual, 64)
+    b, errB := strconv.ParseFloat(c.value, 64)
+    if errA != nil || errB != nil {
+        switch c.op {
+        case "==":
+            return actual == c.value
+        case "!=":
+            return actual != c.value
+        }
+        return false
+    }
+    switch c.op {
+    case "==":
+        return a == b
+    case "!=":
+        return a != b
+    case ">":
+        return a > b
+    case ">=":
+        return a >= b
+    case "<":
+        return a < b
+    default:
+        return a <= b
+    }
+}
+
+// escalate returns the most severe level among the level of the entry and the matching rules.
+func (l *Logger) escalate(level string, slot int, message string, fields Fields) (string, int) {
+    for _, e := range l.escalations {
+        if e.slot < slot && e.matches(message, fields) {
+            level, slot = e.level, e.slot
+        }
+    }
+    return level, slot
+}
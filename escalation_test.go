@@ -0,0 +1,56 @@
+package logger_test
+
+import (
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestEscalationRules(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel: "warning",
+        Escalations: []logger.EscalationRule{
+            {Condition: "retry_count>5", Level: "error"},
+            {Pattern: "(?i)disk (full|failure)", Level: "warning"},
+            {Condition: "status==timeout", Pattern: "^Request", Level: "warning"},
+        },
+    })
+
+    log.WithField("retry_count", 6).Info("Retrying upload")
+    log.WithField("retry_count", 2).Info("Retrying download")
+    log.Debug("Disk full on /var")
+    log.WithField("status", "timeout").Info("Request aborted")
+    log.WithField("status", "timeout").Info("Job aborted")
+    log.WithField("retry_count", 9).Error("Already an error")
+
+    content := read()
+    for _, expected := range []string{
+        "[ERROR] Retrying upload",
+        "[WARNING] Disk full on /var",
+        "[WARNING] Request aborted",
+        "[ERROR] Already an error",
+    } {
+        if !strings.Contains(content, expected) {
+            t.Errorf("Expected log to contain '%s', got '%s'", expected, content)
+        }
+    }
+    for _, unexpected := range []string{"Retrying download", "Job aborted"} {
+        if strings.Contains(content, unexpected) {
+            t.Errorf("Expected log not to contain '%s', got '%s'", unexpected, content)
+        }
+    }
+}
+
+func TestInvalidEscalationRules(t *testing.T) {
+    for _, rule := range []logger.EscalationRule{
+        {Condition: "retry_count>5", Level: "fatal"},
+        {Condition: "retry_count", Level: "error"},
+        {Pattern: "(", Level: "error"},
+        {Level: "error"},
+    } {
+        if _, err := logger.NewLogger(logger.LogConfig{Escalations: []logger.EscalationRule{rule}}); err == nil {
+            t.Errorf("Expected an error for rule %+v", rule)
+        }
+    }
+}
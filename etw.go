@@ -0,0 +1,62 @@
+package logger
+
+import (
+    "encoding/hex"
+    "fmt"
+    "strings"
+)
+
+// ETWConfig configures the ETW output, which writes entries as events of an Event Tracing for
+// Windows provider, collected with tools such as logman, PerfView or Windows Performance Recorder.
+// Levels map to ETW levels: FATAL to critical, ERROR to error, WARNING to warning, INFO to
+// informational and DEBUG and TRACE to verbose. The output is only available on 64-bit Windows.
+type ETWConfig struct {
+    Provider string      // GUID of the provider, e.g. "5eea2d3b-6a17-4bc4-a27a-2d3c1b8d8b39".
+    Level    interface{} // Log level of the ETW output: can be a Level, a string or a number (default: "info").
+}
+
+// etwSinkName is the name of the ETW output in routing rules.
+const etwSinkName = "etw"
+
+// etwGUID is the layout of a Windows GUID.
+type etwGUID struct {
+    Data1 uint32
+    Data2 uint16
+    Data3 uint16
+    Data4 [8]byte
+}
+
+// parseGUID parses a GUID in the "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx" form, optionally in braces.
+func parseGUID(s string) (etwGUID, error) {
+    var g etwGUID
+    hexDigits := strings.ReplaceAll(strings.Trim(s, "{}"), "-", "")
+    b, err := hex.DecodeString(hexDigits)
+    if err != nil || len(b) != 16 || len(strings.Trim(s, "{}")) != 36 {
+        return g, fmt.Errorf("invalid ETW provider GUID %q", s)
+    }
+    g.Data1 = uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+    g.Data2 = uint16(b[4])<<8 | uint16(b[5])
+    g.Data3 = uint16(b[6])<<8 | uint16(b[7])
+    copy(g.Data4[:], b[8:])
+    return g, nil
+}
+
+// etwLevel returns the ETW level of a level, by its numeric value for custom levels.
+func etwLevel(level string) uint8 {
+    switch severity(level) {
+    case 2:
+        return 1 // Critical
+    case 3:
+        return 2 // Error
+    case 4:
+        return 3 // Warning
+    case 5, 6:
+        return 4 // Informational
+    }
+    return 5 // Verbose
+}
+
+// etwMessage returns the text of the event of an entry: the message and the fields.
+func etwMessage(e Entry) string {
+    return e.Message + formatFields(e.Fields)
+}
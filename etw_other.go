@@ -0,0 +1,10 @@
+//go:build !windows || !(amd64 || arm64)
+
+package logger
+
+import "fmt"
+
+// newETWSink returns an error: ETW is only available on 64-bit Windows.
+func (l *Logger) newETWSink(config ETWConfig) (Sink, error) {
+    return nil, fmt.Errorf("ETW output is only supported on 64-bit Windows")
+}
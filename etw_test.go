@@ -0,0 +1,25 @@
+package logger_test
+
+import (
+    "runtime"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestETWConfig(t *testing.T) {
+    _, err := logger.NewLogger(logger.LogConfig{ETW: &logger.ETWConfig{Provider: "not-a-guid"}})
+    if err == nil {
+        t.Fatalf("Expected an error for an invalid provider")
+    }
+    if runtime.GOOS == "windows" && (runtime.GOARCH == "amd64" || runtime.GOARCH == "arm64") {
+        if !strings.Contains(err.Error(), "invalid ETW provider GUID") {
+            t.Errorf("Expected an invalid GUID error, got %v", err)
+        }
+        return
+    }
+    if !strings.Contains(err.Error(), "only supported on 64-bit Windows") {
+        t.Errorf("Expected an unsupported platform error, got %v", err)
+    }
+}
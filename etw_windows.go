@@ -0,0 +1,93 @@
+//go:build windows && (amd64 || arm64)
+
+package logger
+
+import (
+    "fmt"
+    "syscall"
+    "unsafe"
+)
+
+// ETW functions of advapi32.dll.
+var (
+    advapi32             = syscall.NewLazyDLL("advapi32.dll")
+    procEventRegister    = advapi32.NewProc("EventRegister")
+    procEventUnregister  = advapi32.NewProc("EventUnregister")
+    procEventWriteString = advapi32.NewProc("EventWriteString")
+)
+
+// etwSink is the ETW output, writing entries as string events of the provider.
+type etwSink struct {
+    handle uint64
+    level  int
+    health sinkHealth
+}
+
+// newETWSink validates the configuration and registers the provider of the ETW output.
+func (l *Logger) newETWSink(config ETWConfig) (*etwSink, error) {
+    provider, err := parseGUID(config.Provider)
+    if err != nil {
+        return nil, err
+    }
+    levelValue := config.Level
+    if levelValue == nil {
+        levelValue = "info"
+    }
+    level, err := l.parseLevel(levelValue)
+    if err != nil {
+        return nil, fmt.Errorf("invalid ETW log level: %v", err)
+    }
+    if err := procEventRegister.Find(); err != nil {
+        return nil, fmt.Errorf("ETW is not available: %v", err)
+    }
+    s := &etwSink{level: level}
+    status, _, _ := procEventRegister.Call(uintptr(unsafe.Pointer(&provider)), 0, 0, uintptr(unsafe.Pointer(&s.handle)))
+    if status != 0 {
+        return nil, fmt.Errorf("failed to register ETW provider %s: %v", config.Provider, syscall.Errno(status))
+    }
+    return s, nil
+}
+
+// Name returns the name of the ETW output.
+func (s *etwSink) Name() string {
+    return etwSinkName
+}
+
+// Enabled reports whether the level of the ETW output allows the level.
+func (s *etwSink) Enabled(level string, value int) bool {
+    return value <= s.level
+}
+
+// WriteEntry writes the entry as a string event. Events nobody collects are discarded by ETW.
+func (s *etwSink) WriteEntry(e Entry) error {
+    text, err := syscall.UTF16PtrFromString(etwMessage(e))
+    if err != nil {
+        return err
+    }
+    status, _, _ := procEventWriteString.Call(uintptr(s.handle), uintptr(etwLevel(e.Level)), 0, uintptr(unsafe.Pointer(text)))
+    if status != 0 {
+        err = fmt.Errorf("failed to write ETW event: %v", syscall.Errno(status))
+        s.health.record(err)
+    }
+    return err
+}
+
+// state returns the state of the ETW output.
+func (s *etwSink) state() SinkState {
+    state := SinkState{Name: etwSinkName, Level: levelName(s.level), Connected: s.handle != 0}
+    s.health.fill(&state)
+    return state
+}
+
+// Close unregisters the provider.
+func (s *etwSink) Close() error {
+    if s.handle == 0 {
+        return nil
+    }
+    status, _, _ := procEventUnregister.Call(uintptr(s.handle))
+    s.handle = 0
+    if status != 0 {
+        return fmt.Errorf("failed to unregister ETW provider: %v", syscall.Errno(status))
+    }
+    return nil
+}
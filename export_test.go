@@ -0,0 +1,8 @@
+package logger
+
+// UnregisterLevel removes a custom level, so that tests registering levels can run again.
+func UnregisterLevel(name string) {
+    levelsMu.Lock()
+    defer levelsMu.Unlock()
+    delete(levels, name)
+}
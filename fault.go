@@ -0,0 +1,90 @@
+package logger
+
+import (
+    "errors"
+    "io"
+    "math/rand/v2"
+    "sync"
+    "time"
+)
+
+// ErrInjectedFault is the error returned by writes failed by a FaultWriter.
+var ErrInjectedFault = errors.New("injected write fault")
+
+// FaultConfig configures fault injection into writes, for testing how an application and the
+// logger behave when the log output fails. Set it in FileSinkConfig.Faults to inject faults into
+// the file output, or use NewFaultWriter to wrap any writer.
+type FaultConfig struct {
+    ErrorRate   float64       // Fraction of writes failing with ErrInjectedFault without writing anything, from 0 to 1.
+    PartialRate float64       // Fraction of writes writing only a part of the data before failing with ErrInjectedFault.
+    Latency     time.Duration // Delay added to every write.
+    Seed        uint64        // Seed making the injected faults reproducible, 0 for a random seed.
+}
+
+// enabled reports whether the configuration injects any faults.
+func (c FaultConfig) enabled() bool {
+    return c.ErrorRate > 0 || c.PartialRate > 0 || c.Latency > 0
+}
+
+// FaultWriter is a writer injecting failures, partial writes and latency into writes to another writer.
+// It is safe for concurrent use if the wrapped writer is.
+type FaultWriter struct {
+    w      io.Writer
+    config FaultConfig
+    mu     sync.Mutex
+    rnd    *rand.Rand
+}
+
+// NewFaultWriter wraps the writer with fault injection.
+//
+// Arguments:
+//   - w (io.Writer): Writer to wrap.
+//   - config (FaultConfig): Faults to inject.
+//
+// Returns:
+//   - (*FaultWriter): Writer injecting the faults.
+func NewFaultWriter(w io.Writer, config FaultConfig) *FaultWriter {
+    seed := config.Seed
+    if seed == 0 {
+        seed = rand.Uint64()
+    }
+    return &FaultWriter{w: w, config: config, rnd: rand.New(rand.NewPCG(seed, seed))}
+}
+
+// Write writes p to the wrapped writer, unless a fault is injected.
+//
+// Arguments:
+//   - p ([]byte): Data to write.
+//
+// Returns:
+//   - (int): Number of bytes written.
+//   - error: ErrInjectedFault for injected failures, or the error of the wrapped writer.
+func (fw *FaultWriter) Write(p []byte) (int, error) {
+    return fw.inject(p, fw.w.Write)
+}
+
+// inject writes p with the write function, applying the configured faults.
+func (fw *FaultWriter) inject(p []byte, write func([]byte) (int, error)) (int, error) {
+    if fw.config.Latency > 0 {
+        time.Sleep(fw.config.Latency)
+    }
+    fw.mu.Lock()
+    r := fw.rnd.Float64()
+    cut := 0
+    if len(p) > 1 {
+        cut = 1 + fw.rnd.IntN(len(p)-1)
+    }
+    fw.mu.Unlock()
+
+    switch {
+    case r < fw.config.ErrorRate:
+        return 0, ErrInjectedFault
+    case r < fw.config.ErrorRate+fw.config.PartialRate && cut > 0:
+        n, err := write(p[:cut])
+        if err == nil {
+            err = ErrInjectedFault
+        }
+        return n, err
+    }
+    return write(p)
+}
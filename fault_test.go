@@ -0,0 +1,64 @@
+package logger_test
+
+import (
+    "bytes"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+func TestFaultWriter(t *testing.T) {
+    var buf bytes.Buffer
+    w := logger.NewFaultWriter(&buf, logger.FaultConfig{ErrorRate: 1})
+    if n, err := w.Write([]byte("entry\n")); n != 0 || !errors.Is(err, logger.ErrInjectedFault) {
+        t.Errorf("Expected an injected failure, got %d, %v", n, err)
+    }
+
+    w = logger.NewFaultWriter(&buf, logger.FaultConfig{PartialRate: 1})
+    n, err := w.Write([]byte("entry\n"))
+    if n == 0 || n >= 6 || !errors.Is(err, logger.ErrInjectedFault) || buf.Len() != n {
+        t.Errorf("Expected a partial write, got %d, %v and '%s'", n, err, buf.String())
+    }
+
+    buf.Reset()
+    w = logger.NewFaultWriter(&buf, logger.FaultConfig{Latency: 20 * time.Millisecond})
+    start := time.Now()
+    if _, err := w.Write([]byte("entry\n")); err != nil || buf.String() != "entry\n" {
+        t.Errorf("Expected a successful write, got %v and '%s'", err, buf.String())
+    }
+    if time.Since(start) < 20*time.Millisecond {
+        t.Errorf("Expected the write to be delayed")
+    }
+}
+
+func TestFaultWriterSeed(t *testing.T) {
+    outcomes := func() []bool {
+        w := logger.NewFaultWriter(&bytes.Buffer{}, logger.FaultConfig{ErrorRate: 0.5, Seed: 42})
+        var result []bool
+        for i := 0; i < 20; i++ {
+            _, err := w.Write([]byte("x"))
+            result = append(result, err == nil)
+        }
+        return result
+    }
+    first, second := outcomes(), outcomes()
+    for i := range first {
+        if first[i] != second[i] {
+            t.Fatalf("Expected the same faults for the same seed, got %v and %v", first, second)
+        }
+    }
+}
+
+func TestFileSinkFaults(t *testing.T) {
+    discardStdout(t)
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel: "info",
+        FileSink:  logger.FileSinkConfig{Faults: logger.FaultConfig{ErrorRate: 1}},
+    })
+    log.Info("Lost entry")
+    if content := read(); content != "" {
+        t.Errorf("Expected all writes to fail, got '%s'", content)
+    }
+}
@@ -0,0 +1,158 @@
+package logger
+
+import (
+    "fmt"
+    "slices"
+    "strconv"
+    "strings"
+)
+
+// Fields is a set of structured key/value pairs attached to log entries.
+// In JSON format fields are added as top-level keys, in standard format they are
+// appended to the message as key=value pairs.
+type Fields map[string]interface{}
+
+// WithField returns a logger based on the global logger that adds the field to every entry.
+//
+// Arguments:
+//   - key (string): Field name.
+//   - value (interface{}): Field value.
+//
+// Returns:
+//   - (*Logger): Logger with the field.
+func WithField(key string, value interface{}) *Logger {
+    return WithFields(Fields{key: value})
+}
+
+// WithFields returns a logger based on the global logger that adds the fields to every entry.
+//
+// Arguments:
+//   - fields (Fields): Fields to add.
+//
+// Returns:
+//   - (*Logger): Logger with the fields.
+func WithFields(fields Fields) *Logger {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return nil
+    }
+    return logInstance.WithFields(fields)
+}
+
+// WithField returns a copy of the logger that adds the field to every entry.
+// The copy shares outputs with the original logger.
+//
+// Arguments:
+//   - key (string): Field name.
+//   - value (interface{}): Field value.
+//
+// Returns:
+//   - (*Logger): Logger with the field.
+func (l *Logger) WithField(key string, value interface{}) *Logger {
+    if l.nop {
+        return l
+    }
+    return l.WithFields(Fields{key: value})
+}
+
+// WithFields returns a copy of the logger that adds the fields to every entry, in addition to
+// the fields of the logger itself. The copy shares outputs with the original logger.
+//
+// Arguments:
+//   - fields (Fields): Fields to add.
+//
+// Returns:
+//   - (*Logger): Logger with the fields.
+func (l *Logger) WithFields(fields Fields) *Logger {
+    if l.nop {
+        return l
+    }
+    child := *l
+    child.fields = make(Fields, len(l.fields)+len(fields))
+    for key, value := range l.fields {
+        child.fields[key] = value
+    }
+    for key, value := range fields {
+        child.fields[key] = value
+    }
+    if l.base != nil {
+        // Loggers of GetLogger remember their own fields for rebase
+        child.added = make(Fields, len(l.added)+len(fields))
+        for key, value := range l.added {
+            child.added[key] = value
+        }
+        for key, value := range fields {
+            child.added[key] = value
+        }
+    }
+    return &child
+}
+
+// formatFields renders fields as " key=value" pairs sorted by key for the standard format.
+func formatFields(fields Fields) string {
+    if len(fields) == 0 {
+        return ""
+    }
+    return string(appendFields(nil, fields))
+}
+
+// appendFields appends the fields as " key=value" pairs sorted by key to buf, leaving out the fields
+// named in skip. Common value types are appended without allocating.
+func appendFields(buf []byte, fields Fields, skip ...string) []byte {
+    var keyBuf [16]string
+    keys := keyBuf[:0]
+    for key := range fields {
+        if key != "" && slices.Contains(skip, key) {
+            continue
+        }
+        keys = append(keys, key)
+    }
+    slices.Sort(keys)
+    for _, key := range keys {
+        buf = append(buf, ' ')
+        buf = append(buf, key...)
+        buf = append(buf, '=')
+        buf = appendFieldValue(buf, key, fields[key])
+    }
+    return buf
+}
+
+// appendFieldValue appends a field value as formatted by fmt.Sprint, quoted if it contains spaces,
+// quotes or equal signs; tags are joined with commas.
+func appendFieldValue(buf []byte, key string, value interface{}) []byte {
+    var text string
+    switch v := value.(type) {
+    case string:
+        text = v
+    case int:
+        return strconv.AppendInt(buf, int64(v), 10)
+    case int64:
+        return strconv.AppendInt(buf, v, 10)
+    case int32:
+        return strconv.AppendInt(buf, int64(v), 10)
+    case uint:
+        return strconv.AppendUint(buf, uint64(v), 10)
+    case uint64:
+        return strconv.AppendUint(buf, v, 10)
+    case uint32:
+        return strconv.AppendUint(buf, uint64(v), 10)
+    case bool:
+        return strconv.AppendBool(buf, v)
+    case float64:
+        return strconv.AppendFloat(buf, v, 'g', -1, 64)
+    case float32:
+        return strconv.AppendFloat(buf, float64(v), 'g', -1, 32)
+    case []string:
+        if key == TagsField {
+            text = strings.Join(v, ",")
+        } else {
+            text = fmt.Sprint(v)
+        }
+    default:
+        text = fmt.Sprint(value)
+    }
+    if strings.ContainsAny(text, " \t\n\"=") {
+        return strconv.AppendQuote(buf, text)
+    }
+    return append(buf, text...)
+}
@@ -0,0 +1,25 @@
+package logger
+
+// FileSinkConfig contains low-level settings of the file output.
+type FileSinkConfig struct {
+    // Preallocate reserves disk space for the log file, in megabytes, when it is opened, reducing
+    // fragmentation on busy hosts. The file size is not changed, so readers see only written data.
+    // Preallocation uses fallocate on Linux and is skipped on other platforms, on file systems without
+    // support for it, and for files managed by log rotation.
+    Preallocate int
+
+    // Sync controls when the log file is flushed to stable storage with fsync,
+    // choosing between durability and throughput. By default fsync is never called.
+    Sync SyncPolicy
+
+    // Faults injects write failures, partial writes and latency into the file output, for testing
+    // application behavior under failing logging. Never set it in production.
+    Faults FaultConfig
+
+    // Rotator creates the writer of the log file when rotation is enabled, replacing the built-in
+    // size-based rotation, see RotatorFunc.
+    Rotator RotatorFunc `json:"-"`
+
+    // RotationHooks drive the built-in rotation deterministically in tests, see RotationHooks.
+    RotationHooks RotationHooks `json:"-"`
+}
@@ -0,0 +1,20 @@
+package logger
+
+import (
+    "errors"
+    "os"
+    "syscall"
+)
+
+// fallocKeepSize is FALLOC_FL_KEEP_SIZE: allocate space without changing the file size.
+const fallocKeepSize = 0x1
+
+// preallocate reserves size bytes of disk space for the file without changing its size.
+// File systems without fallocate support are ignored.
+func preallocate(file *os.File, size int64) error {
+    err := syscall.Fallocate(int(file.Fd()), fallocKeepSize, 0, size)
+    if errors.Is(err, syscall.EOPNOTSUPP) || errors.Is(err, syscall.ENOSYS) {
+        return nil
+    }
+    return err
+}
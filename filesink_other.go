@@ -0,0 +1,10 @@
+//go:build !linux
+
+package logger
+
+import "os"
+
+// preallocate does nothing: preallocation is only supported on Linux.
+func preallocate(file *os.File, size int64) error {
+    return nil
+}
@@ -0,0 +1,68 @@
+package logger_test
+
+import (
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+func TestPreallocate(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "app.log")
+    log, err := logger.NewLogger(logger.LogConfig{
+        FilePath:  path,
+        FileLevel: "info",
+        FileSink:  logger.FileSinkConfig{Preallocate: 1},
+    })
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    log.Info("Preallocated message")
+
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatalf("Failed to read log file: %v", err)
+    }
+    if !strings.HasSuffix(string(data), "Preallocated message\n") || strings.Contains(string(data), "\x00") {
+        t.Errorf("Preallocation must not change the visible file contents, got %q", data)
+    }
+}
+
+func TestSyncPolicy(t *testing.T) {
+    for _, rotation := range []bool{false, true} {
+        path := filepath.Join(t.TempDir(), "app.log")
+        log, err := logger.NewLogger(logger.LogConfig{
+            FilePath:        path,
+            FileLevel:       "info",
+            EnableRotation:  rotation,
+            StackTraceLevel: logger.StackTraceNone,
+            FileSink: logger.FileSinkConfig{Sync: logger.SyncPolicy{
+                EveryEntries: 2,
+                Interval:     time.Millisecond,
+                Level:        "error",
+            }},
+        })
+        if err != nil {
+            t.Fatalf("Failed to create logger: %v", err)
+        }
+        log.Info("First")
+        log.Error("Synced immediately")
+        log.Info("Second")
+        time.Sleep(5 * time.Millisecond)
+
+        data, _ := os.ReadFile(path)
+        if strings.Count(string(data), "\n") != 3 {
+            t.Errorf("Expected 3 entries (rotation %v), got %q", rotation, data)
+        }
+    }
+
+    if _, err := logger.NewLogger(logger.LogConfig{
+        FilePath: filepath.Join(t.TempDir(), "app.log"),
+        FileSink: logger.FileSinkConfig{Sync: logger.SyncPolicy{Level: "loud"}},
+    }); err == nil {
+        t.Errorf("Expected error for invalid sync policy level")
+    }
+}
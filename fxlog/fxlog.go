@@ -0,0 +1,37 @@
+// Package fxlog integrates github.com/nir0k/logger with uber-fx applications. It is a separate module,
+// so that applications without fx do not depend on it through the logger. google/wire needs no such
+// module: logger.ProvideLogger is already a wire provider with cleanup.
+//
+//	app := fx.New(
+//	    fx.Supply(logger.LogConfig{FilePath: "/var/log/app.log", FileLevel: "info"}),
+//	    fxlog.Module,
+//	    fx.Invoke(func(log *logger.Logger) { log.Info("Started") }),
+//	)
+package fxlog
+
+import (
+    "github.com/nir0k/logger"
+    "go.uber.org/fx"
+)
+
+// Module provides a *logger.Logger created from the logger.LogConfig of the application, flushed and
+// closed when the application stops.
+var Module = fx.Module("logger", fx.Provide(New))
+
+// New creates a logger from the configuration and registers its Shutdown as a stop hook of the lifecycle.
+//
+// Arguments:
+//   - lc (fx.Lifecycle): Lifecycle of the application.
+//   - config (logger.LogConfig): Logger configuration, see logger.NewLogger.
+//
+// Returns:
+//   - (*logger.Logger): New logger.
+//   - error: Error if the configuration is invalid or the log file is inaccessible.
+func New(lc fx.Lifecycle, config logger.LogConfig) (*logger.Logger, error) {
+    log, err := logger.NewLogger(config)
+    if err != nil {
+        return nil, err
+    }
+    lc.Append(fx.Hook{OnStop: log.Shutdown})
+    return log, nil
+}
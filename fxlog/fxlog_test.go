@@ -0,0 +1,47 @@
+package fxlog_test
+
+import (
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+    "github.com/nir0k/logger/fxlog"
+    "go.uber.org/fx"
+    "go.uber.org/fx/fxtest"
+)
+
+func TestModule(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "app.log")
+    var log *logger.Logger
+    app := fxtest.New(t,
+        fx.Supply(logger.LogConfig{FilePath: path, FileLevel: "info"}),
+        fxlog.Module,
+        fx.Populate(&log),
+    )
+    app.RequireStart()
+    log.Info("Running")
+    app.RequireStop()
+    log.Info("After stop")
+
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatalf("Failed to read log file: %v", err)
+    }
+    if file := string(data); !strings.Contains(file, "Running") || strings.Contains(file, "After stop") {
+        t.Errorf("Expected the logger to be closed when the application stops, got '%s'", file)
+    }
+}
+
+func TestModuleInvalidConfig(t *testing.T) {
+    app := fx.New(
+        fx.Supply(logger.LogConfig{FileLevel: "loud"}),
+        fxlog.Module,
+        fx.Invoke(func(*logger.Logger) {}),
+        fx.NopLogger,
+    )
+    if err := app.Err(); err == nil || !strings.Contains(err.Error(), "invalid file log level") {
+        t.Errorf("Expected the configuration error, got %v", err)
+    }
+}
@@ -0,0 +1,17 @@
+module github.com/nir0k/logger/fxlog
+
+go 1.23.2
+
+require (
+	github.com/nir0k/logger v0.0.0-00010101000000-000000000000
+	go.uber.org/fx v1.23.0
+)
+
+require (
+	go.uber.org/dig v1.18.0 // indirect
+	go.uber.org/multierr v1.10.0 // indirect
+	go.uber.org/zap v1.26.0 // indirect
+	golang.org/x/sys v0.0.0-20220412211240-33da011f77ad // indirect
+)
+
+replace github.com/nir0k/logger => ../
@@ -0,0 +1,317 @@
+package logger
+
+import (
+    "bytes"
+    "compress/gzip"
+    "compress/zlib"
+    "crypto/tls"
+    "encoding/binary"
+    "encoding/json"
+    "fmt"
+    "io"
+    "math/rand/v2"
+    "net"
+    "os"
+    "strings"
+    "sync"
+    "time"
+)
+
+// GELFConfig configures the GELF output, which ships entries to Graylog in the Graylog Extended Log
+// Format over UDP or TCP. Levels map to syslog severities like in SyslogConfig, and the fields of
+// entries are sent as additional fields prefixed with an underscore. UDP messages are compressed and
+// split into chunks when larger than ChunkSize, and messages too large for 128 chunks are truncated;
+// TCP messages are delimited by null bytes and may use TLS. Connecting and writing time out after five
+// seconds; while Graylog is unreachable, entries are dropped and connecting is retried after a delay
+// growing up to a minute.
+type GELFConfig struct {
+    Network     string                 // "udp" or "tcp" (default: "udp").
+    Address     string                 // Address of the Graylog GELF input, e.g. "graylog.example.com:12201".
+    TLS         *tls.Config            `json:"-"` // TLS settings of TCP connections, nil for plain TCP.
+    Compression string                 // Compression of UDP messages: "gzip", "zlib" or "none" (default: "gzip"); TCP messages are not compressed.
+    ChunkSize   int                    // Maximum size of UDP datagrams, larger messages are chunked (default: 1420).
+    Host        string                 // Host of the messages (default: the hostname).
+    Fields      map[string]interface{} // Additional fields added to every message, e.g. {"environment": "production"}.
+    Level       interface{}            // Log level of the GELF output: can be a Level, a string or a number (default: "info").
+    Dedupe      time.Duration          // Window in which consecutive messages with identical content are replaced by reference records, as with SinkConfig.Dedupe; 0 disables it.
+}
+
+// gelfSinkName is the name of the GELF output in routing rules.
+const gelfSinkName = "gelf"
+
+// Limits of chunked GELF messages over UDP.
+const (
+    gelfChunkSize    = 1420 // Default datagram size, fitting the MTU of most networks.
+    gelfChunkHeader  = 12   // Magic bytes, message ID, sequence number and count.
+    gelfMaxChunks    = 128
+    gelfMinChunkSize = gelfChunkHeader + 1
+)
+
+// gelfSink is the GELF output. It connects on first write and reconnects after a failed write, see
+// reconnectingConn.
+type gelfSink struct {
+    config GELFConfig
+    level  int
+    host   string
+    fields map[string]interface{} // Additional fields of every message, with their underscore.
+    dedupe *dedupeFilter          // Replacement of repeated messages by reference records, nil if disabled.
+
+    mu     sync.Mutex
+    conn   *reconnectingConn
+    health sinkHealth
+}
+
+// newGELFSink validates the configuration and creates the GELF output of the logger.
+func (l *Logger) newGELFSink(config GELFConfig) (*gelfSink, error) {
+    if config.Network == "" {
+        config.Network = "udp"
+    }
+    switch config.Network {
+    case "udp", "udp4", "udp6":
+        if config.TLS != nil {
+            return nil, fmt.Errorf("GELF over UDP does not support TLS")
+        }
+    case "tcp", "tcp4", "tcp6":
+    default:
+        return nil, fmt.Errorf("invalid GELF network: %s", config.Network)
+    }
+    if config.Address == "" {
+        return nil, fmt.Errorf("GELF address is required")
+    }
+    switch strings.ToLower(config.Compression) {
+    case "", "gzip", "zlib", "none":
+    default:
+        return nil, fmt.Errorf("invalid GELF compression: %s", config.Compression)
+    }
+    if config.ChunkSize == 0 {
+        config.ChunkSize = gelfChunkSize
+    } else if config.ChunkSize < gelfMinChunkSize {
+        return nil, fmt.Errorf("GELF chunk size must be at least %d", gelfMinChunkSize)
+    }
+
+    levelValue := config.Level
+    if levelValue == nil {
+        levelValue = "info"
+    }
+    level, err := l.parseLevel(levelValue)
+    if err != nil {
+        return nil, fmt.Errorf("invalid GELF log level: %v", err)
+    }
+
+    host := config.Host
+    if host == "" {
+        host, _ = os.Hostname()
+    }
+    fields := make(map[string]interface{}, len(config.Fields))
+    for key, value := range config.Fields {
+        fields[gelfFieldName(key)] = value
+    }
+    s := &gelfSink{config: config, level: level, host: host, fields: fields, dedupe: newDedupeFilter(config.Dedupe)}
+    s.conn = newReconnectingConn(s.dial)
+    return s, nil
+}
+
+// Name returns the name of the GELF output.
+func (s *gelfSink) Name() string {
+    return gelfSinkName
+}
+
+// Enabled reports whether the level of the GELF output allows the level.
+func (s *gelfSink) Enabled(level string, value int) bool {
+    return value <= s.level
+}
+
+// WriteEntry sends the entry as a GELF message, reconnecting once if the connection was lost.
+func (s *gelfSink) WriteEntry(e Entry) error {
+    e = s.dedupe.apply(e)
+    msg, err := s.format(e)
+    if err == nil && s.udp() && len(msg) > s.maxMessage() {
+        msg, err = s.truncate(e)
+    }
+    if err != nil {
+        s.health.record(err)
+        return err
+    }
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    err = s.conn.write(func(conn net.Conn) error { return s.send(conn, msg) })
+    s.health.record(err)
+    return err
+}
+
+// state returns the state of the GELF output.
+func (s *gelfSink) state() SinkState {
+    s.mu.Lock()
+    connected := s.conn.connected()
+    s.mu.Unlock()
+    state := SinkState{Name: gelfSinkName, Level: levelName(s.level), Connected: connected}
+    s.health.fill(&state)
+    return state
+}
+
+// Close closes the connection to the Graylog input.
+func (s *gelfSink) Close() error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return s.conn.close()
+}
+
+// dial connects to the Graylog input, with TLS if configured.
+func (s *gelfSink) dial(dialer *net.Dialer) (net.Conn, error) {
+    var conn net.Conn
+    var err error
+    if s.config.TLS != nil {
+        conn, err = tls.DialWithDialer(dialer, s.config.Network, s.config.Address, s.config.TLS)
+    } else {
+        conn, err = dialer.Dial(s.config.Network, s.config.Address)
+    }
+    if err != nil {
+        return nil, fmt.Errorf("failed to connect to Graylog: %v", err)
+    }
+    return conn, nil
+}
+
+// udp reports whether messages are sent as datagrams.
+func (s *gelfSink) udp() bool {
+    return strings.HasPrefix(s.config.Network, "udp")
+}
+
+// format renders the entry as a GELF message, compressed for UDP or null-terminated for TCP.
+func (s *gelfSink) format(e Entry) ([]byte, error) {
+    short, _, multiline := strings.Cut(e.Message, "\n")
+    if short == "" {
+        short = "-" // Graylog rejects empty messages, such as those of reference records
+    }
+    data := make(map[string]interface{}, len(s.fields)+len(e.Fields)+8)
+    for key, value := range s.fields {
+        data[key] = value
+    }
+    for key, value := range e.Fields {
+        data[gelfFieldName(key)] = value
+    }
+    data["version"] = "1.1"
+    data["host"] = s.host
+    data["short_message"] = short
+    if multiline {
+        data["full_message"] = e.Message
+    }
+    data["timestamp"] = float64(e.Time.UnixMilli()) / 1000
+    data["level"] = severity(e.Level)
+    data["_level_name"] = e.Level
+    data["_pid"] = e.PID
+    if e.File != "" {
+        data["_file"] = e.File
+        data["_line"] = e.Line
+    }
+
+    msg, err := json.Marshal(data)
+    if err != nil {
+        // Fields that cannot be encoded are sent as strings
+        for key, value := range e.Fields {
+            data[gelfFieldName(key)] = fmt.Sprint(value)
+        }
+        if msg, err = json.Marshal(data); err != nil {
+            return nil, fmt.Errorf("failed to encode GELF message: %v", err)
+        }
+    }
+    if !s.udp() {
+        return append(msg, 0), nil
+    }
+    return compressGELF(msg, strings.ToLower(s.config.Compression))
+}
+
+// maxMessage returns the size of the largest UDP message, sent in the maximum number of chunks.
+func (s *gelfSink) maxMessage() int {
+    return (s.config.ChunkSize - gelfChunkHeader) * gelfMaxChunks
+}
+
+// truncate renders an entry too large for a UDP message with its message shortened, then without
+// its fields if still too large, marked by a "_truncated" field.
+func (s *gelfSink) truncate(e Entry) ([]byte, error) {
+    fields := make(Fields, len(e.Fields)+1)
+    for key, value := range e.Fields {
+        fields[key] = value
+    }
+    fields["truncated"] = true
+    e.Fields = fields
+    for {
+        msg, err := s.format(e)
+        if err != nil || len(msg) <= s.maxMessage() {
+            return msg, err
+        }
+        switch {
+        case e.Message != "":
+            e.Message = strings.ToValidUTF8(e.Message[:len(e.Message)/2], "")
+        case len(e.Fields) > 1:
+            e.Fields = Fields{"truncated": true}
+        default:
+            return nil, fmt.Errorf("GELF message of %d bytes exceeds %d chunks", len(msg), gelfMaxChunks)
+        }
+    }
+}
+
+// gelfFieldName returns the name of an additional field: the key prefixed with an underscore, with
+// characters other than letters, digits, underscores, dashes and dots replaced by underscores. The
+// reserved "id" field is sent as "_id_".
+func gelfFieldName(key string) string {
+    if key == "id" {
+        return "_id_"
+    }
+    var b strings.Builder
+    b.Grow(len(key) + 1)
+    b.WriteByte('_')
+    for _, r := range key {
+        if r == '_' || r == '-' || r == '.' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+            b.WriteRune(r)
+        } else {
+            b.WriteByte('_')
+        }
+    }
+    return b.String()
+}
+
+// compressGELF compresses a UDP message with the compression, "" for gzip.
+func compressGELF(msg []byte, compression string) ([]byte, error) {
+    if compression == "none" {
+        return msg, nil
+    }
+    var buf bytes.Buffer
+    var w io.WriteCloser
+    if compression == "zlib" {
+        w = zlib.NewWriter(&buf)
+    } else {
+        w = gzip.NewWriter(&buf)
+    }
+    if _, err := w.Write(msg); err != nil {
+        return nil, err
+    }
+    if err := w.Close(); err != nil {
+        return nil, err
+    }
+    return buf.Bytes(), nil
+}
+
+// send writes the message to the connection, split into chunks if it does not fit into a UDP
+// datagram. Messages of UDP are at most maxMessage bytes.
+func (s *gelfSink) send(conn net.Conn, msg []byte) error {
+    if !s.udp() || len(msg) <= s.config.ChunkSize {
+        _, err := conn.Write(msg)
+        return err
+    }
+    payload := s.config.ChunkSize - gelfChunkHeader
+    count := (len(msg) + payload - 1) / payload
+    chunk := make([]byte, 0, s.config.ChunkSize)
+    var id [8]byte
+    binary.BigEndian.PutUint64(id[:], rand.Uint64())
+    for i := 0; i < count; i++ {
+        end := min((i+1)*payload, len(msg))
+        chunk = append(chunk[:0], 0x1e, 0x0f)
+        chunk = append(chunk, id[:]...)
+        chunk = append(chunk, byte(i), byte(count))
+        chunk = append(chunk, msg[i*payload:end]...)
+        if _, err := conn.Write(chunk); err != nil {
+            return err
+        }
+    }
+    return nil
+}
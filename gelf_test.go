@@ -0,0 +1,162 @@
+package logger_test
+
+import (
+    "bufio"
+    "bytes"
+    "compress/gzip"
+    "encoding/json"
+    "io"
+    "net"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+func TestGELFUDPChunked(t *testing.T) {
+    conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatalf("Failed to listen: %v", err)
+    }
+    defer conn.Close()
+
+    log, err := logger.NewLogger(logger.LogConfig{
+        ConsoleLevel: "fatal",
+        GELF: &logger.GELFConfig{
+            Address:   conn.LocalAddr().String(),
+            ChunkSize: 64,
+            Host:      "web-1",
+            Fields:    map[string]interface{}{"environment": "production"},
+        },
+    })
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    defer log.Close()
+    log.Debug("Not sent")
+    log.WithFields(logger.Fields{"user id": 42, "id": "a1"}).Warning("Disk almost full\nsda at 95%")
+
+    data, msg := readGELFChunks(t, conn, 64, true)
+    if msg["version"] != "1.1" || msg["host"] != "web-1" || msg["short_message"] != "Disk almost full" ||
+        msg["full_message"] != "Disk almost full\nsda at 95%" || msg["level"] != float64(4) {
+        t.Errorf("Unexpected GELF message: %s", data)
+    }
+    if msg["_environment"] != "production" || msg["_user_id"] != float64(42) || msg["_id_"] != "a1" {
+        t.Errorf("Expected the additional fields, got %s", data)
+    }
+}
+
+func TestGELFUDPTruncated(t *testing.T) {
+    conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatalf("Failed to listen: %v", err)
+    }
+    defer conn.Close()
+
+    log, err := logger.NewLogger(logger.LogConfig{
+        ConsoleLevel: "fatal",
+        GELF:         &logger.GELFConfig{Address: conn.LocalAddr().String(), ChunkSize: 64, Compression: "none"},
+    })
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    defer log.Close()
+    // More than the 128 chunks of 52 bytes of payload
+    log.WithField("dump", strings.Repeat("B", 4000)).Info(strings.Repeat("A", 10000))
+
+    data, msg := readGELFChunks(t, conn, 64, false)
+    short, _ := msg["short_message"].(string)
+    if msg["_truncated"] != true || short == "" || len(short) >= 10000 {
+        t.Errorf("Expected a truncated message, got %s", data)
+    }
+    if len(data) > 52*128 {
+        t.Errorf("Expected the message to fit into 128 chunks, got %d bytes", len(data))
+    }
+}
+
+// readGELFChunks reads the chunks of a GELF message of at most chunkSize bytes from the connection
+// and returns the reassembled message, decompressed if gzipped.
+func readGELFChunks(t *testing.T, conn net.PacketConn, chunkSize int, gzipped bool) ([]byte, map[string]interface{}) {
+    t.Helper()
+    // Reassemble the chunks in their sequence order
+    var chunks [][]byte
+    buf := make([]byte, 2*chunkSize)
+    for received := 0; chunks == nil || received < len(chunks); received++ {
+        conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+        n, _, err := conn.ReadFrom(buf)
+        if err != nil {
+            t.Fatalf("Failed to read GELF chunk: %v", err)
+        }
+        if n > chunkSize || buf[0] != 0x1e || buf[1] != 0x0f {
+            t.Fatalf("Expected chunks of at most %d bytes with the GELF magic bytes, got % x", chunkSize, buf[:n])
+        }
+        if chunks == nil {
+            chunks = make([][]byte, buf[11])
+        }
+        chunks[buf[10]] = append([]byte(nil), buf[12:n]...)
+    }
+    data := bytes.Join(chunks, nil)
+    if gzipped {
+        r, err := gzip.NewReader(bytes.NewReader(data))
+        if err != nil {
+            t.Fatalf("Expected a gzip-compressed message: %v", err)
+        }
+        data, _ = io.ReadAll(r)
+    }
+    var msg map[string]interface{}
+    if err := json.Unmarshal(data, &msg); err != nil {
+        t.Fatalf("Failed to parse GELF message '%s': %v", data, err)
+    }
+    return data, msg
+}
+
+func TestGELFTCP(t *testing.T) {
+    listener, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatalf("Failed to listen: %v", err)
+    }
+    defer listener.Close()
+    messages := make(chan string, 2)
+    go func() {
+        conn, err := listener.Accept()
+        if err != nil {
+            return
+        }
+        defer conn.Close()
+        reader := bufio.NewReader(conn)
+        for {
+            msg, err := reader.ReadString(0)
+            if err != nil {
+                return
+            }
+            messages <- strings.TrimSuffix(msg, "\x00")
+        }
+    }()
+
+    log, err := logger.NewLogger(logger.LogConfig{
+        ConsoleLevel: "fatal",
+        GELF:         &logger.GELFConfig{Network: "tcp", Address: listener.Addr().String()},
+    })
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    defer log.Close()
+    log.Info("First")
+    log.Error("Second")
+
+    for _, expected := range []string{"First", "Second"} {
+        select {
+        case msg := <-messages:
+            if !strings.Contains(msg, `"short_message":"`+expected+`"`) {
+                t.Errorf("Expected message '%s', got '%s'", expected, msg)
+            }
+        case <-time.After(5 * time.Second):
+            t.Fatalf("Timed out waiting for message '%s'", expected)
+        }
+    }
+
+    if _, err := logger.NewLogger(logger.LogConfig{GELF: &logger.GELFConfig{Network: "udp", Address: "localhost:12201", Compression: "brotli"}}); err == nil {
+        t.Errorf("Expected an invalid compression to be rejected")
+    }
+}
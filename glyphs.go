@@ -0,0 +1,61 @@
+package logger
+
+import (
+    "os"
+    "runtime"
+    "strings"
+)
+
+// Glyphs are the console glyph prefixes of the levels shown with LogConfig.ConsoleGlyphs.
+// Levels without a glyph are shown without a prefix.
+var Glyphs = map[string]string{
+    "trace":   "·",
+    "debug":   "•",
+    "info":    "✔",
+    "warning": "⚠",
+    "error":   "✖",
+    "fatal":   "✖",
+}
+
+// FallbackGlyphs are the ASCII glyphs used instead of Glyphs when ASCIIGlyphs is set.
+var FallbackGlyphs = map[string]string{
+    "trace":   ".",
+    "debug":   "*",
+    "info":    "+",
+    "warning": "!",
+    "error":   "x",
+    "fatal":   "X",
+}
+
+// ASCIIGlyphs selects FallbackGlyphs for the console. It is set at startup when the locale is not
+// UTF-8 or the terminal cannot show the glyphs, and can be changed by the application.
+var ASCIIGlyphs = !unicodeTerminal()
+
+// unicodeTerminal reports whether the terminal and the locale support Unicode glyphs.
+func unicodeTerminal() bool {
+    if runtime.GOOS == "windows" {
+        // The legacy console lacks the glyphs, Windows Terminal has them
+        return os.Getenv("WT_SESSION") != ""
+    }
+    if os.Getenv("TERM") == "linux" {
+        return false // Linux virtual console fonts lack most glyphs
+    }
+    for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+        if value := strings.ToLower(os.Getenv(name)); value != "" {
+            return strings.Contains(value, "utf-8") || strings.Contains(value, "utf8")
+        }
+    }
+    return false
+}
+
+// appendGlyph appends the glyph of the level followed by a space to buf.
+func appendGlyph(buf []byte, level string) []byte {
+    glyphs := Glyphs
+    if ASCIIGlyphs {
+        glyphs = FallbackGlyphs
+    }
+    if glyph := glyphs[level]; glyph != "" {
+        buf = append(append(buf, glyph...), ' ')
+    }
+    return buf
+}
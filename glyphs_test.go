@@ -0,0 +1,41 @@
+package logger_test
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestConsoleGlyphs(t *testing.T) {
+    noColor, ascii := logger.NoColor, logger.ASCIIGlyphs
+    defer func() { logger.NoColor, logger.ASCIIGlyphs = noColor, ascii }()
+    logger.NoColor = true
+
+    log, err := logger.NewLogger(logger.LogConfig{ConsoleLevel: "info", ConsoleOutput: true, ConsoleGlyphs: true})
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    var console bytes.Buffer
+    log.ConsoleLogger.SetOutput(&console)
+
+    for _, ascii := range []bool{false, true} {
+        logger.ASCIIGlyphs = ascii
+        console.Reset()
+        log.Info("Deployed")
+        log.Warning("Slow")
+        log.Print("Plain")
+
+        lines := strings.Split(strings.TrimSuffix(console.String(), "\n"), "\n")
+        expected := []string{"✔ [", "⚠ [", "[20"}
+        if ascii {
+            expected = []string{"+ [", "! [", "[20"}
+        }
+        for i, prefix := range expected {
+            if !strings.HasPrefix(lines[i], prefix) {
+                t.Errorf("Expected line %d to start with %q, got %q", i+1, prefix, lines[i])
+            }
+        }
+    }
+}
@@ -1,18 +1,3 @@
 module github.com/nir0k/logger
 
 go 1.23.2
-
-require github.com/natefinch/lumberjack v2.0.0+incompatible
-
-require (
-	github.com/mattn/go-colorable v0.1.13 // indirect
-	github.com/mattn/go-isatty v0.0.20 // indirect
-	golang.org/x/sys v0.25.0 // indirect
-)
-
-require (
-	github.com/BurntSushi/toml v1.4.0 // indirect
-	github.com/fatih/color v1.18.0
-	gopkg.in/natefinch/lumberjack.v2 v2.2.1 // indirect
-	gopkg.in/yaml.v2 v2.4.0 // indirect
-)
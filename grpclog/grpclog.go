@@ -0,0 +1,66 @@
+// Package grpclog provides gRPC server interceptors logging calls with github.com/nir0k/logger.
+// It is a separate module, so that applications without gRPC do not depend on it through the logger.
+//
+// Each call is started with logger.StartRPC on the global logger: handlers get the logger of the
+// call with logger.FromContext, and a summary entry with the method, status code, duration and peer
+// is logged when the call completes.
+//
+//	server := grpc.NewServer(
+//	    grpc.UnaryInterceptor(grpclog.UnaryServerInterceptor()),
+//	    grpc.StreamInterceptor(grpclog.StreamServerInterceptor()),
+//	)
+package grpclog
+
+import (
+    "context"
+
+    "github.com/nir0k/logger"
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/peer"
+    "google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor returns an interceptor logging unary calls, see the package documentation.
+//
+// Returns:
+//   - (grpc.UnaryServerInterceptor): Interceptor for grpc.UnaryInterceptor.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+    return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+        ctx, call := logger.StartRPC(ctx, info.FullMethod, peerAddr(ctx))
+        resp, err := handler(ctx, req)
+        call.End(status.Code(err).String(), err)
+        return resp, err
+    }
+}
+
+// StreamServerInterceptor returns an interceptor logging streaming calls, see the package documentation.
+//
+// Returns:
+//   - (grpc.StreamServerInterceptor): Interceptor for grpc.StreamInterceptor.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+    return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+        ctx, call := logger.StartRPC(stream.Context(), info.FullMethod, peerAddr(stream.Context()))
+        err := handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+        call.End(status.Code(err).String(), err)
+        return err
+    }
+}
+
+// serverStream is a server stream whose context carries the logger of the call.
+type serverStream struct {
+    grpc.ServerStream
+    ctx context.Context
+}
+
+// Context returns the context of the call with its logger.
+func (s *serverStream) Context() context.Context {
+    return s.ctx
+}
+
+// peerAddr returns the address of the peer of the call, empty if unknown.
+func peerAddr(ctx context.Context) string {
+    if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+        return p.Addr.String()
+    }
+    return ""
+}
@@ -0,0 +1,104 @@
+package grpclog_test
+
+import (
+    "context"
+    "encoding/json"
+    "net"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+    "github.com/nir0k/logger/grpclog"
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/peer"
+    "google.golang.org/grpc/status"
+)
+
+// initLogger initializes the global logger with a JSON file and returns a function reading its entries.
+func initLogger(t *testing.T) func() []map[string]interface{} {
+    t.Helper()
+    path := filepath.Join(t.TempDir(), "app.log")
+    if err := logger.InitLogger(logger.LogConfig{FilePath: path, Format: "json", FileLevel: "info"}); err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+    t.Cleanup(logger.ResetLogger)
+    return func() []map[string]interface{} {
+        data, err := os.ReadFile(path)
+        if err != nil {
+            t.Fatalf("Failed to read log file: %v", err)
+        }
+        var entries []map[string]interface{}
+        for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+            var entry map[string]interface{}
+            if err := json.Unmarshal([]byte(line), &entry); err != nil {
+                t.Fatalf("Failed to parse entry '%s': %v", line, err)
+            }
+            entries = append(entries, entry)
+        }
+        return entries
+    }
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+    read := initLogger(t)
+    ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 7), Port: 51234}})
+    info := &grpc.UnaryServerInfo{FullMethod: "/users.Users/Get"}
+
+    _, err := grpclog.UnaryServerInterceptor()(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+        logger.FromContext(ctx).Info("Loading user")
+        return nil, status.Error(codes.NotFound, "user not found")
+    })
+    if status.Code(err) != codes.NotFound {
+        t.Errorf("Expected the error of the handler, got %v", err)
+    }
+
+    entries := read()
+    if len(entries) != 2 {
+        t.Fatalf("Expected 2 entries, got %d: %v", len(entries), entries)
+    }
+    for _, entry := range entries {
+        if entry["rpc_method"] != "/users.Users/Get" || entry["peer"] != "10.0.0.7:51234" {
+            t.Errorf("Expected call fields in entry, got %v", entry)
+        }
+    }
+    if summary := entries[1]; summary["level"] != "error" || summary["code"] != "NotFound" {
+        t.Errorf("Unexpected summary entry: %v", summary)
+    }
+}
+
+// testStream is a server stream with only a context.
+type testStream struct {
+    grpc.ServerStream
+    ctx context.Context
+}
+
+func (s *testStream) Context() context.Context {
+    return s.ctx
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+    read := initLogger(t)
+    info := &grpc.StreamServerInfo{FullMethod: "/users.Users/Watch", IsServerStream: true}
+
+    err := grpclog.StreamServerInterceptor()(nil, &testStream{ctx: context.Background()}, info, func(srv any, stream grpc.ServerStream) error {
+        logger.FromContext(stream.Context()).Info("Watching")
+        return nil
+    })
+    if err != nil {
+        t.Errorf("Unexpected error: %v", err)
+    }
+
+    entries := read()
+    if len(entries) != 2 || entries[0]["rpc_method"] != "/users.Users/Watch" {
+        t.Fatalf("Expected the entry of the handler to carry the call fields, got %v", entries)
+    }
+    if summary := entries[1]; summary["level"] != "info" || summary["code"] != "OK" {
+        t.Errorf("Unexpected summary entry: %v", summary)
+    }
+    if _, ok := entries[1]["peer"]; ok {
+        t.Errorf("Expected no peer field without a peer, got %v", entries[1])
+    }
+}
@@ -0,0 +1,111 @@
+package logger
+
+import (
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "os"
+    "strings"
+)
+
+// HashSaltEnv is the environment variable holding the salt of HashingConfig when Salt is empty.
+const HashSaltEnv = "LOGGER_HASH_SALT"
+
+// HashingConfig replaces the values of identifying fields, such as user emails, with salted hashes
+// before any output, subscriber or the ring buffer sees them. The same value always gives the same
+// hash with the same salt, so that entries of a user can be correlated across services and days,
+// while the raw identifier is stored nowhere. Keep the salt secret and stable per deployment:
+// changing it breaks the correlation with earlier entries.
+type HashingConfig struct {
+    Fields []string // Names of the fields whose values are hashed, case-insensitively, also in nested maps.
+    Salt   string   // Secret salt of the deployment, read from the LOGGER_HASH_SALT environment variable if empty.
+}
+
+// hasher is a compiled HashingConfig.
+type hasher struct {
+    fields map[string]bool
+    salt   []byte
+}
+
+// HashFields returns a processor replacing the values of the configured fields with the hex-encoded
+// HMAC-SHA256 of their text, keyed with the salt and truncated to 128 bits.
+//
+// Arguments:
+//   - config (HashingConfig): Fields to hash and salt.
+//
+// Returns:
+//   - (Processor): Hashing processor.
+//   - error: Error if no field or no salt is configured.
+func HashFields(config HashingConfig) (Processor, error) {
+    salt := config.Salt
+    if salt == "" {
+        salt = os.Getenv(HashSaltEnv)
+    }
+    if salt == "" {
+        return nil, fmt.Errorf("salt is required, set Salt or %s", HashSaltEnv)
+    }
+    h := &hasher{fields: make(map[string]bool), salt: []byte(salt)}
+    for _, name := range config.Fields {
+        if name != "" {
+            h.fields[strings.ToLower(name)] = true
+        }
+    }
+    if len(h.fields) == 0 {
+        return nil, fmt.Errorf("at least one field is required")
+    }
+    return h.process, nil
+}
+
+// process hashes the configured fields of the entry.
+func (h *hasher) process(e Entry) (Entry, bool) {
+    e.Fields, _ = h.hashFields(e.Fields)
+    return e, true
+}
+
+// hashFields returns the fields with the configured values hashed, copied only if a value changes.
+func (h *hasher) hashFields(fields Fields) (Fields, bool) {
+    var result Fields
+    for key, value := range fields {
+        hashed, changed := h.hashValue(key, value)
+        if !changed {
+            continue
+        }
+        if result == nil {
+            result = make(Fields, len(fields))
+            for k, v := range fields {
+                result[k] = v
+            }
+        }
+        result[key] = hashed
+    }
+    if result == nil {
+        return fields, false
+    }
+    return result, true
+}
+
+// hashValue returns the value of the field with the given name hashed, and whether it changed.
+func (h *hasher) hashValue(key string, value interface{}) (interface{}, bool) {
+    if h.fields[strings.ToLower(key)] {
+        if value == nil {
+            return nil, false
+        }
+        return h.hash(fmt.Sprint(value)), true
+    }
+    switch v := value.(type) {
+    case Fields:
+        return h.hashFields(v)
+    case map[string]interface{}:
+        hashed, changed := h.hashFields(Fields(v))
+        return map[string]interface{}(hashed), changed
+    }
+    return value, false
+}
+
+// hash returns the truncated, hex-encoded HMAC of the text.
+func (h *hasher) hash(text string) string {
+    mac := hmac.New(sha256.New, h.salt)
+    mac.Write([]byte(text))
+    return hex.EncodeToString(mac.Sum(nil)[:16])
+}
@@ -0,0 +1,59 @@
+package logger_test
+
+import (
+    "encoding/json"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestFieldHashing(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel: "info",
+        Format:    "json",
+        Hashing:   &logger.HashingConfig{Fields: []string{"email"}, Salt: "deployment-salt"},
+    })
+    log.WithField("email", "bob@example.com").Info("Login")
+    log.WithFields(logger.Fields{"user": logger.Fields{"Email": "bob@example.com", "plan": "pro"}}).Info("Upgrade")
+    log.WithField("email", "alice@example.com").Info("Login")
+
+    output := read()
+    if strings.Contains(output, "example.com") {
+        t.Fatalf("Expected no raw email in the output, got %s", output)
+    }
+    lines := strings.Split(strings.TrimSpace(output), "\n")
+    entries := make([]map[string]interface{}, len(lines))
+    for i, line := range lines {
+        if err := json.Unmarshal([]byte(line), &entries[i]); err != nil {
+            t.Fatalf("Invalid JSON line %q: %v", line, err)
+        }
+    }
+    bob := entries[0]["email"].(string)
+    nested := entries[1]["user"].(map[string]interface{})
+    if len(bob) != 32 || nested["Email"] != bob || nested["plan"] != "pro" {
+        t.Errorf("Expected the same hash for the same value, got %v and %v", bob, nested)
+    }
+    if entries[2]["email"] == bob {
+        t.Errorf("Expected different hashes for different values")
+    }
+
+    // Another salt gives other hashes
+    other, readOther := newFileLogger(t, logger.LogConfig{
+        FileLevel: "info",
+        Hashing:   &logger.HashingConfig{Fields: []string{"email"}, Salt: "other-salt"},
+    })
+    other.WithField("email", "bob@example.com").Info("Login")
+    if strings.Contains(readOther(), bob) {
+        t.Errorf("Expected the hash to depend on the salt")
+    }
+
+    t.Setenv(logger.HashSaltEnv, "")
+    if _, err := logger.HashFields(logger.HashingConfig{Fields: []string{"email"}}); err == nil {
+        t.Errorf("Expected an error without a salt")
+    }
+    t.Setenv(logger.HashSaltEnv, "env-salt")
+    if _, err := logger.HashFields(logger.HashingConfig{Fields: []string{"email"}}); err != nil {
+        t.Errorf("Expected the salt from %s, got %v", logger.HashSaltEnv, err)
+    }
+}
@@ -0,0 +1,86 @@
+package logger
+
+import "strings"
+
+// hook is a callback added with AddHook.
+type hook struct {
+    levels map[string]struct{} // Levels of the entries passed to fn, nil for all levels.
+    fn     func(Entry)
+}
+
+// AddHook adds a hook called for the entries logged through the global logger, also across
+// re-initialization with InitLogger, see (*Logger).AddHook.
+//
+// Arguments:
+//   - levels ([]string): Levels of the entries passed to the hook, nil for all levels.
+//   - fn (func(Entry)): Function called with each entry.
+//
+// Returns:
+//   - (func()): Function removing the hook.
+func AddHook(levels []string, fn func(Entry)) func() {
+    return globalHub.addHook(levels, fn)
+}
+
+// AddHook adds a hook called with every entry at one of the levels that is logged through the logger
+// (and loggers derived from it with WithFields) and passes level filtering for at least one output,
+// for example to send errors to a chat channel or count entries in metrics:
+//
+//	log.AddHook([]string{"error", "fatal"}, func(e logger.Entry) {
+//	    errorsTotal.Inc()
+//	})
+//
+// Hooks are called synchronously in the order they were added, before the entry is written, and
+// receive a copy of the entry with its time, level, message, caller and fields. A slow hook slows down
+// logging; use Subscribe to observe entries asynchronously. A panicking hook is reported to the error
+// handler. Hooks must not log at their own levels through the same logger.
+//
+// Arguments:
+//   - levels ([]string): Levels of the entries passed to the hook, nil for all levels.
+//   - fn (func(Entry)): Function called with each entry.
+//
+// Returns:
+//   - (func()): Function removing the hook.
+func (l *Logger) AddHook(levels []string, fn func(Entry)) func() {
+    return l.hub.addHook(levels, fn)
+}
+
+// addHook adds a hook to the hub. The hooks slice is replaced rather than modified, so that
+// runHooks can call the hooks without holding the lock.
+func (h *entryHub) addHook(levels []string, fn func(Entry)) func() {
+    hk := &hook{fn: fn}
+    if len(levels) > 0 {
+        hk.levels = make(map[string]struct{}, len(levels))
+        for _, level := range levels {
+            hk.levels[strings.ToLower(level)] = struct{}{}
+        }
+    }
+    h.mu.Lock()
+    h.hooks = append(h.hooks[:len(h.hooks):len(h.hooks)], hk)
+    h.mu.Unlock()
+
+    return func() {
+        h.mu.Lock()
+        defer h.mu.Unlock()
+        for i, other := range h.hooks {
+            if other == hk {
+                h.hooks = append(h.hooks[:i:i], h.hooks[i+1:]...)
+                return
+            }
+        }
+    }
+}
+
+// runHooks calls the hooks matching the level of the entry.
+func (h *entryHub) runHooks(entry Entry) {
+    h.mu.RLock()
+    hooks := h.hooks
+    h.mu.RUnlock()
+    for _, hk := range hooks {
+        if hk.levels != nil {
+            if _, ok := hk.levels[entry.Level]; !ok {
+                continue
+            }
+        }
+        guard("hook", func() { hk.fn(entry) })
+    }
+}
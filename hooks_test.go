@@ -0,0 +1,40 @@
+package logger_test
+
+import (
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestAddHook(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info", StackTraceLevel: logger.StackTraceNone})
+
+    var errors, all []logger.Entry
+    log.AddHook([]string{"ERROR", "fatal"}, func(e logger.Entry) { errors = append(errors, e) })
+    remove := log.AddHook(nil, func(e logger.Entry) { all = append(all, e) })
+    log.AddHook(nil, func(logger.Entry) { panic("broken hook") })
+    var panics int
+    logger.SetErrorHandler(func(component string, err error) { panics++ })
+    defer logger.SetErrorHandler(nil)
+
+    log.WithField("component", "db").Error("Connection lost")
+    log.Info("Connected")
+    log.Debug("Filtered by level")
+    remove()
+    log.Warning("After removal")
+
+    if len(errors) != 1 || errors[0].Message != "Connection lost" || errors[0].Fields["component"] != "db" || errors[0].Line == 0 {
+        t.Errorf("Unexpected error entries: %+v", errors)
+    }
+    if len(all) != 2 || all[1].Level != "info" {
+        t.Errorf("Expected the error and info entries before removal, got %+v", all)
+    }
+    // A panicking hook is reported and does not prevent writing
+    if panics != 3 {
+        t.Errorf("Expected 3 reported panics, got %d", panics)
+    }
+    if file := read(); strings.Count(file, "\n") != 3 {
+        t.Errorf("Expected 3 entries in the file, got '%s'", file)
+    }
+}
@@ -0,0 +1,180 @@
+package logger
+
+import "sync/atomic"
+
+// LoggerInterface is the leveled logging API of a logger, for injecting the logger into application
+// code and replacing it with a mock or NopLogger in unit tests. It only has exported methods with
+// plain argument types, so mock generators such as mockgen can implement it:
+//
+//	mockgen -destination=mocks/logger.go -package=mocks github.com/nir0k/logger LoggerInterface
+//
+// Use (*Logger).Interface to obtain the interface of a logger.
+type LoggerInterface interface {
+    Trace(v ...interface{})
+    Tracef(format string, v ...interface{})
+    Debug(v ...interface{})
+    Debugf(format string, v ...interface{})
+    Info(v ...interface{})
+    Infof(format string, v ...interface{})
+    Warning(v ...interface{})
+    Warningf(format string, v ...interface{})
+    Error(v ...interface{})
+    Errorf(format string, v ...interface{})
+    Fatal(v ...interface{})
+    Fatalf(format string, v ...interface{})
+    WithFields(fields Fields) LoggerInterface
+}
+
+// Interface returns the logger as a LoggerInterface.
+//
+// Returns:
+//   - (LoggerInterface): Interface logging through the logger.
+func (l *Logger) Interface() LoggerInterface {
+    return loggerInterface{l}
+}
+
+// loggerInterface adapts a Logger to LoggerInterface, whose WithFields returns the interface.
+type loggerInterface struct {
+    *Logger
+}
+
+// WithFields returns the interface of a copy of the logger that adds the fields to every entry.
+func (li loggerInterface) WithFields(fields Fields) LoggerInterface {
+    return loggerInterface{li.Logger.WithFields(fields)}
+}
+
+// NopLogger is a LoggerInterface implementation that discards everything, including Fatal messages,
+// which do not terminate the application.
+type NopLogger struct{}
+
+// Trace does nothing.
+func (NopLogger) Trace(v ...interface{}) {}
+
+// Tracef does nothing.
+func (NopLogger) Tracef(format string, v ...interface{}) {}
+
+// Debug does nothing.
+func (NopLogger) Debug(v ...interface{}) {}
+
+// Debugf does nothing.
+func (NopLogger) Debugf(format string, v ...interface{}) {}
+
+// Info does nothing.
+func (NopLogger) Info(v ...interface{}) {}
+
+// Infof does nothing.
+func (NopLogger) Infof(format string, v ...interface{}) {}
+
+// Warning does nothing.
+func (NopLogger) Warning(v ...interface{}) {}
+
+// Warningf does nothing.
+func (NopLogger) Warningf(format string, v ...interface{}) {}
+
+// Error does nothing.
+func (NopLogger) Error(v ...interface{}) {}
+
+// Errorf does nothing.
+func (NopLogger) Errorf(format string, v ...interface{}) {}
+
+// Fatal does nothing.
+func (NopLogger) Fatal(v ...interface{}) {}
+
+// Fatalf does nothing.
+func (NopLogger) Fatalf(format string, v ...interface{}) {}
+
+// WithFields returns the NopLogger itself.
+func (n NopLogger) WithFields(fields Fields) LoggerInterface {
+    return n
+}
+
+// Nop returns a logger that does nothing, for libraries with optional logging.
+//
+// Returns:
+//   - (LoggerInterface): No-op logger.
+func Nop() LoggerInterface {
+    return NopLogger{}
+}
+
+// NewNop returns a *Logger that discards everything, for libraries accepting a *Logger and for
+// benchmarks and tests. Entries are dropped before anything is evaluated, without allocating for
+// formatted messages or fields, WithField and WithFields return the logger itself, and level guards
+// such as DebugEnabled report false. Unlike with NopLogger, Fatal still terminates the application,
+// as with any *Logger.
+//
+// Returns:
+//   - (*Logger): Logger without outputs.
+func NewNop() *Logger {
+    l, err := NewLogger(LogConfig{StackTraceLevel: StackTraceNone})
+    if err != nil {
+        // A configuration without outputs cannot fail
+        panic(err)
+    }
+    l.nop = true
+    return l
+}
+
+// DiscardLogger is a LoggerInterface implementation that discards entries but counts them,
+// for benchmarks isolating logging overhead and tests asserting that something was logged.
+// Fatal messages do not terminate the application. It is safe for concurrent use, and its zero value
+// is ready to use.
+type DiscardLogger struct {
+    count atomic.Uint64
+}
+
+// Discard returns a logger that discards entries and counts them.
+//
+// Returns:
+//   - (*DiscardLogger): Counting logger.
+func Discard() *DiscardLogger {
+    return &DiscardLogger{}
+}
+
+// Count returns the number of entries logged, including through loggers returned by WithFields.
+//
+// Returns:
+//   - (uint64): Number of entries.
+func (d *DiscardLogger) Count() uint64 {
+    return d.count.Load()
+}
+
+// Trace counts the entry.
+func (d *DiscardLogger) Trace(v ...interface{}) { d.count.Add(1) }
+
+// Tracef counts the entry.
+func (d *DiscardLogger) Tracef(format string, v ...interface{}) { d.count.Add(1) }
+
+// Debug counts the entry.
+func (d *DiscardLogger) Debug(v ...interface{}) { d.count.Add(1) }
+
+// Debugf counts the entry.
+func (d *DiscardLogger) Debugf(format string, v ...interface{}) { d.count.Add(1) }
+
+// Info counts the entry.
+func (d *DiscardLogger) Info(v ...interface{}) { d.count.Add(1) }
+
+// Infof counts the entry.
+func (d *DiscardLogger) Infof(format string, v ...interface{}) { d.count.Add(1) }
+
+// Warning counts the entry.
+func (d *DiscardLogger) Warning(v ...interface{}) { d.count.Add(1) }
+
+// Warningf counts the entry.
+func (d *DiscardLogger) Warningf(format string, v ...interface{}) { d.count.Add(1) }
+
+// Error counts the entry.
+func (d *DiscardLogger) Error(v ...interface{}) { d.count.Add(1) }
+
+// Errorf counts the entry.
+func (d *DiscardLogger) Errorf(format string, v ...interface{}) { d.count.Add(1) }
+
+// Fatal counts the entry.
+func (d *DiscardLogger) Fatal(v ...interface{}) { d.count.Add(1) }
+
+// Fatalf counts the entry.
+func (d *DiscardLogger) Fatalf(format string, v ...interface{}) { d.count.Add(1) }
+
+// WithFields returns the logger itself: fields are discarded and entries counted together.
+func (d *DiscardLogger) WithFields(fields Fields) LoggerInterface {
+    return d
+}
@@ -0,0 +1,104 @@
+package logger_test
+
+import (
+    "fmt"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+// greet is application code depending on the logger interface.
+func greet(log logger.LoggerInterface, name string) {
+    log.WithFields(logger.Fields{"user": name}).Infof("Hello, %s", name)
+}
+
+// recordingLogger is a hand-written mock of LoggerInterface.
+type recordingLogger struct {
+    logger.NopLogger
+    fields  logger.Fields
+    entries *[]string
+}
+
+func (r recordingLogger) Infof(format string, v ...interface{}) {
+    *r.entries = append(*r.entries, fmt.Sprintf("%v "+format, append([]interface{}{r.fields}, v...)...))
+}
+
+func (r recordingLogger) WithFields(fields logger.Fields) logger.LoggerInterface {
+    return recordingLogger{fields: fields, entries: r.entries}
+}
+
+func TestLoggerInterfaceMock(t *testing.T) {
+    var entries []string
+    greet(recordingLogger{entries: &entries}, "alice")
+    if len(entries) != 1 || entries[0] != "map[user:alice] Hello, alice" {
+        t.Errorf("Unexpected entries: %q", entries)
+    }
+    greet(logger.NopLogger{}, "bob")
+}
+
+func TestLoggerInterface(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info"})
+    greet(log.Interface(), "carol")
+    if content := read(); !strings.Contains(content, "Hello, carol user=carol") {
+        t.Errorf("Expected the entry with fields, got '%s'", content)
+    }
+}
+
+func TestNopAndDiscard(t *testing.T) {
+    nop := logger.Nop()
+    nop.WithFields(logger.Fields{"k": "v"}).Fatal("Ignored")
+
+    discard := logger.Discard()
+    greet(discard, "dave")
+    discard.Error("Counted")
+    discard.Fatalf("Counted %d", 3)
+    if count := discard.Count(); count != 3 {
+        t.Errorf("Expected 3 counted entries, got %d", count)
+    }
+
+    var zero logger.DiscardLogger
+    zero.WithFields(logger.Fields{"k": "v"}).Info("Counted")
+    if count := zero.Count(); count != 1 {
+        t.Errorf("Expected the zero value to count entries, got %d", count)
+    }
+}
+
+func TestNewNop(t *testing.T) {
+    log := logger.NewNop()
+    child := log.WithField("user", "bob")
+    child.Print("Ignored")
+    child.Error("Ignored")
+    if log.ErrorEnabled() || log.IsLevelEnabled("print") {
+        t.Errorf("Expected all levels to be disabled")
+    }
+    if allocs := testing.AllocsPerRun(100, func() {
+        child.Info("Ignored")
+        child.Debug("Ignored", 42)
+        log.Print("Ignored")
+        log.Infof("Ignored %d", 42)
+        log.Infow("Ignored", "user", "bob", "attempt", 3)
+        log.WithField("user", "bob").Info("Ignored")
+        log.WithFields(logger.Fields{"user": "bob"}).Info("Ignored")
+    }); allocs != 0 {
+        t.Errorf("Expected no allocations, got %.1f", allocs)
+    }
+    if err := log.Close(); err != nil {
+        t.Errorf("Failed to close the nop logger: %v", err)
+    }
+}
+
+func BenchmarkNewNop(b *testing.B) {
+    log := logger.NewNop()
+    b.ReportAllocs()
+    for i := 0; i < b.N; i++ {
+        log.Info("Entry")
+    }
+}
+
+func BenchmarkDiscard(b *testing.B) {
+    log := logger.Discard()
+    for i := 0; i < b.N; i++ {
+        log.Infof("Entry %d", i)
+    }
+}
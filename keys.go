@@ -0,0 +1,172 @@
+package logger
+
+import (
+    "fmt"
+    "reflect"
+    "sort"
+    "sync"
+    "time"
+)
+
+// KeyType is the expected type of the values of a registered field key.
+type KeyType int
+
+// Field value types for RegisterKey.
+const (
+    TypeAny      KeyType = iota // Any value.
+    TypeString                  // string.
+    TypeInt                     // Any signed or unsigned integer type.
+    TypeFloat                   // float32 or float64.
+    TypeBool                    // bool.
+    TypeTime                    // time.Time.
+    TypeDuration                // time.Duration.
+)
+
+// String returns the name of the key type.
+func (t KeyType) String() string {
+    switch t {
+    case TypeString:
+        return "string"
+    case TypeInt:
+        return "int"
+    case TypeFloat:
+        return "float"
+    case TypeBool:
+        return "bool"
+    case TypeTime:
+        return "time"
+    case TypeDuration:
+        return "duration"
+    default:
+        return "any"
+    }
+}
+
+// accepts reports whether the value has the key type.
+func (t KeyType) accepts(value interface{}) bool {
+    switch value.(type) {
+    case time.Time:
+        return t == TypeTime || t == TypeAny
+    case time.Duration:
+        return t == TypeDuration || t == TypeAny
+    }
+    switch reflect.ValueOf(value).Kind() {
+    case reflect.String:
+        return t == TypeString || t == TypeAny
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+        reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+        return t == TypeInt || t == TypeAny
+    case reflect.Float32, reflect.Float64:
+        return t == TypeFloat || t == TypeAny
+    case reflect.Bool:
+        return t == TypeBool || t == TypeAny
+    }
+    return t == TypeAny
+}
+
+// RejectedFieldsKey is the field listing the fields removed from an entry by strict key checking.
+const RejectedFieldsKey = "rejected_fields"
+
+// Registry of field keys with their types. Fields added by the logger itself are pre-registered.
+var (
+    keysMu sync.RWMutex
+    keys   = map[string]KeyType{
+        "bytes":           TypeInt,
+        "causes":          TypeAny,
+        ComponentField:    TypeString,
+        "done":            TypeInt,
+        DroppedField:      TypeInt,
+        "duration":        TypeString,
+        "elapsed":         TypeString,
+        EntryIDField:      TypeString,
+        "error":           TypeString,
+        ErrorStackField:   TypeString,
+        "error_type":      TypeString,
+        FuncField:         TypeString,
+        "goroutine":       TypeInt,
+        "locked_thread":   TypeBool,
+        "method":          TypeString,
+        "op":              TypeString,
+        "op_id":           TypeString,
+        "outcome":         TypeString,
+        "path":            TypeString,
+        "percent":         TypeString,
+        RejectedFieldsKey: TypeAny,
+        RetentionField:    TypeString,
+        SampledField:      TypeBool,
+        StackTraceField:   TypeString,
+        "status":          TypeInt,
+        "stream":          TypeString,
+        "task":            TypeString,
+        TagsField:         TypeAny,
+        "tid":             TypeInt,
+        "total":           TypeInt,
+    }
+)
+
+// RegisterKey registers a field key with the type of its values, so that field names stay consistent
+// across a large codebase. Registering a key again with the same type is allowed; registering it with
+// another type is a collision and fails. With LogConfig.StrictKeys, fields with unregistered keys or
+// values of the wrong type are removed from entries.
+//
+// Arguments:
+//   - name (string): Field key.
+//   - t (KeyType): Type of the field values.
+//
+// Returns:
+//   - error: Error if the key is empty or already registered with another type.
+func RegisterKey(name string, t KeyType) error {
+    if name == "" {
+        return fmt.Errorf("invalid field key: %q", name)
+    }
+    keysMu.Lock()
+    defer keysMu.Unlock()
+    if existing, ok := keys[name]; ok && existing != t {
+        return fmt.Errorf("field key %s already registered with type %s", name, existing)
+    }
+    keys[name] = t
+    return nil
+}
+
+// MustRegisterKey registers a field key like RegisterKey and panics on collisions,
+// for use in package-level variable declarations and init functions.
+//
+// Arguments:
+//   - name (string): Field key.
+//   - t (KeyType): Type of the field values.
+//
+// Returns:
+//   - (string): The field key, so that it can be used to declare a key constant.
+func MustRegisterKey(name string, t KeyType) string {
+    if err := RegisterKey(name, t); err != nil {
+        panic(err)
+    }
+    return name
+}
+
+// checkKeys removes fields with unregistered keys or values of the wrong type, listing their
+// names in the rejected_fields field.
+func checkKeys(fields Fields) Fields {
+    keysMu.RLock()
+    var rejected []string
+    for key, value := range fields {
+        if t, ok := keys[key]; !ok || !t.accepts(value) {
+            rejected = append(rejected, key)
+        }
+    }
+    keysMu.RUnlock()
+    if len(rejected) == 0 {
+        return fields
+    }
+
+    sort.Strings(rejected)
+    result := make(Fields, len(fields)-len(rejected)+1)
+    for key, value := range fields {
+        result[key] = value
+    }
+    for _, key := range rejected {
+        delete(result, key)
+    }
+    result[RejectedFieldsKey] = rejected
+    return result
+}
@@ -0,0 +1,49 @@
+package logger_test
+
+import (
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+func TestRegisterKeyCollision(t *testing.T) {
+    if err := logger.RegisterKey("request_path", logger.TypeString); err != nil {
+        t.Fatalf("Failed to register key: %v", err)
+    }
+    if err := logger.RegisterKey("request_path", logger.TypeString); err != nil {
+        t.Errorf("Expected registering the same key and type again to succeed, got %v", err)
+    }
+    if err := logger.RegisterKey("request_path", logger.TypeInt); err == nil {
+        t.Errorf("Expected an error for a type collision")
+    }
+    defer func() {
+        if recover() == nil {
+            t.Errorf("Expected MustRegisterKey to panic on a collision")
+        }
+    }()
+    logger.MustRegisterKey("request_path", logger.TypeBool)
+}
+
+func TestStrictKeys(t *testing.T) {
+    userID := logger.MustRegisterKey("user_id", logger.TypeString)
+    latency := logger.MustRegisterKey("latency", logger.TypeDuration)
+
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info", StrictKeys: true})
+    log.WithFields(logger.Fields{
+        userID:     42,
+        latency:    time.Second,
+        "userid":   "alice",
+        "attempts": 3,
+    }).Info("Request served")
+    log.WithRetention("30d").Info("Built-in field")
+
+    content := read()
+    if !strings.Contains(content, "Request served latency=1s rejected_fields=\"[attempts user_id userid]\"") {
+        t.Errorf("Expected mistyped and unregistered fields to be rejected, got '%s'", content)
+    }
+    if !strings.Contains(content, "Built-in field retention=30d") {
+        t.Errorf("Expected built-in fields to be accepted, got '%s'", content)
+    }
+}
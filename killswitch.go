@@ -0,0 +1,39 @@
+package logger
+
+import "sync/atomic"
+
+// Global kill switch state and the number of entries suppressed while output was off.
+var (
+    outputDisabled    atomic.Bool
+    suppressedEntries atomic.Uint64
+)
+
+// Disable turns all output of all loggers off at once: entries are no longer written to the file or
+// the console, published to subscribers or kept in the ring buffer, but they are still counted (see
+// SuppressedEntries). Used for benchmarking and as an emergency switch under disk pressure.
+func Disable() {
+    outputDisabled.Store(true)
+}
+
+// Enable turns output back on after Disable. Loggers with LogConfig.Disabled set stay silent.
+func Enable() {
+    outputDisabled.Store(false)
+}
+
+// SuppressedEntries returns the number of entries dropped because output was turned off with Disable
+// or LogConfig.Disabled, since the start of the process.
+//
+// Returns:
+//   - (uint64): Number of suppressed entries.
+func SuppressedEntries() uint64 {
+    return suppressedEntries.Load()
+}
+
+// suppressed reports whether output of the logger is turned off, counting the entry if it is.
+func (l *Logger) suppressed() bool {
+    if outputDisabled.Load() || l.Config.Disabled {
+        suppressedEntries.Add(1)
+        return true
+    }
+    return false
+}
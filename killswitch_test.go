@@ -0,0 +1,35 @@
+package logger_test
+
+import (
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestKillSwitch(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info"})
+
+    before := logger.SuppressedEntries()
+    logger.Disable()
+    log.Info("Suppressed entry")
+    log.Error("Suppressed error")
+    logger.Enable()
+    log.Info("Written entry")
+
+    content := read()
+    if strings.Contains(content, "Suppressed") || !strings.Contains(content, "Written entry") {
+        t.Errorf("Expected only entries written while enabled, got '%s'", content)
+    }
+    if suppressed := logger.SuppressedEntries() - before; suppressed != 2 {
+        t.Errorf("Expected 2 suppressed entries, got %d", suppressed)
+    }
+}
+
+func TestConfigDisabled(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info", Disabled: true})
+    log.Error("Suppressed error")
+    if content := read(); content != "" {
+        t.Errorf("Expected no output from a disabled logger, got '%s'", content)
+    }
+}
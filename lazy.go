@@ -0,0 +1,58 @@
+package logger
+
+import "fmt"
+
+// Lazy is a log argument whose text is computed only if the entry passes the level filtering, for
+// messages that are expensive to build:
+//
+//	log.Debug("state: ", logger.Lazy(func() string { return dump(state) }))
+//
+// Arguments of type func() string are evaluated lazily as well, and both can be used with the
+// formatted variants such as Debugf.
+type Lazy func() string
+
+// String calls the function, returning an empty string for a nil function.
+func (f Lazy) String() string {
+    if f == nil {
+        return ""
+    }
+    return f()
+}
+
+// formatted is a message formatted when the entry is built, so that the arguments of the formatted
+// variants are not formatted for entries dropped by the levels.
+type formatted struct {
+    format string
+    v      []interface{}
+}
+
+// sprintf returns the message of a formatted variant, formatted when the entry is built. The
+// arguments are copied, so that the variadic slices of the callers do not escape when nothing is logged.
+func sprintf(format string, v []interface{}) interface{} {
+    return formatted{format: format, v: append([]interface{}(nil), v...)}
+}
+
+// String formats the message.
+func (f formatted) String() string {
+    return fmt.Sprintf(f.format, lazyArgs(f.v)...)
+}
+
+// lazyArgs returns the arguments with the func() string values wrapped as Lazy, so that fmt calls
+// them and reports their panics like those of other Stringers.
+func lazyArgs(v []interface{}) []interface{} {
+    var args []interface{}
+    for i, arg := range v {
+        fn, ok := arg.(func() string)
+        if !ok {
+            continue
+        }
+        if args == nil {
+            args = append([]interface{}(nil), v...) // The arguments of the caller are not modified
+        }
+        args[i] = Lazy(fn)
+    }
+    if args == nil {
+        return v
+    }
+    return args
+}
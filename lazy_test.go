@@ -0,0 +1,47 @@
+package logger_test
+
+import (
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestLazyArguments(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info", ConsoleLevel: "error"})
+
+    calls := 0
+    expensive := func() string {
+        calls++
+        return "expensive payload"
+    }
+    log.Debug("Dropped ", logger.Lazy(expensive))
+    log.Debug("Dropped ", expensive)
+    log.Debugf("Dropped %s %v", logger.Lazy(expensive), expensive)
+    if calls != 0 {
+        t.Errorf("Expected lazy arguments of dropped entries not to be evaluated, got %d calls", calls)
+    }
+
+    log.Info("Written ", logger.Lazy(expensive))
+    log.Info("Func ", expensive)
+    log.Infof("Formatted %s and %v", logger.Lazy(expensive), expensive)
+    log.Info("Nil ", logger.Lazy(nil), "done")
+    if calls != 4 {
+        t.Errorf("Expected 4 evaluations for written entries, got %d", calls)
+    }
+
+    output := read()
+    for _, expected := range []string{
+        "Written expensive payload",
+        "Func expensive payload",
+        "Formatted expensive payload and expensive payload",
+        "Nil done",
+    } {
+        if !strings.Contains(output, expected) {
+            t.Errorf("Expected %q in output, got %q", expected, output)
+        }
+    }
+    if strings.Contains(output, "Dropped") {
+        t.Errorf("Expected DEBUG entries to be dropped, got %q", output)
+    }
+}
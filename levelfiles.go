@@ -0,0 +1,53 @@
+package logger
+
+import (
+    "fmt"
+    "log"
+    "os"
+    "path/filepath"
+    "sort"
+    "strings"
+)
+
+// levelFileSinkPrefix prefixes the names of the sinks of LogConfig.LevelFiles, e.g. "file:warning".
+const levelFileSinkPrefix = "file:"
+
+// openLevelFiles opens the per-level files of LogConfig.LevelFiles, each receiving the entries at its
+// level or more severe, with the format, processors and rotation settings of the file output.
+// Each file is rotated independently.
+func (l *Logger) openLevelFiles(levelFiles map[string]string) error {
+    type levelFile struct {
+        level string
+        value int
+        path  string
+    }
+    var files []levelFile
+    for level, path := range levelFiles {
+        value, err := l.parseLevel(level)
+        if err != nil {
+            return fmt.Errorf("invalid level file level: %v", err)
+        }
+        files = append(files, levelFile{level: strings.ToLower(level), value: value, path: path})
+    }
+    // Most verbose files first
+    sort.Slice(files, func(i, j int) bool { return files[i].value > files[j].value })
+
+    for _, lf := range files {
+        dir := filepath.Dir(lf.path)
+        if _, err := os.Stat(dir); os.IsNotExist(err) {
+            return fmt.Errorf("log directory does not exist: %s", dir)
+        }
+        file, err := openLogFile(lf.path, l.Config)
+        if err != nil {
+            return err
+        }
+        l.files = append(l.files, file)
+
+        name := levelFileSinkPrefix + lf.level
+        s := newOutputSink(name, "file "+lf.level, log.New(file, "", 0), newLevel(lf.value), l.fileFormat())
+        s.processors = l.Config.FileProcessors
+        s.writeLimit = l.Config.maxWrite()
+        l.addSink(s, true)
+    }
+    return nil
+}
@@ -0,0 +1,50 @@
+package logger_test
+
+import (
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestLevelFiles(t *testing.T) {
+    dir := t.TempDir()
+    errorLog := filepath.Join(dir, "error.log")
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel:  "debug",
+        LevelFiles: map[string]string{"warning": errorLog},
+    })
+    log.Debug("Cache miss")
+    log.Warning("Slow query")
+    log.Error("Query failed")
+    log.Close()
+
+    file := read()
+    for _, msg := range []string{"Cache miss", "Slow query", "Query failed"} {
+        if !strings.Contains(file, msg) {
+            t.Errorf("Expected '%s' in the main file, got '%s'", msg, file)
+        }
+    }
+    data, err := os.ReadFile(errorLog)
+    if err != nil {
+        t.Fatalf("Failed to read the level file: %v", err)
+    }
+    if got := string(data); strings.Contains(got, "Cache miss") || !strings.Contains(got, "[WARNING] Slow query") || !strings.Contains(got, "[ERROR] Query failed") {
+        t.Errorf("Expected only warnings and errors in the level file, got '%s'", got)
+    }
+}
+
+func TestLevelFilesInvalid(t *testing.T) {
+    dir := t.TempDir()
+    configs := []map[string]string{
+        {"loud": filepath.Join(dir, "loud.log")},
+        {"error": filepath.Join(dir, "missing", "error.log")},
+    }
+    for _, levelFiles := range configs {
+        if _, err := logger.NewLogger(logger.LogConfig{LevelFiles: levelFiles}); err == nil {
+            t.Errorf("Expected %v to be rejected", levelFiles)
+        }
+    }
+}
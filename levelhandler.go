@@ -0,0 +1,107 @@
+package logger
+
+import (
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "strings"
+)
+
+// levelRequest is the body of a level change, see (*Logger).LevelHandler.
+type levelRequest struct {
+    Level   interface{} `json:"level,omitempty"`
+    File    interface{} `json:"file,omitempty"`
+    Console interface{} `json:"console,omitempty"`
+}
+
+// LevelHandler returns the level endpoint of the global logger, see (*Logger).LevelHandler. The
+// global logger is resolved on each request, so the handler keeps working after InitLogger.
+//
+// Returns:
+//   - (http.Handler): Level handler.
+func LevelHandler() http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        l := currentLogger()
+        if l == nil {
+            http.NotFound(w, r)
+            return
+        }
+        l.LevelHandler().ServeHTTP(w, r)
+    })
+}
+
+// LevelHandler returns an HTTP handler reading and changing the levels of the logger at runtime,
+// to be mounted on an admin mux, e.g. at /debug/loglevel:
+//   - GET returns the current levels as JSON: {"file":"info","console":"warning"};
+//   - PUT changes them from a JSON body, {"level":"debug"} for both outputs or {"file":"debug"}
+//     and/or {"console":"debug"} for one, or from the level, file and console query parameters,
+//     and returns the new levels.
+//
+// The handler does not authenticate requests: protect the admin mux accordingly.
+//
+// Returns:
+//   - (http.Handler): Level handler.
+func (l *Logger) LevelHandler() http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        switch r.Method {
+        case http.MethodGet:
+        case http.MethodPut:
+            if err := l.changeLevels(r); err != nil {
+                http.Error(w, err.Error(), http.StatusBadRequest)
+                return
+            }
+        default:
+            w.Header().Set("Allow", "GET, PUT")
+            http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+            return
+        }
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(map[string]string{
+            "file":    levelName(int(l.levels.file.Load())),
+            "console": levelName(int(l.levels.console.Load())),
+        })
+    })
+}
+
+// changeLevels applies the level change of the request, validating all levels before changing any.
+func (l *Logger) changeLevels(r *http.Request) error {
+    var req levelRequest
+    if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") || r.URL.RawQuery == "" {
+        if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+            return fmt.Errorf("invalid request body: %v", err)
+        }
+    } else {
+        query := r.URL.Query()
+        for key, target := range map[string]*interface{}{"level": &req.Level, "file": &req.File, "console": &req.Console} {
+            if value := query.Get(key); value != "" {
+                *target = value
+            }
+        }
+    }
+    if req.Level == nil && req.File == nil && req.Console == nil {
+        return fmt.Errorf("no level given")
+    }
+    if req.Level != nil {
+        req.File, req.Console = req.Level, req.Level
+    }
+
+    var file, console int
+    var err error
+    if req.File != nil {
+        if file, err = l.parseLevel(req.File); err != nil {
+            return err
+        }
+    }
+    if req.Console != nil {
+        if console, err = l.parseLevel(req.Console); err != nil {
+            return err
+        }
+    }
+    if req.File != nil {
+        l.levels.file.Store(int64(file))
+    }
+    if req.Console != nil {
+        l.levels.console.Store(int64(console))
+    }
+    return nil
+}
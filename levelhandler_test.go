@@ -0,0 +1,64 @@
+package logger_test
+
+import (
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestLevelHandler(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info", ConsoleLevel: "warning"})
+    handler := log.LevelHandler()
+
+    do := func(method, target, body string) *httptest.ResponseRecorder {
+        req := httptest.NewRequest(method, target, strings.NewReader(body))
+        if body != "" {
+            req.Header.Set("Content-Type", "application/json")
+        }
+        rec := httptest.NewRecorder()
+        handler.ServeHTTP(rec, req)
+        return rec
+    }
+
+    if rec := do(http.MethodGet, "/debug/loglevel", ""); strings.TrimSpace(rec.Body.String()) != `{"console":"warning","file":"info"}` {
+        t.Errorf("Unexpected levels: %s", rec.Body.String())
+    }
+    log.Debug("Before")
+    if rec := do(http.MethodPut, "/debug/loglevel", `{"file":"debug"}`); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"file":"debug"`) {
+        t.Errorf("Failed to change the file level: %d %s", rec.Code, rec.Body.String())
+    }
+    log.Debug("After")
+    if file := read(); strings.Contains(file, "Before") || !strings.Contains(file, "After") {
+        t.Errorf("Expected the level change to apply, got '%s'", file)
+    }
+
+    if rec := do(http.MethodPut, "/debug/loglevel?level=error", ""); !strings.Contains(rec.Body.String(), `{"console":"error","file":"error"}`) {
+        t.Errorf("Failed to change both levels: %s", rec.Body.String())
+    }
+    if rec := do(http.MethodPut, "/debug/loglevel", `{"file":"info","console":"loud"}`); rec.Code != http.StatusBadRequest {
+        t.Errorf("Expected an invalid level to be rejected, got %d", rec.Code)
+    }
+    if rec := do(http.MethodGet, "/debug/loglevel", ""); !strings.Contains(rec.Body.String(), `"file":"error"`) {
+        t.Errorf("Expected a rejected change to keep the levels, got %s", rec.Body.String())
+    }
+    if rec := do(http.MethodPost, "/debug/loglevel", ""); rec.Code != http.StatusMethodNotAllowed {
+        t.Errorf("Expected POST to be rejected, got %d", rec.Code)
+    }
+}
+
+func TestLevelHandlerGlobal(t *testing.T) {
+    resetLogger()
+    defer resetLogger()
+    handler := logger.LevelHandler()
+    if err := logger.InitLogger(logger.LogConfig{FileLevel: "info", ConsoleLevel: "info"}); err != nil {
+        t.Fatalf("Failed to initialize the logger: %v", err)
+    }
+    req := httptest.NewRequest(http.MethodPut, "/debug/loglevel?file=debug", nil)
+    handler.ServeHTTP(httptest.NewRecorder(), req)
+    if state := logger.State(); state.FileLevel != "debug" {
+        t.Errorf("Expected the handler to change the logger initialized after it, got %s", state.FileLevel)
+    }
+}
@@ -0,0 +1,213 @@
+package logger
+
+import (
+    "fmt"
+    "os"
+    "strings"
+    "sync"
+)
+
+// levelInfo describes a registered log level.
+type levelInfo struct {
+    value int   // Numeric slot of the level: lower is more severe.
+    color Color // Console color used for the level.
+}
+
+// Registry of known log levels, shared by all logger instances.
+var (
+    levelsMu sync.RWMutex
+    levels   = map[string]levelInfo{
+        "trace":   {value: 5, color: FgCyan},
+        "debug":   {value: 4, color: FgBlue},
+        "info":    {value: 3, color: FgGreen},
+        "warning": {value: 2, color: FgYellow},
+        "error":   {value: 1, color: FgRed},
+        "fatal":   {value: 0, color: FgHiRed},
+    }
+)
+
+// Level is a log level, accepted wherever a level is configured as a string or a number, such as
+// LogConfig.FileLevel and SetLevel. Lower values are more severe.
+type Level int
+
+// Built-in levels, from the most severe.
+const (
+    FatalLevel Level = iota
+    ErrorLevel
+    WarningLevel
+    InfoLevel
+    DebugLevel
+    TraceLevel
+)
+
+// ParseLevel returns the level with the name, case-insensitive, including custom levels registered
+// with RegisterLevel.
+//
+// Arguments:
+//   - name (string): Level name, e.g. "info".
+//
+// Returns:
+//   - (Level): Parsed level.
+//   - error: Error if no level has the name.
+func ParseLevel(name string) (Level, error) {
+    value, ok := levelMap()[strings.ToLower(strings.TrimSpace(name))]
+    if !ok {
+        return 0, fmt.Errorf("invalid log level: %s", name)
+    }
+    return Level(value), nil
+}
+
+// String returns the name of the level, preferring built-in levels over custom levels sharing
+// the value, or the number for unknown levels.
+func (l Level) String() string {
+    return levelName(int(l))
+}
+
+// MarshalText returns the name of the level, so that levels are written by name in JSON.
+func (l Level) MarshalText() ([]byte, error) {
+    return []byte(l.String()), nil
+}
+
+// UnmarshalText parses a level name.
+func (l *Level) UnmarshalText(text []byte) error {
+    level, err := ParseLevel(string(text))
+    if err != nil {
+        return err
+    }
+    *l = level
+    return nil
+}
+
+// RegisterLevel registers a custom log level (for example "notice" or "security") that can be used
+// with Log/Logf and in the FileLevel and ConsoleLevel configuration fields.
+// The value places the level in the existing ordering: 0 is "fatal", 5 is "trace", and a custom level
+// sharing a slot with a built-in level is filtered exactly like that level. Values above 5 are allowed
+// and are more verbose than "trace".
+// Levels must be registered before the logger that uses them is created.
+//
+// Arguments:
+//   - name (string): Level name, case-insensitive.
+//   - value (int): Numeric slot of the level, must not be negative.
+//   - c (Color): Console color for messages at this level.
+//
+// Returns:
+//   - error: Error if the name is empty, already registered, or the value is negative.
+func RegisterLevel(name string, value int, c Color) error {
+    name = strings.ToLower(strings.TrimSpace(name))
+    if name == "" || name == "print" {
+        return fmt.Errorf("invalid level name: %q", name)
+    }
+    if value < 0 {
+        return fmt.Errorf("invalid value for level %s: %d", name, value)
+    }
+
+    levelsMu.Lock()
+    defer levelsMu.Unlock()
+    if _, ok := levels[name]; ok {
+        return fmt.Errorf("log level already registered: %s", name)
+    }
+    levels[name] = levelInfo{value: value, color: c}
+    return nil
+}
+
+// levelMap returns a copy of the registered levels as a name to value map.
+func levelMap() map[string]int {
+    levelsMu.RLock()
+    defer levelsMu.RUnlock()
+    m := make(map[string]int, len(levels))
+    for name, info := range levels {
+        m[name] = info.value
+    }
+    return m
+}
+
+// levelColor returns the console color of the level, white for unknown levels.
+func levelColor(level string) Color {
+    levelsMu.RLock()
+    defer levelsMu.RUnlock()
+    if info, ok := levels[level]; ok {
+        return info.color
+    }
+    return FgWhite
+}
+
+// levelNames validates the display names of levels and returns them by lowercase level name.
+func (l *Logger) levelNames(names map[string]string) (map[string]string, error) {
+    if len(names) == 0 {
+        return nil, nil
+    }
+    result := make(map[string]string, len(names))
+    for level, name := range names {
+        level = strings.ToLower(level)
+        if _, ok := l.LogLevelMap[level]; !ok && level != "print" {
+            return nil, fmt.Errorf("invalid level: %s", level)
+        }
+        result[level] = name
+    }
+    return result, nil
+}
+
+// maxLevelValue returns the most verbose registered level value.
+func maxLevelValue(m map[string]int) int {
+    maxValue := 0
+    for _, v := range m {
+        if v > maxValue {
+            maxValue = v
+        }
+    }
+    return maxValue
+}
+
+// Log logs a message at the given level, which can be a built-in or a registered custom level.
+//
+// Arguments:
+//   - level (string): Level name.
+//   - v (...interface{}): Message to log.
+func Log(level string, v ...interface{}) {
+    ensureLoggerInitialized()
+    if logInstance != nil {
+        logInstance.Log(level, v...)
+    }
+}
+
+// Logf logs a formatted message at the given level.
+//
+// Arguments:
+//   - level (string): Level name.
+//   - format (string): Format string.
+//   - v (...interface{}): Values for formatting the message.
+func Logf(level string, format string, v ...interface{}) {
+    ensureLoggerInitialized()
+    if logInstance != nil {
+        logInstance.Logf(level, format, v...)
+    }
+}
+
+// Log logs a message at the given level, which can be a built-in or a registered custom level.
+// Messages at the "fatal" level terminate the application.
+//
+// Arguments:
+//   - level (string): Level name.
+//   - v (...interface{}): Message to log.
+func (l *Logger) Log(level string, v ...interface{}) {
+    level = strings.ToLower(level)
+    l.log(level, v...)
+    if level == "fatal" {
+        os.Exit(1)
+    }
+}
+
+// Logf logs a formatted message at the given level.
+// Messages at the "fatal" level terminate the application.
+//
+// Arguments:
+//   - level (string): Level name.
+//   - format (string): Format string.
+//   - v (...interface{}): Values for formatting the message.
+func (l *Logger) Logf(level string, format string, v ...interface{}) {
+    level = strings.ToLower(level)
+    l.logf(level, format, v)
+    if level == "fatal" {
+        os.Exit(1)
+    }
+}
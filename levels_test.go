@@ -0,0 +1,97 @@
+package logger_test
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestRegisterLevel(t *testing.T) {
+    if err := logger.RegisterLevel("notice", 3, logger.FgMagenta); err != nil {
+        t.Fatalf("Failed to register level: %v", err)
+    }
+    t.Cleanup(func() { logger.UnregisterLevel("notice") })
+    if err := logger.RegisterLevel("notice", 3, logger.FgMagenta); err == nil {
+        t.Errorf("Expected error when registering a level twice")
+    }
+    if err := logger.RegisterLevel("bad", -1, logger.FgMagenta); err == nil {
+        t.Errorf("Expected error for negative level value")
+    }
+
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "notice"})
+    log.Log("notice", "Custom level message")
+    log.Logf("NOTICE", "Custom level message: %d", 1)
+    log.Debug("Debug message")
+
+    output := read()
+    if !strings.Contains(output, "[NOTICE] Custom level message") {
+        t.Errorf("Expected custom level message in output, got '%s'", output)
+    }
+    if !strings.Contains(output, "Custom level message: 1") {
+        t.Errorf("Expected formatted custom level message in output, got '%s'", output)
+    }
+    if strings.Contains(output, "Debug message") {
+        t.Errorf("Debug message should be filtered by the custom level, got '%s'", output)
+    }
+}
+
+func TestLevelNames(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel:       "info",
+        ConsoleLevel:    "info",
+        ConsoleOutput:   true,
+        StackTraceLevel: logger.StackTraceNone,
+        LevelNames:      map[string]string{"Warning": "WRN", "error": "ERR"},
+    })
+    var console bytes.Buffer
+    log.ConsoleLogger.SetOutput(&console)
+    log.Warning("Disk almost full")
+    log.Error("Disk full")
+    log.Info("Cleaned up")
+
+    out := console.String()
+    for _, expected := range []string{"[WRN] Disk almost full", "[ERR] Disk full", "[INFO] Cleaned up"} {
+        if !strings.Contains(out, expected) {
+            t.Errorf("Expected %q in the console output, got '%s'", expected, out)
+        }
+    }
+    if file := read(); !strings.Contains(file, "[WARNING] Disk almost full") || !strings.Contains(file, "[ERROR] Disk full") {
+        t.Errorf("Expected canonical level names in the file, got '%s'", file)
+    }
+
+    _, err := logger.NewLogger(logger.LogConfig{ConsoleOutput: true, LevelNames: map[string]string{"loud": "LOUD"}})
+    if err == nil {
+        t.Errorf("Expected an unknown level to be rejected")
+    }
+}
+
+func TestLevelType(t *testing.T) {
+    level, err := logger.ParseLevel("Warning")
+    if err != nil || level != logger.WarningLevel {
+        t.Fatalf("Expected WarningLevel, got %v, %v", level, err)
+    }
+    if _, err := logger.ParseLevel("loud"); err == nil {
+        t.Errorf("Expected an unknown level to be rejected")
+    }
+    if s := logger.TraceLevel.String(); s != "trace" {
+        t.Errorf("Expected trace, got %s", s)
+    }
+    if text, _ := logger.ErrorLevel.MarshalText(); string(text) != "error" {
+        t.Errorf("Expected error, got %s", text)
+    }
+
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: logger.InfoLevel})
+    log.Debug("Hidden")
+    if err := log.SetFileLevel(logger.DebugLevel); err != nil {
+        t.Fatalf("Failed to set level: %v", err)
+    }
+    log.Debug("Shown")
+    if output := read(); strings.Contains(output, "Hidden") || !strings.Contains(output, "Shown") {
+        t.Errorf("Expected typed levels to filter entries, got %q", output)
+    }
+    if state := log.State(); state.FileLevel != logger.DebugLevel.String() {
+        t.Errorf("Expected file level debug, got %s", state.FileLevel)
+    }
+}
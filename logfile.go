@@ -0,0 +1,279 @@
+package logger
+
+import (
+    "fmt"
+    "io"
+    "os"
+    "path/filepath"
+    "reflect"
+    "sync"
+    "sync/atomic"
+    "time"
+)
+
+// Open log files by absolute path. Loggers configured with the same path in one process share
+// the writer of the file, so that their writes are serialized instead of interleaved by two
+// unsynchronized writers.
+var (
+    sharedFilesMu sync.Mutex
+    sharedFiles   = map[string]*sharedFile{}
+)
+
+// logFile is the handle of a logger on a log file of the file output, optionally rotated, that can
+// be synced, reopened after external rotation and closed. The file itself is shared with the other
+// loggers writing to the same path and closed with the last handle. It is safe for concurrent use.
+type logFile struct {
+    *sharedFile
+    released atomic.Bool
+}
+
+// sharedFile is a log file open in the process, shared by the handles of the loggers writing to it.
+type sharedFile struct {
+    mu     sync.Mutex
+    key    string // Key of the file in sharedFiles.
+    refs   int    // Number of open handles, guarded by sharedFilesMu.
+    path   string
+    config LogConfig
+    w      io.Writer    // *os.File, or the rotating writer with rotation.
+    faults *FaultWriter // Fault injection into writes, nil if disabled.
+    closed bool
+}
+
+// openLogFile opens the log file at path, with rotation if it is enabled in the configuration.
+// If another logger has the file open, the file is shared and keeps the rotation settings of that logger.
+func openLogFile(path string, config LogConfig) (*logFile, error) {
+    key, err := filepath.Abs(path)
+    if err != nil {
+        key = filepath.Clean(path)
+    }
+    sharedFilesMu.Lock()
+    defer sharedFilesMu.Unlock()
+    if f, ok := sharedFiles[key]; ok && !f.stale() {
+        if f.config.EnableRotation != config.EnableRotation || !reflect.DeepEqual(f.config.RotationConfig, config.RotationConfig) {
+            reportError("file output", fmt.Errorf("log file %s is shared with another logger, keeping its rotation settings", path))
+        }
+        f.refs++
+        return &logFile{sharedFile: f}, nil
+    }
+
+    f := &sharedFile{key: key, refs: 1, path: path, config: config}
+    if config.FileSink.Faults.enabled() {
+        f.faults = NewFaultWriter(nil, config.FileSink.Faults)
+    }
+    if err := f.open(); err != nil {
+        return nil, err
+    }
+    sharedFiles[key] = f
+    return &logFile{sharedFile: f}, nil
+}
+
+// Write writes p to the file. Writes after Close are discarded.
+func (h *logFile) Write(p []byte) (int, error) {
+    if h.released.Load() {
+        return len(p), nil
+    }
+    return h.sharedFile.Write(p)
+}
+
+// connected reports whether the handle and the file are open.
+func (h *logFile) connected() bool {
+    return !h.released.Load() && h.sharedFile.connected()
+}
+
+// Close releases the handle. The file is synced and closed with the last handle, and later writes
+// through the handle are discarded. It is safe to call several times.
+func (h *logFile) Close() error {
+    if !h.released.CompareAndSwap(false, true) {
+        return nil
+    }
+    sharedFilesMu.Lock()
+    h.refs--
+    last := h.refs == 0
+    if last && sharedFiles[h.key] == h.sharedFile {
+        delete(sharedFiles, h.key)
+    }
+    sharedFilesMu.Unlock()
+    if !last {
+        return nil
+    }
+    return h.sharedFile.close()
+}
+
+// detach stops sharing the file with loggers opened later, which open the path anew. It is used
+// when the global logger is replaced, so that the new configuration applies to its files.
+func (h *logFile) detach() {
+    sharedFilesMu.Lock()
+    defer sharedFilesMu.Unlock()
+    if sharedFiles[h.key] == h.sharedFile {
+        delete(sharedFiles, h.key)
+    }
+}
+
+// detachFiles detaches the log files of the logger, see logFile.detach.
+func (l *Logger) detachFiles() {
+    for _, file := range l.files {
+        file.detach()
+    }
+}
+
+// stale reports whether the open file was removed or moved away since it was opened, in which case
+// it is not shared with new loggers. It must be called with sharedFilesMu held.
+func (f *sharedFile) stale() bool {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    if f.closed {
+        return true
+    }
+    file, ok := f.w.(*os.File)
+    if r, rotating := f.w.(*RotatingFile); rotating {
+        r.mu.Lock()
+        file, ok = r.file, r.file != nil
+        r.mu.Unlock()
+    }
+    if !ok {
+        return false
+    }
+    opened, err := file.Stat()
+    if err != nil {
+        return true
+    }
+    current, err := os.Stat(f.path)
+    return err != nil || !os.SameFile(opened, current)
+}
+
+// open opens the underlying writer. It must be called with f.mu held or before f is shared.
+func (f *sharedFile) open() error {
+    if f.config.EnableRotation {
+        if f.config.FileSink.Rotator == nil {
+            f.w = NewRotatingFile(f.path, f.config.RotationConfig, f.config.FileSink.RotationHooks)
+            return nil
+        }
+        w, err := f.config.FileSink.Rotator(f.path, f.config.RotationConfig)
+        if err != nil {
+            return fmt.Errorf("failed to open rotated log file: %v", err)
+        }
+        f.w = w
+        return nil
+    }
+    file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+    if err != nil {
+        return fmt.Errorf("failed to open log file: %v", err)
+    }
+    if f.config.FileSink.Preallocate > 0 {
+        if err := preallocate(file, int64(f.config.FileSink.Preallocate)*1024*1024); err != nil {
+            file.Close()
+            return fmt.Errorf("failed to preallocate log file: %v", err)
+        }
+    }
+    f.w = file
+    return nil
+}
+
+// Write writes p to the file. Writes after Close are discarded.
+func (f *sharedFile) Write(p []byte) (int, error) {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    if f.closed {
+        return len(p), nil
+    }
+    if f.faults != nil {
+        return f.faults.inject(p, f.w.Write)
+    }
+    return f.w.Write(p)
+}
+
+// Sync flushes the file to stable storage. Writers of a custom Rotator without a Sync method
+// are synced through a separate descriptor of the file, which flushes the same data.
+func (f *sharedFile) Sync() error {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    if f.closed {
+        return nil
+    }
+    if syncer, ok := f.w.(interface{ Sync() error }); ok {
+        return syncer.Sync()
+    }
+    file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND, 0)
+    if os.IsNotExist(err) {
+        return nil
+    } else if err != nil {
+        return err
+    }
+    defer file.Close()
+    return file.Sync()
+}
+
+// Reopen closes and reopens the file, so that writes go to a new file after the current one
+// was moved away by an external tool such as logrotate.
+func (f *sharedFile) Reopen() error {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    if f.closed {
+        return nil
+    }
+    if closer, ok := f.w.(io.Closer); ok {
+        closer.Close()
+    }
+    return f.open()
+}
+
+// Rotate rotates the file with the built-in rotation, or with the writer of a Rotator if it has
+// a Rotate method.
+func (f *sharedFile) Rotate() error {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    if f.closed {
+        return nil
+    }
+    rotator, ok := f.w.(RotatingWriter)
+    if !ok {
+        return fmt.Errorf("rotation is not enabled for %s", f.path)
+    }
+    return rotator.Rotate()
+}
+
+// rotatingWriter returns the rotation backend of the file, nil if it has none.
+func (f *sharedFile) rotatingWriter() RotatingWriter {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    rotator, _ := f.w.(RotatingWriter)
+    return rotator
+}
+
+// connected reports whether the file is open.
+func (f *sharedFile) connected() bool {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    return !f.closed
+}
+
+// lastRotation returns the time of the last rotation of the file, zero if it is not rotated by
+// the built-in rotation or was not rotated yet.
+func (f *sharedFile) lastRotation() time.Time {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    if r, ok := f.w.(*RotatingFile); ok {
+        return r.lastRotation()
+    }
+    return time.Time{}
+}
+
+// close syncs and closes the file. Later writes are discarded.
+func (f *sharedFile) close() error {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    if f.closed {
+        return nil
+    }
+    f.closed = true
+    var err error
+    if syncer, ok := f.w.(interface{ Sync() error }); ok {
+        err = syncer.Sync()
+    }
+    if closer, ok := f.w.(io.Closer); ok {
+        if cerr := closer.Close(); err == nil {
+            err = cerr
+        }
+    }
+    return err
+}
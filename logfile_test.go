@@ -0,0 +1,78 @@
+package logger_test
+
+import (
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestSharedLogFile(t *testing.T) {
+    dir := t.TempDir()
+    config := logger.LogConfig{
+        FilePath:       filepath.Join(dir, "app.log"),
+        FileLevel:      "info",
+        EnableRotation: true,
+        FileSink: logger.FileSinkConfig{
+            RotationHooks: logger.RotationHooks{Now: newRotationClock(), Synchronous: true},
+        },
+    }
+    first, err := logger.NewLogger(config)
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    second, err := logger.NewLogger(config)
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    defer second.Close()
+
+    first.Info("First before rotation")
+    second.Info("Second before rotation")
+    // Both loggers write through the rotated writer
+    if err := first.Rotate(); err != nil {
+        t.Fatalf("Failed to rotate: %v", err)
+    }
+    second.Info("Second after rotation")
+    first.Close()
+    first.Info("First after close")
+    second.Info("Second after close")
+
+    backups, _ := filepath.Glob(filepath.Join(dir, "app-*"))
+    if len(backups) != 1 {
+        t.Fatalf("Expected one backup, got %v", backups)
+    }
+    rotated, _ := os.ReadFile(backups[0])
+    current, _ := os.ReadFile(config.FilePath)
+    if strings.Count(string(rotated), "\n") != 2 || !strings.Contains(string(rotated), "Second before rotation") {
+        t.Errorf("Expected the entries of both loggers in the backup, got '%s'", rotated)
+    }
+    if !strings.Contains(string(current), "Second after rotation") || !strings.Contains(string(current), "Second after close") ||
+        strings.Contains(string(current), "First after close") {
+        t.Errorf("Unexpected entries in the current file: '%s'", current)
+    }
+}
+
+func TestSharedLogFileRemoved(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "app.log")
+    first, err := logger.NewLogger(logger.LogConfig{FilePath: path, FileLevel: "info"})
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    defer first.Close()
+    first.Info("Before removal")
+    os.Remove(path)
+
+    // A file removed since it was opened is opened anew instead of shared
+    second, err := logger.NewLogger(logger.LogConfig{FilePath: path, FileLevel: "info"})
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    defer second.Close()
+    second.Info("After removal")
+    if data, _ := os.ReadFile(path); !strings.Contains(string(data), "After removal") {
+        t.Errorf("Expected the new logger to write to a new file, got '%s'", data)
+    }
+}
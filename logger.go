@@ -37,7 +37,7 @@
 package logger
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
 	"log"
@@ -46,10 +46,8 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
-
-	"github.com/fatih/color"
-	"github.com/natefinch/lumberjack"
 )
 
 // Global variable for the logger instance
@@ -58,7 +56,9 @@ var (
     mu          sync.Mutex
 )
 // InitLogger initializes the logger and saves the instance in the global variable logInstance.
-// If the logger is already initialized, it will be reset and re-initialized with the new configuration.
+// If the logger is already initialized, it will be reset and re-initialized with the new configuration:
+// the files and sinks of the previous logger are closed once its queued entries are written, except
+// the custom sinks the new configuration still uses.
 //
 // Arguments:
 //   - config (LogConfig): Logger configuration with settings for log level, format, file output, and rotation.
@@ -70,17 +70,47 @@ func InitLogger(config LogConfig) error {
     defer mu.Unlock()
 
     // Reset the logger if it is already initialized
-    if logInstance != nil {
-        logInstance = nil
+    held := startupHolder
+    previous := logInstance
+    reloaded := previous != nil && previous != held
+    if reloaded {
+        previous.stopBackground()
+        previous.detachFiles()
     }
+    logInstance = nil
 
     // Logger initialization
     var err error
-    logInstance, err = NewLogger(config)
+    setErrorOutput(config.ErrorOutput)
+    var diag *diagnosticsFile
+    logInstance, err = newLogger(config)
+    if err == nil && logInstance.Config.Diagnostics != nil {
+        if diag, err = logInstance.newDiagnostics(*logInstance.Config.Diagnostics); err != nil {
+            logInstance.Close()
+            err = fmt.Errorf("invalid diagnostics: %v", err)
+        }
+    }
+    if reloaded {
+        previous.release(logInstance)
+    }
     if err != nil {
-        fmt.Println("Logger initialization error:", err)
+        fmt.Fprintln(errorWriter(), "Logger initialization error:", err)
+        // Entries held since the startup stay held for the next attempt
+        logInstance = held
         return err
     }
+    logInstance.hub = globalHub
+    logInstance.applyLoggerLevels()
+    forgetConfig(false)
+    setDiagnostics(diag)
+    if reloaded {
+        diagnose(1, InfoLevel, "config", "Logger reconfigured")
+    }
+    if held != nil {
+        startupHolder = nil
+        held.stopBackground()
+        logInstance.replay(held.ring.snapshot())
+    }
 
     return nil
 }
@@ -89,26 +119,84 @@ func InitLogger(config LogConfig) error {
 func ResetLogger() {
     mu.Lock()
     defer mu.Unlock()
-    logInstance = nil
+    if logInstance != nil {
+        logInstance.stopBackground()
+        logInstance.detachFiles()
+    }
+    logInstance, startupHolder = nil, nil
+    setErrorOutput(nil)
+    setDiagnostics(nil)
+    forgetConfig(true)
 }
 
 // LogConfig represents the configuration settings for the logger.
 type LogConfig struct {
-    FilePath       string         // Full path to the log file.
-    Format         string         // Log format: "standard" or "json".
-    FileLevel      interface{}    // Log level for file output: can be a string or a number.
-    ConsoleLevel   interface{}    // Log level for console output: can be a string or a number.
-    ConsoleOutput  bool           // Whether to output logs to the console.
-    EnableRotation bool           // Whether to enable log rotation.
-    RotationConfig RotationConfig // Settings for log rotation.
+    FilePath          string                 // Path to the log file; "~" and environment variables are expanded, see ExpandPath.
+    Format            string                 // Log format: "standard" or "json".
+    FileFormat        string                 // Log format for file output, overrides Format if set.
+    ConsoleFormat     string                 // Log format for console output, overrides Format if set; "json-pretty" indents JSON.
+    FileLevel         interface{}            // Log level for file output: can be a Level, a string or a number.
+    ConsoleLevel      interface{}            // Log level for console output: can be a Level, a string or a number.
+    ConsoleOutput     bool                   // Whether to output logs to the console.
+    EnableRotation    bool                   // Whether to enable log rotation.
+    RotationConfig    RotationConfig         // Settings for log rotation.
+    Verbosity         int                    // Maximum verbosity enabled for V(n) loggers (klog-style -v).
+    ThreadInfo        bool                   // Whether to add goroutine, OS thread and LockOSThread state to entries.
+    RingBufferSize    int                    // Number of recent entries kept in memory, 0 disables the ring buffer.
+    RingBufferLevel   interface{}            // Log level for the ring buffer, independent of the outputs (default: most verbose).
+    FileShards        int                    // Number of files the file output is spread across by goroutine, see MergeShards.
+    FileSink          FileSinkConfig         // Low-level settings of the file output.
+    Processors        []Processor            `json:"-"` // Processors applied to every entry before any output, see Processor.
+    FileProcessors    []Processor            `json:"-"` // Processors applied only to entries written to the file.
+    ConsoleProcessors []Processor            `json:"-"` // Processors applied only to entries written to the console.
+    Transforms        []Transform            // Declarative transformations applied after Processors, see Transform.
+    Escalations       []EscalationRule       // Rules raising the severity of matching entries, see EscalationRule.
+    Retention         string                 // Default retention hint of entries (e.g. "30d"), see WithRetention.
+    Disabled          bool                   // Kill switch turning all output of the logger off, see Disable.
+    StrictKeys        bool                   // Whether to remove fields with unregistered keys or mistyped values, see RegisterKey.
+    Sampler           SamplerFunc            `json:"-"` // Decides which entries logged with a context are written, see SamplerFunc.
+    Async             bool                   // Whether entries are written to the outputs by a background goroutine, ERROR and FATAL first, see Flush.
+    QueueSize         int                    // Number of entries queued for the background writer in async mode (default: 1024).
+    Sinks             []SinkConfig           // Named outputs written in addition to the file and console outputs, see SinkConfig.
+    Routes            []RouteRule            // Rules directing entries to the outputs, see RouteRule.
+    Syslog            *SyslogConfig          // Syslog output, nil to disable it, see SyslogConfig.
+    ETW               *ETWConfig             // Event Tracing for Windows output, nil to disable it, see ETWConfig.
+    GELF              *GELFConfig            // Graylog output in the GELF format over UDP or TCP, nil to disable it, see GELFConfig.
+    Loki              *LokiConfig            // Grafana Loki output pushing batches of entries over HTTP, nil to disable it, see LokiConfig.
+    LevelFiles        map[string]string      // Additional files by least severe level, e.g. {"warning": "error.log"}, rotated independently.
+    EntryIDs          bool                   // Whether to add a unique, time-sortable ULID to every entry in the "entry_id" field.
+    StackTraceLevel   interface{}            // Least severe level of entries with a stack trace in the "stacktrace" field, "none" to disable (default: "error").
+    ConsoleOverflow   string                 // Handling of console lines longer than the terminal: "wrap", "truncate" or empty to keep them; file output is unchanged.
+    ConsoleWidth      int                    // Width of console lines for ConsoleOverflow, 0 to detect the terminal width.
+    ColorTheme        string                 // Console color theme: "default", "solarized", "monochrome" or a theme added to Themes.
+    ColorStyles       map[string]Style       // Console styles by level overriding the theme, e.g. {"error": {Foreground: "#ff5f00", Bold: true}}.
+    ConsoleGlyphs     bool                   // Whether console lines start with a glyph of their level such as ✔ or ⚠, see Glyphs.
+    LevelNames        map[string]string      // Level names shown by the console in the standard format, e.g. {"warning": "WRN"}; other outputs keep the canonical names.
+    Burst             BurstConfig            // Temporary verbose file output after errors, see BurstConfig.
+    Sampling          *SamplingConfig        // Sampling of repeated entries, nil to disable it, see SamplingConfig.
+    Repeats           *RepeatConfig          // Collapsing of consecutive identical entries, nil to disable it, see RepeatConfig.
+    Redaction         *RedactionConfig       // Redaction of secrets in messages and fields before any output, nil to disable it, see RedactionConfig.
+    Hashing           *HashingConfig         // Salted hashing of identifying fields before any output, nil to disable it, see HashingConfig.
+    LoggerLevels      map[string]interface{} // Levels of the named loggers of the global logger, e.g. {"db": "trace", "server.*": "debug"}, see GetLogger.
+    DebugTargets      map[string][]string    // Field values whose entries bypass the output levels, e.g. {"user_id": {"42"}}, see SetDebugTargets.
+    LevelWindows      []LevelWindow          // Recurring time windows with other levels of the file and console outputs, see LevelWindow.
+    Output            io.Writer              `json:"-"` // Writer of the console output instead of stdout, e.g. a bytes.Buffer in tests; setting it enables the console output.
+    ErrorOutput       io.Writer              `json:"-"` // Writer of the errors of the global logger itself printed by the default error handler (default: stdout), see SetErrorHandler.
+    RelativeToExe     bool                   // Whether relative paths of log files are resolved against the directory of the executable instead of the working directory.
+    Diagnostics       *DiagnosticsConfig     // File receiving the errors, drops and reloads of the global logger itself instead of ErrorOutput, nil to disable it; rejected by NewLogger, see DiagnosticsConfig.
 }
 
 // RotationConfig contains settings for log rotation.
 type RotationConfig struct {
-    MaxSize    int  // Maximum size in megabytes before rotating logs.
-    MaxBackups int  // Maximum number of old log files to keep.
-    MaxAge     int  // Maximum number of days to keep old log files.
-    Compress   bool // Whether to compress old log files.
+    MaxSize       int           // Maximum size in megabytes before rotating logs.
+    MaxBackups    int           // Maximum number of old log files to keep.
+    MaxAge        int           // Maximum number of days to keep old log files.
+    Compress      bool          // Whether to compress old log files.
+    ThinAfter     int           // Number of days after which rotated files keep only entries at ThinLevel or above, 0 disables thinning.
+    ThinLevel     interface{}   // Least severe level kept in thinned files (default: "warning").
+    Interval      time.Duration // Rotate files at least this often, e.g. 24h; 0 rotates by size only.
+    AlignInterval bool          // Whether interval rotations happen at wall-clock boundaries, so that replicas rotate together.
+    TimeZone      string        // IANA time zone of the boundaries, e.g. "Europe/Berlin" (default: UTC).
 }
 
 // Logger represents a customizable logger with various configuration options.
@@ -116,9 +204,70 @@ type Logger struct {
     FileLogger      *log.Logger
     ConsoleLogger   *log.Logger
     Config          LogConfig
-    FileLogLevel    int
-    ConsoleLogLevel int
+    FileLogLevel    int // Deprecated: level of the file output at creation only, use State for the current level.
+    ConsoleLogLevel int // Deprecated: level of the console output at creation only, use State for the current level.
     LogLevelMap     map[string]int
+    fields          Fields          // Structured fields added to every entry, see WithFields.
+    hub             *entryHub       // Hooks and subscribers receiving entries, see AddHook and Subscribe.
+    ring            *ringBuffer     // Recent entries kept in memory, nil if disabled.
+    stop            *stopSignal     // Signal stopping the background jobs of the logger.
+    shards          *shardedWriter  // Sharded file output, nil unless FileShards is above 1.
+    syncer          *fileSyncer     // Applies the fsync policy of the file output, nil if disabled.
+    files           []*logFile      // Files of the file output.
+    processors      []Processor     // Processors applied to every entry: Config.Processors, the transforms, hashing, then redaction.
+    escalations     []escalation    // Compiled severity escalation rules.
+    ctx             context.Context // Context passed to the sampler, see WithContext.
+    async           *asyncWriter    // Background writer of the outputs, nil unless Config.Async is set.
+    batch           *BatchLogger    // Batch collecting the output of the logger, see Batch.
+    destination     string          // Single output the logger writes to, see File and Console; empty for all.
+    sinks           []Sink          // Outputs of the logger: the built-in outputs, then the configured sinks.
+    routes          []routeRule     // Compiled routing rules.
+    defaultRoute    *route          // Outputs of the entries matched by no routing rule.
+    levels          *outputLevels   // Current levels of the file and console outputs, see SetFileLevel.
+    stackLevel      int             // Least severe level of the entries with a stack trace, -1 if disabled.
+    burst           *burstCapture   // Burst capture state of the file output, nil if disabled.
+    messages        *samplingState  // Sampling of repeated entries, see SetSampling.
+    repeats         *repeatFilter   // Duplicate suppression state, nil if disabled.
+    targets         *debugTargets   // Field values whose entries bypass the output levels, see SetDebugTargets.
+    named           *atomic.Int64   // Own level of a named logger, see GetLogger; nil for other loggers.
+    name            string          // Name of a named logger, empty for other loggers.
+    base            *Logger         // Global logger a logger of GetLogger derives from, nil for other loggers.
+    added           Fields          // Fields added to a logger of GetLogger with WithFields, kept by rebase.
+    nop             bool            // Whether the logger discards everything, see NewNop.
+    origin          *callerLocation // Call site reported instead of the caller, for summaries of other entries.
+}
+
+// stopSignal is closed once to stop background jobs.
+type stopSignal struct {
+    once sync.Once
+    ch   chan struct{}
+}
+
+// newStopSignal creates an open stop signal.
+func newStopSignal() *stopSignal {
+    return &stopSignal{ch: make(chan struct{})}
+}
+
+// stopBackground stops the background jobs of the logger, waiting for the entries queued in
+// async mode to be written. It is safe to call several times.
+func (l *Logger) stopBackground() {
+    if l.stop != nil {
+        l.stop.once.Do(func() { close(l.stop.ch) })
+    }
+    if l.async != nil {
+        <-l.async.stopped
+    }
+}
+
+// abort releases what NewLogger set up before failing with the error: it stops the background jobs
+// and closes the log files and the sinks.
+func (l *Logger) abort(err error) (*Logger, error) {
+    l.stopBackground()
+    for _, file := range l.files {
+        file.Close()
+    }
+    closeSinks(l.sinks)
+    return nil, err
 }
 
 // setDefaults sets default values for the logger configuration.
@@ -132,6 +281,9 @@ func setDefaults(config *LogConfig) {
     if config.ConsoleLevel == nil {
         config.ConsoleLevel = "warning"
     }
+    if config.StackTraceLevel == nil {
+        config.StackTraceLevel = "error"
+    }
     if config.RotationConfig.MaxSize == 0 {
         config.RotationConfig.MaxSize = 10 // 10 MB
     }
@@ -143,11 +295,20 @@ func setDefaults(config *LogConfig) {
     }
 }
 
+// Defaults of the global logger used without InitLogger. They are strings so that release builds
+// can ship quieter defaults than development builds by setting them at build time, for example:
+//
+//	go build -ldflags "-X github.com/nir0k/logger.DefaultLevel=warning -X github.com/nir0k/logger.DefaultFormat=json"
+var (
+    DefaultLevel  = "info"     // Console log level of the default configuration.
+    DefaultFormat = "standard" // Log format of the default configuration: "standard" or "json".
+)
+
 // defaultConfig returns the default logger configuration.
 func defaultConfig() LogConfig {
     return LogConfig{
-        Format:        "standard",
-        ConsoleLevel:  "info",
+        Format:        DefaultFormat,
+        ConsoleLevel:  DefaultLevel,
         ConsoleOutput: true,
     }
 }
@@ -173,41 +334,27 @@ func ensureLoggerInitialized() {
 //   - (*Logger): Pointer to the new Logger instance.
 //   - error: Error if the configuration is invalid or the log file is inaccessible.
 func NewLogger(config LogConfig) (*Logger, error) {
+    if config.Diagnostics != nil {
+        return nil, fmt.Errorf("diagnostics are only supported by the global logger, see InitLogger")
+    }
+    return newLogger(config)
+}
+
+// newLogger creates a logger as NewLogger does, leaving the diagnostics of the configuration to InitLogger.
+func newLogger(config LogConfig) (*Logger, error) {
     // Set default values
     setDefaults(&config)
+    if err := config.resolvePaths(); err != nil {
+        return nil, err
+    }
 
     l := &Logger{
-        Config: config,
-        LogLevelMap: map[string]int{
-            "trace":   5,
-            "debug":   4,
-            "info":    3,
-            "warning": 2,
-            "error":   1,
-            "fatal":   0,
-        },
-    }
-
-    // Function to get the numeric value of the log level
-    getLogLevel := func(level interface{}) (int, error) {
-        switch v := level.(type) {
-        case string:
-            logLevel, ok := l.LogLevelMap[strings.ToLower(v)]
-            if !ok {
-                return 0, fmt.Errorf("invalid log level: %s", v)
-            }
-            return logLevel, nil
-        case int:
-            if v < 0 {
-                return 0, nil // "fatal" level for values less than 0
-            } else if v > 5 {
-                return 5, nil // "trace" level for values greater than 5
-            }
-            return v, nil
-        default:
-            return 0, fmt.Errorf("invalid type for log level: %T", v)
-        }
+        Config:      config,
+        LogLevelMap: levelMap(),
+        hub:         &entryHub{},
+        stop:        newStopSignal(),
     }
+    getLogLevel := l.parseLevel
 
     // Set log levels for file and console
     fileLevel, err := getLogLevel(config.FileLevel)
@@ -223,6 +370,67 @@ func NewLogger(config LogConfig) (*Logger, error) {
         return nil, fmt.Errorf("invalid console log level: %v", err)
     }
     l.ConsoleLogLevel = consoleLevel
+    l.stackLevel, err = l.stackTraceLevel(config.StackTraceLevel)
+    if err != nil {
+        return nil, fmt.Errorf("invalid stack trace level: %v", err)
+    }
+    l.messages = newSamplingState(config.Sampling)
+    l.repeats = newRepeatFilter(config.Repeats)
+    l.targets = newDebugTargets(config.DebugTargets)
+    l.levels = &outputLevels{}
+    l.levels.file.Store(int64(fileLevel))
+    l.levels.console.Store(int64(consoleLevel))
+    l.levels.verbosity.Store(int64(config.Verbosity))
+    if _, err := l.parseLoggerLevels(config.LoggerLevels); err != nil {
+        return nil, fmt.Errorf("invalid logger levels: %v", err)
+    }
+    schedule, err := l.newLevelSchedule(config.LevelWindows)
+    if err != nil {
+        return nil, fmt.Errorf("invalid level windows: %v", err)
+    }
+
+    // Set up the in-memory ring buffer of recent entries
+    if config.RingBufferSize > 0 {
+        ringLevel := maxLevelValue(l.LogLevelMap)
+        if config.RingBufferLevel != nil {
+            ringLevel, err = getLogLevel(config.RingBufferLevel)
+            if err != nil {
+                return nil, fmt.Errorf("invalid ring buffer log level: %v", err)
+            }
+        }
+        l.ring = newRingBuffer(config.RingBufferSize, ringLevel)
+    }
+
+    // Compile the configured transformations after the processors
+    transforms, err := compileTransforms(config.Transforms)
+    if err != nil {
+        return nil, fmt.Errorf("invalid transforms: %v", err)
+    }
+    l.processors = append(append([]Processor{}, config.Processors...), transforms...)
+    if config.Hashing != nil {
+        hash, err := HashFields(*config.Hashing)
+        if err != nil {
+            return nil, fmt.Errorf("invalid hashing: %v", err)
+        }
+        l.processors = append(l.processors, hash)
+    }
+    if config.Redaction != nil {
+        // Redaction runs last so that no processor reintroduces secrets
+        redact, err := Redact(*config.Redaction)
+        if err != nil {
+            return nil, fmt.Errorf("invalid redaction: %v", err)
+        }
+        l.processors = append(l.processors, redact)
+    }
+
+    l.escalations, err = compileEscalations(config.Escalations, l.LogLevelMap)
+    if err != nil {
+        return nil, fmt.Errorf("invalid escalations: %v", err)
+    }
+
+    if config.Retention != "" {
+        l.fields = Fields{RetentionField: config.Retention}
+    }
 
     // Set up file logging if a path is specified
     if config.FilePath != "" {
@@ -230,103 +438,457 @@ func NewLogger(config LogConfig) (*Logger, error) {
         if _, err := os.Stat(dir); os.IsNotExist(err) {
             return nil, fmt.Errorf("log directory does not exist: %s", dir)
         }
+        if config.EnableRotation {
+            if _, err := config.RotationConfig.location(); err != nil {
+                return nil, fmt.Errorf("invalid rotation time zone: %v", err)
+            }
+        }
 
         var fileWriter io.Writer
-        if config.EnableRotation {
-            fileWriter = &lumberjack.Logger{
-                Filename:   config.FilePath,
-                MaxSize:    config.RotationConfig.MaxSize,
-                MaxBackups: config.RotationConfig.MaxBackups,
-                MaxAge:     config.RotationConfig.MaxAge,
-                Compress:   config.RotationConfig.Compress,
+        if config.FileShards > 1 {
+            shards, err := newShardedWriter(config)
+            if err != nil {
+                return nil, err
             }
+            l.shards = shards
+            l.files = shards.files
+            fileWriter = shards
         } else {
-            file, err := os.OpenFile(config.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+            file, err := openLogFile(config.FilePath, config)
             if err != nil {
-                return nil, fmt.Errorf("failed to open log file: %v", err)
+                return l.abort(err)
             }
+            l.files = []*logFile{file}
             fileWriter = file
         }
 
+        if config.FileSink.Sync.enabled() {
+            l.syncer, err = l.newFileSyncer(config.FileSink.Sync, l.files)
+            if err != nil {
+                return l.abort(err)
+            }
+        }
+
         l.FileLogger = log.New(fileWriter, "", 0)
+
+        if config.EnableRotation && config.RotationConfig.ThinAfter > 0 {
+            if _, err := getLogLevel(config.RotationConfig.thinLevel()); err != nil {
+                return l.abort(fmt.Errorf("invalid thinning log level: %v", err))
+            }
+            go l.runThinning()
+        }
     } else {
         l.FileLogger = nil // No file logger if FilePath is not set
     }
 
     // Set up console output
-    if config.ConsoleOutput {
-        l.ConsoleLogger = log.New(os.Stdout, "", 0)
+    if config.Output != nil {
+        l.ConsoleLogger = log.New(config.Output, "", 0)
+    } else if config.ConsoleOutput {
+        l.ConsoleLogger = log.New(consoleWriter(), "", 0)
+    }
+
+    // Set up the sinks and the routing rules directing entries to them
+    if l.FileLogger != nil {
+        file := newOutputSink(destinationFile, "file", l.FileLogger, &l.levels.file, l.fileFormat())
+        file.processors = config.FileProcessors
+        file.syncer = l.syncer
+        file.writeLimit = config.maxWrite()
+        if file.burst, err = l.newBurstCapture(config.Burst); err != nil {
+            return l.abort(fmt.Errorf("invalid burst capture: %v", err))
+        }
+        l.burst = file.burst
+        l.addSink(file, true)
+    }
+    if err := l.openLevelFiles(config.LevelFiles); err != nil {
+        return l.abort(err)
+    }
+    if l.ConsoleLogger != nil {
+        console := newOutputSink(destinationConsole, "console", l.ConsoleLogger, &l.levels.console, l.consoleFormat())
+        console.processors = config.ConsoleProcessors
+        if console.theme, err = l.compileTheme(config.ColorTheme, config.ColorStyles); err != nil {
+            return l.abort(fmt.Errorf("invalid color theme: %v", err))
+        }
+        if f, ok := config.Output.(*os.File); config.Output != nil && (!ok || !isTerminal(f)) {
+            console.theme = nil // Writers other than terminals get uncolored lines
+        }
+        console.glyphs = config.ConsoleGlyphs
+        console.pause = &consolePause{}
+        switch strings.ToLower(config.ConsoleOverflow) {
+        case "", OverflowWrap, OverflowTruncate:
+            console.width = newConsoleWidth(config.ConsoleOverflow, config.ConsoleWidth)
+        default:
+            return l.abort(fmt.Errorf("invalid console overflow %q, use \"wrap\" or \"truncate\"", config.ConsoleOverflow))
+        }
+        if console.levelNames, err = l.levelNames(config.LevelNames); err != nil {
+            return l.abort(fmt.Errorf("invalid level names: %v", err))
+        }
+        l.addSink(console, true)
+    }
+    if config.Syslog != nil {
+        syslog, err := l.newSyslogSink(*config.Syslog)
+        if err != nil {
+            return l.abort(err)
+        }
+        l.addSink(syslog, true)
+    }
+    if config.ETW != nil {
+        etw, err := l.newETWSink(*config.ETW)
+        if err != nil {
+            return l.abort(err)
+        }
+        l.addSink(etw, true)
+    }
+    if config.GELF != nil {
+        gelf, err := l.newGELFSink(*config.GELF)
+        if err != nil {
+            return l.abort(err)
+        }
+        l.addSink(gelf, true)
+    }
+    if config.Loki != nil {
+        loki, err := l.newLokiSink(*config.Loki)
+        if err != nil {
+            return l.abort(err)
+        }
+        l.addSink(loki, true)
+    }
+    if err := l.openSinks(config.Sinks); err != nil {
+        return l.abort(err)
+    }
+    if err := l.compileRoutes(config.Routes); err != nil {
+        return l.abort(fmt.Errorf("invalid routes: %v", err))
+    }
+
+    if config.Async {
+        l.async = l.newAsyncWriter(config.QueueSize)
+    }
+    if schedule != nil {
+        go schedule.run()
+    }
+    if l.repeats != nil {
+        go l.runRepeats()
     }
 
     return l, nil
 }
 
+// parseLevel returns the numeric value of a log level given as a level name, a Level or a number.
+// Numbers outside the range of registered levels are clamped to it.
+func (l *Logger) parseLevel(level interface{}) (int, error) {
+    switch v := level.(type) {
+    case string:
+        logLevel, ok := l.LogLevelMap[strings.ToLower(v)]
+        if !ok {
+            return 0, fmt.Errorf("invalid log level: %s", v)
+        }
+        return logLevel, nil
+    case Level:
+        return l.parseLevel(int(v))
+    case float64:
+        // Numbers decoded from configuration files
+        return l.parseLevel(int(v))
+    case int:
+        maxLevel := maxLevelValue(l.LogLevelMap)
+        if v < 0 {
+            return 0, nil // "fatal" level for values less than 0
+        } else if v > maxLevel {
+            return maxLevel, nil // Most verbose level for values above the highest registered level
+        }
+        return v, nil
+    default:
+        return 0, fmt.Errorf("invalid type for log level: %T", v)
+    }
+}
+
 // log is an internal method that writes messages with the specified level and arguments.
 func (l *Logger) log(level string, v ...interface{}) {
+    l.logSkip(4, level, v...)
+}
+
+// logf logs a formatted message, see log. Nop loggers return before the message is built.
+func (l *Logger) logf(level, format string, v []interface{}) {
+    if l.nop {
+        return
+    }
+    l.logSkip(4, level, sprintf(format, v))
+}
+
+// admit decides where an entry at the level goes before it is built: whether it passes the output
+// levels, whether it bypasses the levels of the outputs, whether the ring buffer keeps it and whether
+// the sampler routes it.
+func (l *Logger) admit(level string, msgLevel int) (passes, bypass, toRing, sampling bool) {
+    // Now the check is for "higher or equal" for output
+    passes = level == "print" || l.levels.allow(msgLevel) || l.enabled(level, msgLevel)
+    // Named loggers with their own level replace the output levels
+    named, namedPasses := l.namedPasses(msgLevel)
+    if named && level != "print" {
+        passes = namedPasses
+    }
+    // Entries of targeted users or requests bypass the levels
+    targeted := !passes && l.targets.matches(l.fields)
+    bypass = targeted || (named && passes)
+    passes = passes || targeted
+    toRing = l.ring.accepts(level, msgLevel)
+    // Entries logged with a context are routed by the sampler instead of the output levels
+    sampling = l.ctx != nil && l.Config.Sampler != nil && level != "print"
+    return passes, bypass, toRing, sampling
+}
+
+// logSkip writes messages with the specified level and arguments, reporting the caller
+// found skip frames above logSkip itself.
+func (l *Logger) logSkip(skip int, level string, v ...interface{}) {
+    if l.nop {
+        return
+    }
+    if l.base != nil {
+        if current := l.rebase(); current != nil {
+            current.logSkip(skip+1, level, v...)
+            return
+        }
+    }
     msgLevel, ok := l.LogLevelMap[level]
     if (!ok && level != "print") {
         return
     }
+    if l.suppressed() {
+        return
+    }
 
-    // Now the check is for "higher or equal" for output
-    if (level != "print" && msgLevel > l.FileLogLevel && msgLevel > l.ConsoleLogLevel) {
+    // Apply severity escalation rules before the level check
+    if len(l.escalations) > 0 && level != "print" {
+        message := sprint(v)
+        level, msgLevel = l.escalate(level, msgLevel, message, l.fields)
+        v = []interface{}{message}
+    }
+
+    passes, bypass, toRing, sampling := l.admit(level, msgLevel)
+    if !passes && !toRing && !sampling {
         return
     }
 
-    timestamp := time.Now().Format(time.RFC3339)
-    pid := os.Getpid()
+    // Repeated messages are sampled before the entry is built
+    var sampled bool
+    var dropped uint64
+    if messages := l.messages.load(); passes && messages != nil && level != "print" && level != "fatal" {
+        message := sprint(v)
+        v = []interface{}{message}
+        passes, sampled, dropped = messages.check(level, message)
+        if !passes && !toRing && !sampling {
+            return
+        }
+    }
 
     // Get caller information
-    _, file, line, ok := runtime.Caller(3)
+    file, line, ok := caller(skip)
+    if l.origin != nil {
+        file, line, ok = l.origin.file, l.origin.line, true
+    }
     if !ok {
         file = "unknown"
         line = 0
-    } else {
-        file = trimPathToProject(file)
     }
 
-    prefix := fmt.Sprintf("[%s] [PID: %d] [%s:%d] [%s] ", timestamp, pid, file, line, strings.ToUpper(level))
+    // Consecutive identical messages are collapsed, summarizing the previous run first
+    if passes && l.repeats != nil && level != "print" && level != "fatal" {
+        message := sprint(v)
+        v = []interface{}{message}
+        suppress, ended := l.repeats.check(level, message, l.fields, file, line)
+        if ended.count > 0 {
+            l.logRepeated(ended)
+        }
+        if suppress {
+            passes = false
+            if !toRing && !sampling {
+                return
+            }
+        }
+    }
 
-    var logEntry string
+    now := time.Now()
+    fields := l.fields
+    var pooled Fields
+    stack := level != "print" && msgLevel <= l.stackLevel
+    if l.Config.ThreadInfo || l.Config.EntryIDs || sampled || stack {
+        // The fields added to each entry share a single pooled copy of the fields of the logger
+        pooled = getEntryFields()
+        defer putEntryFields(pooled)
+        fields = pooled
+        for key, value := range l.fields {
+            fields[key] = value
+        }
+        if l.Config.ThreadInfo {
+            addThreadInfo(fields)
+        }
+        if l.Config.EntryIDs {
+            fields[EntryIDField] = newULID(now)
+        }
+        if sampled {
+            addSamplingInfo(fields, dropped)
+        }
+        if stack {
+            fields[StackTraceField] = captureStack()
+        }
+    }
+    if l.Config.StrictKeys {
+        fields = checkKeys(fields)
+    }
+
+    entry := Entry{
+        Time:    now,
+        Level:   level,
+        Message: sprint(v),
+        PID:     os.Getpid(),
+        File:    file,
+        Line:    line,
+        Fields:  fields,
+        pooled:  pooled != nil,
+    }
+
+    entry, keep := applyProcessors("processor", l.processors, entry)
+    if !keep {
+        return
+    }
 
-    if strings.ToLower(l.Config.Format) == "json" {
-        logData := map[string]interface{}{
-            "timestamp": timestamp,
-            "level":     level,
-            "pid":       pid,
-            "file":      file,
-            "line":      line,
-            "message":   fmt.Sprint(v...),
+    if sampling {
+        passes = false
+        guard("sampler", func() {
+            passes = l.Config.Sampler(l.ctx, entry)
+        })
+    }
+    if passes && l.burst.triggers(level, msgLevel) {
+        l.startBurst(entry.Time)
+    }
+    if toRing {
+        l.ring.add(entry.retain())
+    }
+    if !passes {
+        return
+    }
+    if level == "fatal" {
+        // The console entries held by PauseConsole are written before the application exits
+        l.ResumeConsole()
+    }
+    l.hub.publish(entry)
+
+    // Entries passed by the sampler, targeted or passed by a named level are written regardless of the output levels
+    sampling = sampling || bypass
+    if l.batch != nil {
+        l.writeOutputs(entry, level, msgLevel, sampling)
+        return
+    }
+    if l.async != nil {
+        if level != "fatal" && l.async.enqueue(l, entry.retain(), level, msgLevel, sampling) {
+            return
+        }
+        if level == "fatal" {
+            // Fatal entries are written right away, then the backlog before the application exits
+            l.writeOutputs(entry, level, msgLevel, sampling)
+            l.async.flush()
+            return
         }
-        jsonBytes, _ := json.Marshal(logData)
-        logEntry = string(jsonBytes)
-    } else {
-        logEntry = prefix + fmt.Sprint(v...)
     }
+    l.writeOutputs(entry, level, msgLevel, sampling)
+}
 
-    // Check log level for file and console
-    if l.FileLogger != nil && (level == "print" || msgLevel <= l.FileLogLevel) {
-        l.FileLogger.Println(logEntry)
+// writeOutputs writes the entry to the sinks it is routed to if their levels allow it,
+// rendering it separately for each sink, and reports the write errors as one OutputError.
+func (l *Logger) writeOutputs(entry Entry, level string, msgLevel int, sampling bool) {
+    r := l.routeOf(level, msgLevel, entry.Fields)
+    if r == nil {
+        return
     }
+    var errs outputErrors
+    for _, i := range r.sinks {
+        s := l.sinks[i]
+        if l.destination != "" && s.Name() != l.destination {
+            continue
+        }
+        if level != "print" && !sampling && !s.Enabled(level, msgLevel) {
+            continue
+        }
+        if l.batch != nil {
+            l.batch.add(i, s, entry, level, msgLevel)
+            continue
+        }
+        errs.add(s, l.writeSink(s, entry, level, msgLevel))
+    }
+    // The failures of the sinks are reported together
+    errs.report()
+}
 
-    if l.Config.ConsoleOutput && (level == "print" || msgLevel <= l.ConsoleLogLevel) {
-        colorFunc := color.New(color.FgWhite).SprintFunc()
-        switch level {
-        case "trace":
-            colorFunc = color.New(color.FgCyan).SprintFunc()
-        case "debug":
-            colorFunc = color.New(color.FgBlue).SprintFunc()
-        case "info":
-            colorFunc = color.New(color.FgGreen).SprintFunc()
-        case "warning":
-            colorFunc = color.New(color.FgYellow).SprintFunc()
-        case "error":
-            colorFunc = color.New(color.FgRed).SprintFunc()
-        case "fatal":
-            colorFunc = color.New(color.FgHiRed).SprintFunc()
+// writeSink renders the entry and writes it to the sink, returning the write error.
+func (l *Logger) writeSink(s Sink, entry Entry, level string, msgLevel int) error {
+    if ls, ok := s.(lineSink); ok {
+        buf := getLineBuffer()
+        defer putLineBuffer(buf)
+        line, ok := ls.appendLine(*buf, entry)
+        *buf = line
+        if !ok {
+            return nil
         }
-        l.ConsoleLogger.Println(colorFunc(logEntry))
+        err := ls.writeLines(line)
+        ls.afterWrite(level, msgLevel)
+        return err
+    }
+    if _, custom := s.(*namedSink); custom {
+        // Custom sinks may keep the entry
+        entry = entry.retain()
+    }
+    return writeEntry(s, entry)
+}
+
+// sprint formats the arguments like fmt.Sprint, evaluating lazy arguments, without allocating for a
+// single string.
+func sprint(v []interface{}) string {
+    if len(v) == 1 {
+        switch m := v[0].(type) {
+        case string:
+            return m
+        case formatted:
+            return m.String()
+        }
+    }
+    return fmt.Sprint(lazyArgs(v)...)
+}
+
+// callerLocation is the file, trimmed to the project level, and line of a call site.
+type callerLocation struct {
+    file string
+    line int
+}
+
+// Call sites resolved by caller, by program counter.
+var (
+    callersMu sync.RWMutex
+    callers   = map[uintptr]callerLocation{}
+)
+
+// caller returns the file, trimmed to the project level, and line of the caller skip frames above
+// the caller of caller, like runtime.Caller. Call sites are cached by program counter, so that
+// resolving a known call site does not allocate; this assumes the working directory does not
+// change while logging.
+func caller(skip int) (string, int, bool) {
+    var pcs [1]uintptr
+    if runtime.Callers(skip+2, pcs[:]) == 0 {
+        return "", 0, false
     }
+    callersMu.RLock()
+    loc, ok := callers[pcs[0]]
+    callersMu.RUnlock()
+    if ok {
+        return loc.file, loc.line, true
+    }
+
+    frame, _ := runtime.CallersFrames([]uintptr{pcs[0]}).Next()
+    if frame.File == "" {
+        return "", 0, false
+    }
+    loc = callerLocation{file: trimPathToProject(frame.File), line: frame.Line}
+    callersMu.Lock()
+    callers[pcs[0]] = loc
+    callersMu.Unlock()
+    return loc.file, loc.line, true
 }
 
 // trimPathToProject trims the file path to the project level.
@@ -362,14 +924,14 @@ func findProjectDir() string {
     return ""
 }
 
-// GetLoggerConfig returns the current logger configuration.
+// GetLoggerConfig returns a deep copy of the configuration of the global logger, see LogConfig.Clone.
+// It is safe to call concurrently with InitLogger.
 //
 // Returns:
-//   - (LogConfig): Logger configuration used in logInstance.
+//   - (LogConfig): Copy of the logger configuration used in logInstance.
 func GetLoggerConfig() LogConfig {
-    ensureLoggerInitialized()
-    if logInstance != nil {
-        return logInstance.Config
+    if l := currentLogger(); l != nil {
+        return l.GetConfig()
     }
     return LogConfig{}
 }
@@ -670,7 +1232,7 @@ func (l *Logger) Fatal(v ...interface{}) {
 //   - format (string): Format string.
 //   - v (...interface{}): Values for formatting the message.
 func (l *Logger) Tracef(format string, v ...interface{}) {
-    l.log("trace", fmt.Sprintf(format, v...))
+    l.logf("trace", format, v)
 }
 
 // Debugf logs a formatted message at the DEBUG level.
@@ -679,7 +1241,7 @@ func (l *Logger) Tracef(format string, v ...interface{}) {
 //   - format (string): Format string.
 //   - v (...interface{}): Values for formatting the message.
 func (l *Logger) Debugf(format string, v ...interface{}) {
-    l.log("debug", fmt.Sprintf(format, v...))
+    l.logf("debug", format, v)
 }
 
 // Infof logs a formatted message at the INFO level.
@@ -688,7 +1250,7 @@ func (l *Logger) Debugf(format string, v ...interface{}) {
 //   - format (string): Format string.
 //   - v (...interface{}): Values for formatting the message.
 func (l *Logger) Infof(format string, v ...interface{}) {
-    l.log("info", fmt.Sprintf(format, v...))
+    l.logf("info", format, v)
 }
 
 // Warningf logs a formatted message at the WARNING level.
@@ -697,7 +1259,7 @@ func (l *Logger) Infof(format string, v ...interface{}) {
 //   - format (string): Format string.
 //   - v (...interface{}): Values for formatting the message.
 func (l *Logger) Warningf(format string, v ...interface{}) {
-    l.log("warning", fmt.Sprintf(format, v...))
+    l.logf("warning", format, v)
 }
 
 // Errorf logs a formatted message at the ERROR level.
@@ -706,7 +1268,7 @@ func (l *Logger) Warningf(format string, v ...interface{}) {
 //   - format (string): Format string.
 //   - v (...interface{}): Values for formatting the message.
 func (l *Logger) Errorf(format string, v ...interface{}) {
-    l.log("error", fmt.Sprintf(format, v...))
+    l.logf("error", format, v)
 }
 
 // Fatalf logs a formatted message at the FATAL level and terminates the application.
@@ -715,7 +1277,7 @@ func (l *Logger) Errorf(format string, v ...interface{}) {
 //   - format (string): Format string.
 //   - v (...interface{}): Values for formatting the message.
 func (l *Logger) Fatalf(format string, v ...interface{}) {
-    l.log("fatal", fmt.Sprintf(format, v...))
+    l.logf("fatal", format, v)
     os.Exit(1)
 }
 
@@ -779,7 +1341,7 @@ func (l *Logger) Print(v ...interface{}) {
 //   - format (string): Format string.
 //   - v (...interface{}): Values for formatting the message.
 func (l *Logger) Printf(format string, v ...interface{}) {
-    l.log("print", fmt.Sprintf(format, v...))
+    l.logf("print", format, v)
 }
 
 // Println logs a message with a new line regardless of the logging level.
@@ -0,0 +1,66 @@
+package logger_test
+
+import (
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+// openFiles returns the number of open file descriptors of the process.
+func openFiles(t *testing.T) int {
+    t.Helper()
+    fds, err := os.ReadDir("/proc/self/fd")
+    if err != nil {
+        t.Skipf("Cannot list open files: %v", err)
+    }
+    return len(fds)
+}
+
+func TestNewLoggerErrorClosesFiles(t *testing.T) {
+    dir := t.TempDir()
+    configs := map[string]logger.LogConfig{
+        "console overflow": {ConsoleOutput: true, ConsoleOverflow: "scroll"},
+        "level names":      {ConsoleOutput: true, LevelNames: map[string]string{"loud": "LOUD"}},
+        "routes":           {Routes: []logger.RouteRule{{Sinks: []string{"missing"}}}},
+    }
+    for name, config := range configs {
+        config.FilePath = filepath.Join(dir, name+".log")
+        config.LevelFiles = map[string]string{"error": filepath.Join(dir, name+".error.log")}
+        before := openFiles(t)
+        if _, err := logger.NewLogger(config); err == nil {
+            t.Errorf("%s: expected an error", name)
+        }
+        if after := openFiles(t); after != before {
+            t.Errorf("%s: expected the log files to be closed, %d files open before and %d after", name, before, after)
+        }
+    }
+}
+
+func TestInitLoggerClosesReplacedFiles(t *testing.T) {
+    defer logger.ResetLogger()
+    dir := t.TempDir()
+    config := logger.LogConfig{
+        FilePath:   filepath.Join(dir, "app.log"),
+        LevelFiles: map[string]string{"error": filepath.Join(dir, "error.log")},
+        Sinks:      []logger.SinkConfig{{Name: "audit", Path: filepath.Join(dir, "audit.log"), Default: true}},
+    }
+    if err := logger.InitLogger(config); err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+    before := openFiles(t)
+    for i := 0; i < 5; i++ {
+        if err := logger.InitLogger(config); err != nil {
+            t.Fatalf("Failed to re-initialize logger: %v", err)
+        }
+    }
+    if after := openFiles(t); after != before {
+        t.Errorf("Expected the files of the replaced loggers to be closed, %d files open before and %d after", before, after)
+    }
+    logger.Error("After the reloads")
+    if data, _ := os.ReadFile(filepath.Join(dir, "audit.log")); !strings.Contains(string(data), "After the reloads") {
+        t.Errorf("Expected the current logger to write to its sink, got '%s'", data)
+    }
+}
@@ -8,7 +8,6 @@ import (
     "path/filepath"
     "strings"
     "testing"
-    "time"
 
     "github.com/nir0k/logger"
 )
@@ -18,6 +17,41 @@ func resetLogger() {
     logger.ResetLogger()
 }
 
+// discardStdout discards what is printed to stdout for the rest of the test, such as console entries
+// and the errors the logger reports about itself.
+func discardStdout(t *testing.T) {
+    t.Helper()
+    devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+    if err != nil {
+        t.Fatalf("Failed to open %s: %v", os.DevNull, err)
+    }
+    original := os.Stdout
+    os.Stdout = devNull
+    t.Cleanup(func() {
+        os.Stdout = original
+        devNull.Close()
+    })
+}
+
+// newFileLogger creates a logger writing to a file in a temporary directory and
+// returns it together with a function reading the file contents.
+func newFileLogger(t *testing.T, config logger.LogConfig) (*logger.Logger, func() string) {
+    t.Helper()
+    config.FilePath = filepath.Join(t.TempDir(), "app.log")
+    log, err := logger.NewLogger(config)
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    read := func() string {
+        data, err := os.ReadFile(config.FilePath)
+        if err != nil {
+            t.Fatalf("Failed to read log file: %v", err)
+        }
+        return string(data)
+    }
+    return log, read
+}
+
 func TestConsoleOutput(t *testing.T) {
     resetLogger()
     // Check logging only to console, without writing to file.
@@ -127,8 +161,7 @@ func TestNoFileLoggingWhenFilePathNotSet(t *testing.T) {
 func TestLogRotationWithCompression(t *testing.T) {
     resetLogger()
     // Check that log files are correctly rotated and compressed.
-    logFile := filepath.Join(os.TempDir(), "log_rotation.txt")
-    defer os.RemoveAll(filepath.Dir(logFile))
+    logFile := filepath.Join(t.TempDir(), "log_rotation.txt")
 
     config := logger.LogConfig{
         FilePath:      logFile,
@@ -143,6 +176,10 @@ func TestLogRotationWithCompression(t *testing.T) {
             MaxAge:     1,  // 1 day
             Compress:   true,
         },
+        // Rotate at 100 KB and compress before the rotating write returns
+        FileSink: logger.FileSinkConfig{
+            RotationHooks: logger.RotationHooks{MaxSizeBytes: 100 * 1024, Synchronous: true},
+        },
     }
 
     log, err := logger.NewLogger(config)
@@ -152,13 +189,10 @@ func TestLogRotationWithCompression(t *testing.T) {
 
     // Write enough messages to check rotation and compression
     smallMessage := strings.Repeat("A", 1024*10) // 10 KB
-    for i := 0; i < 110; i++ {
+    for i := 0; i < 11; i++ {
         log.Info("Message number", i, smallMessage)
     }
 
-    // Wait for rotation to occur
-    time.Sleep(2 * time.Second)
-
     // Check that rotation and compression occurred
     files, err := os.ReadDir(filepath.Dir(logFile))
     if err != nil {
@@ -181,8 +215,7 @@ func TestLogRotationWithCompression(t *testing.T) {
 func TestLogRotationWithoutCompression(t *testing.T) {
     resetLogger()
     // Check log rotation without compression.
-    logFile := filepath.Join(os.TempDir(), "log_rotation.txt")
-    defer os.RemoveAll(filepath.Dir(logFile))
+    logFile := filepath.Join(t.TempDir(), "log_rotation.txt")
 
     config := logger.LogConfig{
         FilePath:      logFile,
@@ -197,6 +230,10 @@ func TestLogRotationWithoutCompression(t *testing.T) {
             MaxAge:     1, // 1 day
             Compress:   false,
         },
+        // Rotate at 100 KB
+        FileSink: logger.FileSinkConfig{
+            RotationHooks: logger.RotationHooks{MaxSizeBytes: 100 * 1024, Synchronous: true},
+        },
     }
 
     log, err := logger.NewLogger(config)
@@ -206,13 +243,10 @@ func TestLogRotationWithoutCompression(t *testing.T) {
 
     // Write enough messages to trigger log rotation
     smallMessage := strings.Repeat("A", 1024*10) // 10 KB
-    for i := 0; i < 110; i++ {                   // 110 * 10 KB = 1.1 MB
+    for i := 0; i < 11; i++ {                    // 11 * 10 KB = 110 KB
         log.Info("Message number", i, smallMessage)
     }
 
-    // Wait for rotation to occur
-    time.Sleep(1 * time.Second)
-
     // Check the number of log files after rotation
     files, err := os.ReadDir(filepath.Dir(logFile))
     if err != nil {
@@ -266,6 +300,20 @@ func TestDefaultConfig(t *testing.T) {
     }
 }
 
+func TestBuildTimeDefaults(t *testing.T) {
+    resetLogger()
+    defer resetLogger()
+    // Check that the build-time defaults apply to the global logger used without InitLogger.
+    level, format := logger.DefaultLevel, logger.DefaultFormat
+    logger.DefaultLevel, logger.DefaultFormat = "error", "json"
+    defer func() { logger.DefaultLevel, logger.DefaultFormat = level, format }()
+
+    config := logger.GetLoggerConfig()
+    if config.ConsoleLevel != "error" || config.Format != "json" {
+        t.Errorf("Expected the build-time defaults, got level '%v' and format '%s'", config.ConsoleLevel, config.Format)
+    }
+}
+
 
 func TestLogMethods(t *testing.T) {
     resetLogger()
@@ -280,11 +328,12 @@ func TestLogMethods(t *testing.T) {
     os.Stdout = w
 
     config := logger.LogConfig{
-        FilePath:      "", // File path not specified, logging will be to console only
-        Format:        "standard",
-        FileLevel:     "trace",
-        ConsoleLevel:  "trace",
-        ConsoleOutput: true,
+        FilePath:        "", // File path not specified, logging will be to console only
+        Format:          "standard",
+        FileLevel:       "trace",
+        ConsoleLevel:    "trace",
+        ConsoleOutput:   true,
+        StackTraceLevel: logger.StackTraceNone,
     }
 
     log, err := logger.NewLogger(config)
@@ -725,3 +774,34 @@ func TestLoggerInitialization(t *testing.T) {
         t.Errorf("Expected 'Info message after initialization' in console output, got '%s'", output)
     }
 }
+
+func TestOutputWriters(t *testing.T) {
+    noColor := logger.NoColor
+    logger.NoColor = false
+    defer func() { logger.NoColor = noColor }()
+    defer logger.ResetLogger()
+
+    var output, errorOutput bytes.Buffer
+    err := logger.InitLogger(logger.LogConfig{
+        ConsoleLevel: "info",
+        Output:       &output,
+        ErrorOutput:  &errorOutput,
+        Sinks:        []logger.SinkConfig{{Name: "broken", Writer: failingWriter{}, Level: "error", Default: true}},
+    })
+    if err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+
+    logger.Info("Message to the buffer")
+    logger.Error("Message the broken sink fails")
+
+    if !strings.Contains(output.String(), "[INFO] Message to the buffer") {
+        t.Errorf("Expected the console output in the writer, got %q", output.String())
+    }
+    if strings.Contains(output.String(), "\x1b[") {
+        t.Errorf("Expected uncolored lines in a writer that is not a terminal, got %q", output.String())
+    }
+    if !strings.Contains(errorOutput.String(), "Logger outputs error") || !strings.Contains(errorOutput.String(), "disk full") {
+        t.Errorf("Expected the sink error in the error output, got %q", errorOutput.String())
+    }
+}
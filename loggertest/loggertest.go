@@ -0,0 +1,158 @@
+// Package loggertest provides a logger recording its entries in memory, for asserting on logging in
+// unit tests without redirecting os.Stdout:
+//
+//	log, rec := loggertest.NewTestLogger()
+//	service := NewService(log)
+//	service.Run()
+//	if !rec.ContainsMessage("service started") {
+//	    t.Errorf("expected the start to be logged, got %v", rec.Entries())
+//	}
+package loggertest
+
+import (
+    "reflect"
+    "strings"
+    "sync"
+
+    "github.com/nir0k/logger"
+)
+
+// SinkName is the name of the Recorder sink in routing rules.
+const SinkName = "recorder"
+
+// Recorder is a sink keeping the entries it receives in memory. It records entries at every level
+// and is safe for concurrent use.
+type Recorder struct {
+    mu      sync.Mutex
+    entries []logger.Entry
+}
+
+// NewRecorder creates an empty recorder, to add to a logger configuration as a custom sink:
+//
+//	rec := loggertest.NewRecorder()
+//	config.Sinks = append(config.Sinks, logger.SinkConfig{Sink: rec, Default: true})
+//
+// Returns:
+//   - (*Recorder): Empty recorder.
+func NewRecorder() *Recorder {
+    return &Recorder{}
+}
+
+// NewTestLogger creates a logger writing every entry, TRACE included, to a recorder only, without
+// stack traces so that entries hold only the fields set by the code under test.
+//
+// Returns:
+//   - (*logger.Logger): Logger writing to the recorder.
+//   - (*Recorder): Recorder of the entries of the logger.
+func NewTestLogger() (*logger.Logger, *Recorder) {
+    rec := NewRecorder()
+    log, err := logger.NewLogger(logger.LogConfig{
+        FileLevel:       "trace",
+        ConsoleLevel:    "trace",
+        StackTraceLevel: logger.StackTraceNone,
+        Sinks:           []logger.SinkConfig{{Sink: rec, Default: true}},
+    })
+    if err != nil {
+        // The configuration is fixed and valid
+        panic("loggertest: " + err.Error())
+    }
+    return log, rec
+}
+
+// Name returns the name of the recorder sink.
+func (r *Recorder) Name() string {
+    return SinkName
+}
+
+// Enabled reports that the recorder records entries at every level.
+func (r *Recorder) Enabled(level string, value int) bool {
+    return true
+}
+
+// WriteEntry records the entry.
+func (r *Recorder) WriteEntry(e logger.Entry) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.entries = append(r.entries, e)
+    return nil
+}
+
+// Entries returns the recorded entries, oldest first.
+//
+// Returns:
+//   - ([]logger.Entry): Copy of the recorded entries.
+func (r *Recorder) Entries() []logger.Entry {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    return append([]logger.Entry(nil), r.entries...)
+}
+
+// Len returns the number of recorded entries.
+//
+// Returns:
+//   - (int): Number of entries.
+func (r *Recorder) Len() int {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    return len(r.entries)
+}
+
+// FilterLevel returns the recorded entries at the level.
+//
+// Arguments:
+//   - level (string): Level name, case-insensitive.
+//
+// Returns:
+//   - ([]logger.Entry): Entries at the level, oldest first.
+func (r *Recorder) FilterLevel(level string) []logger.Entry {
+    level = strings.ToLower(level)
+    var result []logger.Entry
+    for _, e := range r.Entries() {
+        if e.Level == level {
+            result = append(result, e)
+        }
+    }
+    return result
+}
+
+// FilterField returns the recorded entries with the field set to the value, compared with
+// reflect.DeepEqual so that slices and maps can be matched.
+//
+// Arguments:
+//   - key (string): Field name.
+//   - value (interface{}): Field value.
+//
+// Returns:
+//   - ([]logger.Entry): Entries with the field, oldest first.
+func (r *Recorder) FilterField(key string, value interface{}) []logger.Entry {
+    var result []logger.Entry
+    for _, e := range r.Entries() {
+        if v, ok := e.Fields[key]; ok && reflect.DeepEqual(v, value) {
+            result = append(result, e)
+        }
+    }
+    return result
+}
+
+// ContainsMessage reports whether a recorded entry has a message containing the text.
+//
+// Arguments:
+//   - text (string): Text searched in the messages.
+//
+// Returns:
+//   - (bool): Whether the text was logged.
+func (r *Recorder) ContainsMessage(text string) bool {
+    for _, e := range r.Entries() {
+        if strings.Contains(e.Message, text) {
+            return true
+        }
+    }
+    return false
+}
+
+// Reset removes the recorded entries.
+func (r *Recorder) Reset() {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.entries = nil
+}
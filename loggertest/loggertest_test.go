@@ -0,0 +1,52 @@
+package loggertest_test
+
+import (
+    "sync"
+    "testing"
+
+    "github.com/nir0k/logger"
+    "github.com/nir0k/logger/loggertest"
+)
+
+func TestRecorder(t *testing.T) {
+    log, rec := loggertest.NewTestLogger()
+
+    log.Trace("Cache lookup")
+    log.WithField("user", "bob").Info("User logged in")
+    log.Errorw("Payment failed", "order", 42)
+
+    if rec.Len() != 3 {
+        t.Fatalf("Expected 3 entries, got %v", rec.Entries())
+    }
+    if !rec.ContainsMessage("logged in") || rec.ContainsMessage("logged out") {
+        t.Errorf("Unexpected ContainsMessage results for %v", rec.Entries())
+    }
+    errs := rec.FilterLevel("ERROR")
+    if len(errs) != 1 || errs[0].Message != "Payment failed" || errs[0].Fields["order"] != 42 {
+        t.Errorf("Expected the ERROR entry with its fields, got %v", errs)
+    }
+    if _, ok := errs[0].Fields[logger.StackTraceField]; ok {
+        t.Errorf("Expected no stack trace in recorded entries")
+    }
+    if users := rec.FilterField("user", "bob"); len(users) != 1 || users[0].Level != "info" {
+        t.Errorf("Expected the entry of bob, got %v", users)
+    }
+    log.WithField("roles", []string{"admin"}).Info("Roles loaded")
+    if admins := rec.FilterField("roles", []string{"admin"}); len(admins) != 1 {
+        t.Errorf("Expected slice fields to be compared by value, got %v", admins)
+    }
+
+    rec.Reset()
+    var wg sync.WaitGroup
+    for i := 0; i < 10; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            log.Debug("Concurrent entry")
+        }()
+    }
+    wg.Wait()
+    if rec.Len() != 10 {
+        t.Errorf("Expected 10 concurrent entries, got %d", rec.Len())
+    }
+}
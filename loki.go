@@ -0,0 +1,411 @@
+package logger
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "fmt"
+    "hash/fnv"
+    "io"
+    "net/http"
+    "os"
+    "path/filepath"
+    "regexp"
+    "strconv"
+    "sync"
+    "time"
+)
+
+// LokiConfig configures the Loki output, which batches entries and pushes them to the HTTP push API
+// of Grafana Loki, without an agent reading the log file back. Entries are pushed when BatchSize
+// entries are pending or FlushInterval has elapsed. Failed pushes are retried with exponential
+// backoff while new entries keep being collected, up to ten batches, beyond which the oldest entries
+// are dropped. Barrier waits until the pending entries are pushed; closing the logger cancels the push
+// in progress and tries the pending entries once more for at most two seconds.
+type LokiConfig struct {
+    URL           string            // Push endpoint, e.g. "http://loki:3100/loki/api/v1/push".
+    Labels        map[string]string // Labels of the stream, e.g. {"app": "billing", "env": "production"} (default: {"job": the program name}).
+    LevelLabel    bool              // Whether entries are split into streams by a "level" label.
+    Headers       map[string]string // HTTP headers of the pushes, e.g. {"X-Scope-OrgID": "tenant-1"}.
+    Format        string            // Format of the lines: "standard" or "json" (default: Format of the logger).
+    BatchSize     int               // Number of entries pushed at once (default: 1000).
+    FlushInterval time.Duration     // Maximum time entries wait before being pushed (default: 1s).
+    MinBackoff    time.Duration     // Delay before the first retry of a failed push, doubled for each retry (default: 500ms).
+    MaxBackoff    time.Duration     // Maximum delay between retries (default: 30s).
+    MaxRetries    int               // Retries of a failed push before its entries are dropped (default: 10).
+    Level         interface{}       // Log level of the Loki output: can be a Level, a string or a number (default: "info").
+    Client        *http.Client      `json:"-"` // HTTP client (default: a client with a timeout of 10s).
+    // Dedupe replaces a batch repeating the content of the last batch pushed in full within this window
+    // by a reference record carrying its content hash in the "dedup_ref" field; 0 disables it. Batches
+    // pushed in full end with a record carrying their content hash in the "content_hash" field.
+    Dedupe time.Duration
+}
+
+// lokiSinkName is the name of the Loki output in routing rules.
+const lokiSinkName = "loki"
+
+// Defaults of the Loki output.
+const (
+    lokiBatchSize     = 1000
+    lokiFlushInterval = time.Second
+    lokiMinBackoff    = 500 * time.Millisecond
+    lokiMaxBackoff    = 30 * time.Second
+    lokiMaxRetries    = 10
+    lokiPendingLimit  = 10 // Batches kept while pushes fail.
+    lokiTimeout       = 10 * time.Second
+    lokiCloseTimeout  = 2 * time.Second // Bound of the push of the remaining entries when stopping.
+)
+
+// lokiLabelName matches valid Loki label names.
+var lokiLabelName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// lokiLine is a rendered entry waiting to be pushed.
+type lokiLine struct {
+    level string
+    time  time.Time
+    line  string
+    hash  string // Content hash of the entry with Dedupe, empty for the records of deduplication.
+}
+
+// lokiPush is the body of a push request.
+type lokiPush struct {
+    Streams []lokiStream `json:"streams"`
+}
+
+// lokiStream is a stream of a push request with its lines as [timestamp, line] pairs.
+type lokiStream struct {
+    Stream map[string]string `json:"stream"`
+    Values [][2]string       `json:"values"`
+}
+
+// lokiSink is the Loki output. Entries are collected by WriteEntry and pushed by a background goroutine.
+type lokiSink struct {
+    config LokiConfig
+    level  int
+    format string
+    dedupe *dedupeFilter // Replacement of repeated batches by reference records, nil if disabled.
+
+    mu      sync.Mutex
+    pending []lokiLine
+    dropped int // Entries dropped since the last report.
+
+    wake    chan struct{}   // Signals a full batch.
+    syncs   chan chan error // Requests to push all pending entries, see Sync.
+    closing chan struct{}
+    once    sync.Once
+    done    chan struct{} // Closed when the background goroutine has returned.
+    health  sinkHealth
+}
+
+// newLokiSink validates the configuration and creates the Loki output of the logger. Its background
+// goroutine runs until the logger is stopped or the output is closed.
+func (l *Logger) newLokiSink(config LokiConfig) (*lokiSink, error) {
+    if config.URL == "" {
+        return nil, fmt.Errorf("Loki URL is required")
+    }
+    for name := range config.Labels {
+        if !lokiLabelName.MatchString(name) {
+            return nil, fmt.Errorf("invalid Loki label name: %q", name)
+        }
+    }
+    if _, ok := config.Labels["level"]; ok && config.LevelLabel {
+        return nil, fmt.Errorf("Loki label \"level\" is set by LevelLabel")
+    }
+    if len(config.Labels) == 0 {
+        config.Labels = map[string]string{"job": filepath.Base(os.Args[0])}
+    }
+    if config.BatchSize <= 0 {
+        config.BatchSize = lokiBatchSize
+    }
+    if config.FlushInterval <= 0 {
+        config.FlushInterval = lokiFlushInterval
+    }
+    if config.MinBackoff <= 0 {
+        config.MinBackoff = lokiMinBackoff
+    }
+    if config.MaxBackoff <= 0 {
+        config.MaxBackoff = lokiMaxBackoff
+    }
+    if config.MaxRetries <= 0 {
+        config.MaxRetries = lokiMaxRetries
+    }
+    if config.Client == nil {
+        config.Client = &http.Client{Timeout: lokiTimeout}
+    }
+    format := config.Format
+    if format == "" {
+        format = l.Config.Format
+    }
+
+    levelValue := config.Level
+    if levelValue == nil {
+        levelValue = "info"
+    }
+    level, err := l.parseLevel(levelValue)
+    if err != nil {
+        return nil, fmt.Errorf("invalid Loki log level: %v", err)
+    }
+
+    s := &lokiSink{
+        config:  config,
+        level:   level,
+        format:  format,
+        dedupe:  newDedupeFilter(config.Dedupe),
+        wake:    make(chan struct{}, 1),
+        syncs:   make(chan chan error),
+        closing: make(chan struct{}),
+        done:    make(chan struct{}),
+    }
+    go s.run(l.stop.ch)
+    return s, nil
+}
+
+// Name returns the name of the Loki output.
+func (s *lokiSink) Name() string {
+    return lokiSinkName
+}
+
+// Enabled reports whether the level of the Loki output allows the level.
+func (s *lokiSink) Enabled(level string, value int) bool {
+    return value <= s.level
+}
+
+// WriteEntry renders the entry and adds it to the pending entries, dropping the oldest ones beyond
+// the limit while pushes fail.
+func (s *lokiSink) WriteEntry(e Entry) error {
+    line := lokiLine{level: e.Level, time: e.Time, line: string(e.appendFormat(nil, s.format, nil))}
+    if s.dedupe != nil {
+        line.hash = contentHash(e)
+    }
+    s.mu.Lock()
+    s.pending = append(s.pending, line)
+    if excess := len(s.pending) - s.config.BatchSize*lokiPendingLimit; excess > 0 {
+        s.pending = append(s.pending[:0], s.pending[excess:]...)
+        s.dropped += excess
+    }
+    full := len(s.pending) >= s.config.BatchSize
+    s.mu.Unlock()
+    if full {
+        select {
+        case s.wake <- struct{}{}:
+        default:
+        }
+    }
+    return nil
+}
+
+// Sync pushes the pending entries and waits until Loki has accepted them, or the context is done.
+//
+// Arguments:
+//   - ctx (context.Context): Context bounding the wait.
+//
+// Returns:
+//   - error: Error of the last failed push, or the error of the context.
+func (s *lokiSink) Sync(ctx context.Context) error {
+    result := make(chan error, 1)
+    select {
+    case s.syncs <- result:
+    case <-s.done:
+        return nil
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+    select {
+    case err := <-result:
+        return err
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+}
+
+// state returns the state of the Loki output.
+func (s *lokiSink) state() SinkState {
+    state := SinkState{Name: lokiSinkName, Level: levelName(s.level), Connected: true}
+    s.health.fill(&state)
+    return state
+}
+
+// Close pushes the pending entries and stops the background goroutine, waiting at most a few seconds
+// for Loki to accept them.
+func (s *lokiSink) Close() error {
+    s.once.Do(func() { close(s.closing) })
+    <-s.done
+    return nil
+}
+
+// run pushes batches of pending entries until the logger is stopped or the output closed, then
+// pushes the remaining entries. Pushes in progress are canceled when stopping.
+func (s *lokiSink) run(stop <-chan struct{}) {
+    defer close(s.done)
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    go func() {
+        select {
+        case <-stop:
+        case <-s.closing:
+        case <-ctx.Done():
+        }
+        cancel()
+    }()
+
+    ticker := time.NewTicker(s.config.FlushInterval)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-ticker.C:
+            s.flush(ctx, true, false)
+        case <-s.wake:
+            s.flush(ctx, true, true)
+        case result := <-s.syncs:
+            result <- s.flush(ctx, true, false)
+        case <-ctx.Done():
+            final, cancelFinal := context.WithTimeout(context.Background(), lokiCloseTimeout)
+            s.flush(final, false, false)
+            cancelFinal()
+            return
+        }
+    }
+}
+
+// flush pushes the pending entries in batches, only full batches if fullOnly is set. With retry,
+// failed pushes are retried with backoff until the context is done; otherwise each batch is tried
+// once. It returns the error of the last failed push.
+func (s *lokiSink) flush(ctx context.Context, retry, fullOnly bool) error {
+    var lastErr error
+    for {
+        s.mu.Lock()
+        n := min(len(s.pending), s.config.BatchSize)
+        if n == 0 || (fullOnly && n < s.config.BatchSize) {
+            s.mu.Unlock()
+            break
+        }
+        batch := append([]lokiLine(nil), s.pending[:n]...)
+        s.pending = append(s.pending[:0], s.pending[n:]...)
+        dropped := s.dropped
+        s.dropped = 0
+        s.mu.Unlock()
+
+        if dropped > 0 {
+            diagnose(1, WarningLevel, "loki", fmt.Sprintf("%d entries dropped while pushes failed", dropped))
+        }
+        if s.dedupe != nil {
+            batch = s.dedupeBatch(batch)
+        }
+        if err := s.pushWithRetry(ctx, batch, retry); err != nil {
+            if retry && ctx.Err() != nil {
+                // Stopping: the batch is pushed again with the remaining entries
+                s.mu.Lock()
+                s.pending = append(batch, s.pending...)
+                s.mu.Unlock()
+                return err
+            }
+            lastErr = err
+            s.health.record(err)
+            reportError("loki", fmt.Errorf("dropped %d entries: %v", len(batch), err))
+        }
+    }
+    return lastErr
+}
+
+// dedupeBatch returns the batch followed by a record with its content hash, or a reference record
+// replacing it if it repeats the last batch pushed in full within the Dedupe window. Batches already
+// deduplicated, pushed again after a canceled push, are returned as is.
+func (s *lokiSink) dedupeBatch(batch []lokiLine) []lokiLine {
+    h := fnv.New64a()
+    for _, line := range batch {
+        if line.hash == "" {
+            return batch
+        }
+        io.WriteString(h, line.hash)
+        h.Write([]byte{0})
+    }
+    hash := strconv.FormatUint(h.Sum64(), 16)
+    last := batch[len(batch)-1]
+    if s.dedupe.repeats(hash, last.time) {
+        return []lokiLine{s.record(last, DedupRefField, hash)}
+    }
+    return append(batch, s.record(last, ContentHashField, hash))
+}
+
+// record renders a deduplication record with the level and time of the line and the field.
+func (s *lokiSink) record(line lokiLine, key, hash string) lokiLine {
+    e := Entry{Time: line.time, Level: line.level, PID: os.Getpid(), Fields: Fields{key: hash}}
+    return lokiLine{level: line.level, time: line.time, line: string(e.appendFormat(nil, s.format, nil))}
+}
+
+// pushWithRetry pushes the batch, retrying retryable failures with exponential backoff if retry is
+// set, until MaxRetries is reached or the context is done.
+func (s *lokiSink) pushWithRetry(ctx context.Context, batch []lokiLine, retry bool) error {
+    backoff := s.config.MinBackoff
+    for attempt := 0; ; attempt++ {
+        retryable, err := s.push(ctx, batch)
+        if err == nil || !retryable || !retry || attempt >= s.config.MaxRetries {
+            return err
+        }
+        timer := time.NewTimer(backoff)
+        select {
+        case <-timer.C:
+        case <-ctx.Done():
+            timer.Stop()
+            return err
+        }
+        backoff = min(backoff*2, s.config.MaxBackoff)
+    }
+}
+
+// push sends the batch to Loki, reporting whether a failure is worth retrying: network errors, rate
+// limiting and server errors are, other rejections are not.
+func (s *lokiSink) push(ctx context.Context, batch []lokiLine) (bool, error) {
+    body, err := json.Marshal(s.request(batch))
+    if err != nil {
+        return false, fmt.Errorf("failed to encode Loki push: %v", err)
+    }
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.URL, bytes.NewReader(body))
+    if err != nil {
+        return false, fmt.Errorf("invalid Loki push request: %v", err)
+    }
+    req.Header.Set("Content-Type", "application/json")
+    for key, value := range s.config.Headers {
+        req.Header.Set(key, value)
+    }
+    resp, err := s.config.Client.Do(req)
+    if err != nil {
+        return true, fmt.Errorf("failed to push to Loki: %v", err)
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode/100 == 2 {
+        io.Copy(io.Discard, resp.Body)
+        return false, nil
+    }
+    message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+    err = fmt.Errorf("Loki push rejected: %s: %s", resp.Status, bytes.TrimSpace(message))
+    return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+}
+
+// request groups the batch into streams, by level if LevelLabel is set.
+func (s *lokiSink) request(batch []lokiLine) lokiPush {
+    var push lokiPush
+    streams := map[string]int{}
+    for _, line := range batch {
+        key := ""
+        if s.config.LevelLabel {
+            key = line.level
+        }
+        i, ok := streams[key]
+        if !ok {
+            labels := make(map[string]string, len(s.config.Labels)+1)
+            for name, value := range s.config.Labels {
+                labels[name] = value
+            }
+            if s.config.LevelLabel {
+                labels["level"] = line.level
+            }
+            i = len(push.Streams)
+            streams[key] = i
+            push.Streams = append(push.Streams, lokiStream{Stream: labels})
+        }
+        value := [2]string{strconv.FormatInt(line.time.UnixNano(), 10), line.line}
+        push.Streams[i].Values = append(push.Streams[i].Values, value)
+    }
+    return push
+}
@@ -0,0 +1,136 @@
+package logger_test
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+// lokiServer is a fake Loki push endpoint failing its first push with a server error.
+type lokiServer struct {
+    mu      sync.Mutex
+    calls   int
+    streams map[string][]string // Lines by level label.
+    tenant  string
+}
+
+func (s *lokiServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.calls++
+    if s.calls == 1 {
+        http.Error(w, "ingester unavailable", http.StatusServiceUnavailable)
+        return
+    }
+    var push struct {
+        Streams []struct {
+            Stream map[string]string `json:"stream"`
+            Values [][2]string       `json:"values"`
+        } `json:"streams"`
+    }
+    if err := json.NewDecoder(r.Body).Decode(&push); err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+    s.tenant = r.Header.Get("X-Scope-OrgID")
+    for _, stream := range push.Streams {
+        if stream.Stream["app"] != "billing" {
+            http.Error(w, "missing app label", http.StatusBadRequest)
+            return
+        }
+        for _, value := range stream.Values {
+            s.streams[stream.Stream["level"]] = append(s.streams[stream.Stream["level"]], value[1])
+        }
+    }
+    w.WriteHeader(http.StatusNoContent)
+}
+
+func TestLokiPush(t *testing.T) {
+    fake := &lokiServer{streams: map[string][]string{}}
+    server := httptest.NewServer(fake)
+    defer server.Close()
+
+    log, err := logger.NewLogger(logger.LogConfig{
+        ConsoleLevel: "fatal",
+        Loki: &logger.LokiConfig{
+            URL:           server.URL + "/loki/api/v1/push",
+            Labels:        map[string]string{"app": "billing"},
+            LevelLabel:    true,
+            Headers:       map[string]string{"X-Scope-OrgID": "tenant-1"},
+            Format:        "json",
+            FlushInterval: time.Hour,
+            MinBackoff:    time.Millisecond,
+        },
+    })
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    defer log.Close()
+    log.Debug("Not pushed")
+    log.Info("Invoice created")
+    log.Error("Payment failed")
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+    if err := log.Barrier(ctx); err != nil {
+        t.Fatalf("Expected the barrier to wait for the push, got %v", err)
+    }
+
+    fake.mu.Lock()
+    defer fake.mu.Unlock()
+    if fake.calls != 2 {
+        t.Errorf("Expected the failed push to be retried once, got %d calls", fake.calls)
+    }
+    if len(fake.streams["info"]) != 1 || len(fake.streams["error"]) != 1 {
+        t.Fatalf("Expected one line in the info and error streams, got %v", fake.streams)
+    }
+    var entry map[string]interface{}
+    if err := json.Unmarshal([]byte(fake.streams["error"][0]), &entry); err != nil || entry["message"] != "Payment failed" {
+        t.Errorf("Expected the JSON line of the error, got '%s'", fake.streams["error"][0])
+    }
+    if fake.tenant != "tenant-1" {
+        t.Errorf("Expected the configured headers, got tenant '%s'", fake.tenant)
+    }
+}
+
+func TestLokiInvalidLabel(t *testing.T) {
+    _, err := logger.NewLogger(logger.LogConfig{Loki: &logger.LokiConfig{URL: "http://localhost:3100", Labels: map[string]string{"app-name": "x"}}})
+    if err == nil {
+        t.Errorf("Expected an invalid label name to be rejected")
+    }
+}
+
+func TestLokiCloseUnresponsive(t *testing.T) {
+    discardStdout(t)
+    release := make(chan struct{})
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        select {
+        case <-r.Context().Done():
+        case <-release:
+        }
+    }))
+    defer server.Close()
+    defer close(release)
+
+    log, err := logger.NewLogger(logger.LogConfig{
+        ConsoleLevel: "fatal",
+        Loki:         &logger.LokiConfig{URL: server.URL, BatchSize: 1, FlushInterval: time.Hour},
+    })
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    log.Info("Never accepted")
+    time.Sleep(50 * time.Millisecond) // Let the push start
+
+    start := time.Now()
+    log.Close()
+    if elapsed := time.Since(start); elapsed > 4*time.Second {
+        t.Errorf("Expected Close to give up on the push, took %v", elapsed)
+    }
+}
@@ -0,0 +1,291 @@
+package logger
+
+import (
+    "fmt"
+    "math"
+    "sort"
+    "strings"
+    "sync"
+    "sync/atomic"
+)
+
+// inheritLevel is the level of a named logger without its own level, which uses the output levels.
+const inheritLevel = math.MinInt64
+
+// Registry of named loggers: the levels set for names and subtrees, kept across InitLogger, the
+// effective levels of the names in use and the loggers derived from the current global logger.
+var (
+    namedMu        sync.Mutex
+    namedSettings  = map[string]int64{}
+    namedEffective = map[string]*atomic.Int64{}
+    namedLoggers   = map[string]*Logger{}
+    namedBase      *Logger // Global logger the named loggers derive from.
+)
+
+// Names whose level was set by LoggerLevels of the configuration, cleared when a later configuration
+// no longer sets them. Guarded by namedMu.
+var namedConfigured = map[string]bool{}
+
+// namedLevel returns the effective level of the named logger, tracking it from now on.
+// The caller holds namedMu.
+func namedLevel(name string) *atomic.Int64 {
+    level, ok := namedEffective[name]
+    if !ok {
+        level = newLevel(0)
+        level.Store(resolveLevel(name))
+        namedEffective[name] = level
+    }
+    return level
+}
+
+// resolveLevel returns the effective level of a dotted name: its own level, else the level of the
+// nearest ancestor, where a subtree setting "a.b.*" takes precedence over the level of "a.b".
+// The caller holds namedMu.
+func resolveLevel(name string) int64 {
+    if value, ok := namedSettings[name]; ok {
+        return value
+    }
+    for i := strings.LastIndexByte(name, '.'); i >= 0; i = strings.LastIndexByte(name, '.') {
+        name = name[:i]
+        if value, ok := namedSettings[name+".*"]; ok {
+            return value
+        }
+        if value, ok := namedSettings[name]; ok {
+            return value
+        }
+    }
+    return inheritLevel
+}
+
+// setNamedLevel sets or clears the level of a name or subtree and updates the effective levels.
+// The caller holds namedMu.
+func setNamedLevel(name string, value int64) {
+    if value == inheritLevel {
+        delete(namedSettings, name)
+    } else {
+        namedSettings[name] = value
+    }
+    for n, level := range namedEffective {
+        level.Store(resolveLevel(n))
+    }
+}
+
+// GetLogger returns the named logger of a subsystem such as "db", "http" or "auth", derived from the
+// global logger with the "component" field set to the name. Named loggers have their own level, see
+// SetLoggerLevel; the same logger is returned for a name until the global logger is re-initialized.
+// A named logger kept in a package variable, such as var dblog = logger.GetLogger("db"), logs through
+// the current global logger after InitLogger, like the named logger GetLogger returns then.
+// Names form a hierarchy separated by dots, such as "server.http.handlers", where loggers without
+// a level inherit the level of their nearest ancestor.
+//
+// Arguments:
+//   - name (string): Name of the subsystem.
+//
+// Returns:
+//   - (*Logger): Named logger, nil if the global logger cannot be initialized.
+func GetLogger(name string) *Logger {
+    base := currentLogger()
+    if base == nil {
+        return nil
+    }
+
+    namedMu.Lock()
+    defer namedMu.Unlock()
+    if namedBase != base {
+        namedBase, namedLoggers = base, map[string]*Logger{}
+    }
+    if l, ok := namedLoggers[name]; ok {
+        return l
+    }
+    l := base.newNamed(name)
+    l.base = base
+    namedLoggers[name] = l
+    return l
+}
+
+// rebase returns the logger a logger of GetLogger logs through after the global logger was
+// re-initialized: the named logger of the current global logger, with the fields the logger added
+// or overrode with WithFields. It returns nil while the global logger is unchanged.
+func (l *Logger) rebase() *Logger {
+    current := currentLogger()
+    if current == nil || current == l.base {
+        return nil
+    }
+    fresh := GetLogger(l.name)
+    if fresh == nil || len(l.added) == 0 {
+        return fresh
+    }
+    c := *fresh
+    c.fields = make(Fields, len(fresh.fields)+len(l.added))
+    for key, value := range fresh.fields {
+        c.fields[key] = value
+    }
+    for key, value := range l.added {
+        c.fields[key] = value
+    }
+    c.added = l.added
+    return &c
+}
+
+// newNamed derives the named logger from the logger. The caller holds namedMu.
+func (l *Logger) newNamed(name string) *Logger {
+    c := l.WithField(ComponentField, name)
+    c.named = namedLevel(name)
+    c.name = name
+    return c
+}
+
+// Named returns the child logger of a named logger, named after the name of the logger and the
+// child separated by a dot: GetLogger("app.db").Named("pool") is GetLogger("app.db.pool"). Called on
+// a logger without a name, it returns the named logger derived from it. The child inherits the
+// level of the logger unless its own level is set, see SetLoggerLevel.
+//
+// Arguments:
+//   - name (string): Name of the child.
+//
+// Returns:
+//   - (*Logger): Child logger.
+func (l *Logger) Named(name string) *Logger {
+    if l.name != "" {
+        name = l.name + "." + name
+    }
+    namedMu.Lock()
+    defer namedMu.Unlock()
+    if named, ok := namedLoggers[l.name]; l.name != "" && ok && named == l {
+        // Children of the registered loggers are registered too
+        if child, ok := namedLoggers[name]; ok {
+            return child
+        }
+        child := namedBase.newNamed(name)
+        child.base = namedBase
+        namedLoggers[name] = child
+        return child
+    }
+    return l.newNamed(name)
+}
+
+// Name returns the name of a named logger.
+//
+// Returns:
+//   - (string): Name of the logger, empty for loggers not returned by GetLogger or Named.
+func (l *Logger) Name() string {
+    return l.name
+}
+
+// EffectiveLoggerLevel returns the level applied to a named logger: its own level, else the level
+// inherited from the nearest ancestor or subtree, see SetLoggerLevel.
+//
+// Arguments:
+//   - name (string): Name of the logger.
+//
+// Returns:
+//   - (string): Name of the effective level, empty if the logger uses the output levels.
+//   - (bool): Whether a level applies to the logger.
+func EffectiveLoggerLevel(name string) (string, bool) {
+    namedMu.Lock()
+    defer namedMu.Unlock()
+    value := resolveLevel(name)
+    if value == inheritLevel {
+        return "", false
+    }
+    return levelName(int(value)), true
+}
+
+// SetLoggerLevel sets the level of a named logger, independently of the levels of the outputs: its
+// entries at the level or more severe are written to all its outputs, and the others are dropped.
+// The level is inherited by the descendants of the logger without their own level, and a name
+// ending with ".*" such as "server.*" sets the level of the subtree below "server" only.
+// A nil level makes the logger inherit again, or use the output levels without an ancestor level.
+// The level applies to the loggers returned by GetLogger before and after the call.
+//
+// Arguments:
+//   - name (string): Name of the logger, or of a subtree as "name.*".
+//   - level (interface{}): New log level: can be a Level, a string or a number, nil to use the output levels.
+//
+// Returns:
+//   - error: Error if the level is invalid.
+func SetLoggerLevel(name string, level interface{}) error {
+    value := int64(inheritLevel)
+    if level != nil {
+        ensureLoggerInitialized()
+        if logInstance == nil {
+            return fmt.Errorf("logger is not initialized")
+        }
+        parsed, err := logInstance.parseLevel(level)
+        if err != nil {
+            return err
+        }
+        value = int64(parsed)
+    }
+    namedMu.Lock()
+    defer namedMu.Unlock()
+    setNamedLevel(name, value)
+    delete(namedConfigured, name)
+    return nil
+}
+
+// LoggerLevel is the level set for a named logger or subtree, see LoggerLevels.
+type LoggerLevel struct {
+    Name  string // Name of the logger, or of a subtree as "name.*".
+    Level string // Name of the level.
+}
+
+// LoggerLevels returns the levels set for named loggers and subtrees, sorted by name so that the
+// levels of subtrees follow the level of their root.
+//
+// Returns:
+//   - ([]LoggerLevel): Levels by logger name or subtree.
+func LoggerLevels() []LoggerLevel {
+    namedMu.Lock()
+    defer namedMu.Unlock()
+    levels := make([]LoggerLevel, 0, len(namedSettings))
+    for name, value := range namedSettings {
+        levels = append(levels, LoggerLevel{Name: name, Level: levelName(int(value))})
+    }
+    sort.Slice(levels, func(i, j int) bool { return levels[i].Name < levels[j].Name })
+    return levels
+}
+
+// parseLoggerLevels validates the configured levels of named loggers.
+func (l *Logger) parseLoggerLevels(levels map[string]interface{}) (map[string]int, error) {
+    parsed := make(map[string]int, len(levels))
+    for name, level := range levels {
+        value, err := l.parseLevel(level)
+        if err != nil {
+            return nil, fmt.Errorf("logger %s: %v", name, err)
+        }
+        parsed[name] = value
+    }
+    return parsed, nil
+}
+
+// applyLoggerLevels sets the configured levels of named loggers in the registry, clearing the levels
+// of an earlier configuration that the new one no longer sets, unless they were set since with
+// SetLoggerLevel.
+func (l *Logger) applyLoggerLevels() {
+    levels, _ := l.parseLoggerLevels(l.Config.LoggerLevels)
+    namedMu.Lock()
+    defer namedMu.Unlock()
+    for name := range namedConfigured {
+        if _, ok := levels[name]; !ok {
+            setNamedLevel(name, inheritLevel)
+        }
+    }
+    namedConfigured = make(map[string]bool, len(levels))
+    for name, value := range levels {
+        setNamedLevel(name, int64(value))
+        namedConfigured[name] = true
+    }
+}
+
+// namedPasses reports whether the own level of a named logger is set, and whether it allows the level.
+func (l *Logger) namedPasses(msgLevel int) (set, passes bool) {
+    if l.named == nil {
+        return false, false
+    }
+    value := l.named.Load()
+    if value == inheritLevel {
+        return false, false
+    }
+    return true, int64(msgLevel) <= value
+}
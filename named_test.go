@@ -0,0 +1,215 @@
+package logger_test
+
+import (
+    "os"
+    "path/filepath"
+    "reflect"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestNamedLoggers(t *testing.T) {
+    defer logger.ResetLogger()
+    path := filepath.Join(t.TempDir(), "app.log")
+    err := logger.InitLogger(logger.LogConfig{
+        FilePath:     path,
+        FileLevel:    "warning",
+        LoggerLevels: map[string]interface{}{"db": "trace"},
+    })
+    if err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+    defer logger.SetLoggerLevel("db", nil)
+    defer logger.SetLoggerLevel("http", nil)
+
+    db, http := logger.GetLogger("db"), logger.GetLogger("http")
+    if logger.GetLogger("db") != db {
+        t.Errorf("Expected the same logger for the same name")
+    }
+    db.Trace("Query planned")
+    http.Info("Request served")
+    if err := logger.SetLoggerLevel("http", "error"); err != nil {
+        t.Fatalf("Failed to set logger level: %v", err)
+    }
+    http.Warning("Slow request")
+    http.Error("Upstream failed")
+
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatalf("Failed to read log file: %v", err)
+    }
+    output := string(data)
+    if !strings.Contains(output, "[TRACE] Query planned component=db") {
+        t.Errorf("Expected the trace entry of the db logger, got %q", output)
+    }
+    if !strings.Contains(output, "[ERROR] Upstream failed component=http") {
+        t.Errorf("Expected the error entry of the http logger, got %q", output)
+    }
+    if strings.Contains(output, "Request served") || strings.Contains(output, "Slow request") {
+        t.Errorf("Expected the entries below the levels to be dropped, got %q", output)
+    }
+    expected := []logger.LoggerLevel{{Name: "db", Level: "trace"}, {Name: "http", Level: "error"}}
+    if levels := logger.LoggerLevels(); !reflect.DeepEqual(levels, expected) {
+        t.Errorf("Unexpected logger levels: %v", levels)
+    }
+    if err := logger.SetLoggerLevel("db", "verbose"); err == nil {
+        t.Errorf("Expected an error for an invalid level")
+    }
+}
+
+func TestNamedLoggerAfterInit(t *testing.T) {
+    defer logger.ResetLogger()
+    logger.ResetLogger()
+    // Like a package variable initialized before main calls InitLogger
+    db := logger.GetLogger("db")
+    query := db.WithField("table", "users")
+    replica := db.WithField(logger.ComponentField, "db-replica")
+
+    path := filepath.Join(t.TempDir(), "app.log")
+    if err := logger.InitLogger(logger.LogConfig{FilePath: path, FileLevel: "info"}); err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+    db.Info("Connected")
+    query.Info("Query failed")
+    replica.Info("Replica lagging")
+
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatalf("Failed to read log file: %v", err)
+    }
+    output := string(data)
+    if !strings.Contains(output, "[INFO] Connected component=db") || !strings.Contains(output, "[INFO] Query failed component=db table=users") {
+        t.Errorf("Expected the entries of the earlier named logger in the configured file, got %q", output)
+    }
+    if !strings.Contains(output, "[INFO] Replica lagging component=db-replica") {
+        t.Errorf("Expected the overridden field to be kept, got %q", output)
+    }
+}
+
+func TestLoggerLevelsReload(t *testing.T) {
+    defer logger.ResetLogger()
+    defer func() {
+        for _, name := range []string{"cache", "db", "queue"} {
+            logger.SetLoggerLevel(name, nil)
+        }
+    }()
+    config := logger.LogConfig{LoggerLevels: map[string]interface{}{"db": "trace", "cache": "debug", "queue": "debug"}}
+    if err := logger.InitLogger(config); err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+    logger.SetLoggerLevel("queue", "error")
+
+    config.LoggerLevels = map[string]interface{}{"db": "warning"}
+    if err := logger.InitLogger(config); err != nil {
+        t.Fatalf("Failed to re-initialize logger: %v", err)
+    }
+    expected := []logger.LoggerLevel{{Name: "db", Level: "warning"}, {Name: "queue", Level: "error"}}
+    if levels := logger.LoggerLevels(); !reflect.DeepEqual(levels, expected) {
+        t.Errorf("Expected the levels removed from the configuration to be cleared, got %v", levels)
+    }
+}
+
+func TestNamedLoggerHierarchy(t *testing.T) {
+    defer logger.ResetLogger()
+    path := filepath.Join(t.TempDir(), "app.log")
+    err := logger.InitLogger(logger.LogConfig{
+        FilePath:     path,
+        FileLevel:    "error",
+        LoggerLevels: map[string]interface{}{"server": "warning", "server.*": "debug"},
+    })
+    if err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+    defer func() {
+        for _, name := range []string{"server", "server.*", "server.http.handlers"} {
+            logger.SetLoggerLevel(name, nil)
+        }
+    }()
+
+    server, handlers := logger.GetLogger("server"), logger.GetLogger("server.http.handlers")
+    server.Info("Server info")
+    server.Warning("Server warning")
+    handlers.Debug("Handler debug")
+    handlers.Trace("Handler trace")
+
+    // A level set on a descendant overrides the subtree
+    logger.SetLoggerLevel("server.http.handlers", "trace")
+    handlers.Trace("Handler trace after")
+    // Clearing the subtree makes descendants inherit from "server"
+    logger.SetLoggerLevel("server.http.handlers", nil)
+    logger.SetLoggerLevel("server.*", nil)
+    handlers.Info("Handler info inherited")
+
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatalf("Failed to read log file: %v", err)
+    }
+    output := string(data)
+    for _, expected := range []string{"Server warning", "Handler debug", "Handler trace after"} {
+        if !strings.Contains(output, expected) {
+            t.Errorf("Expected %q in %q", expected, output)
+        }
+    }
+    for _, unexpected := range []string{"Server info", "Handler trace component", "Handler info inherited"} {
+        if strings.Contains(output, unexpected) {
+            t.Errorf("Expected %q to be dropped, got %q", unexpected, output)
+        }
+    }
+}
+
+func TestNamedChildren(t *testing.T) {
+    defer logger.ResetLogger()
+    path := filepath.Join(t.TempDir(), "app.log")
+    err := logger.InitLogger(logger.LogConfig{
+        FilePath:     path,
+        FileLevel:    "error",
+        LoggerLevels: map[string]interface{}{"app.db": "debug"},
+    })
+    if err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+    defer func() {
+        for _, name := range []string{"app.db", "app.db.pool"} {
+            logger.SetLoggerLevel(name, nil)
+        }
+    }()
+
+    db := logger.GetLogger("app").Named("db")
+    pool := db.Named("pool")
+    if db != logger.GetLogger("app.db") || pool != logger.GetLogger("app.db.pool") {
+        t.Errorf("Expected children of named loggers to be registered")
+    }
+    if pool.Name() != "app.db.pool" {
+        t.Errorf("Expected name app.db.pool, got %q", pool.Name())
+    }
+    if level, ok := logger.EffectiveLoggerLevel("app.db.pool"); !ok || level != "debug" {
+        t.Errorf("Expected app.db.pool to inherit debug, got %q, %v", level, ok)
+    }
+    if _, ok := logger.EffectiveLoggerLevel("app"); ok {
+        t.Errorf("Expected app to use the output levels")
+    }
+    pool.Debug("Pool debug inherited")
+
+    logger.SetLoggerLevel("app.db.pool", "warning")
+    if level, _ := logger.EffectiveLoggerLevel("app.db.pool"); level != "warning" {
+        t.Errorf("Expected the override of app.db.pool, got %q", level)
+    }
+    pool.Info("Pool info overridden")
+    db.Info("DB info")
+
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatalf("Failed to read log file: %v", err)
+    }
+    output := string(data)
+    for _, expected := range []string{"Pool debug inherited", "DB info", "component=app.db.pool"} {
+        if !strings.Contains(output, expected) {
+            t.Errorf("Expected %q in %q", expected, output)
+        }
+    }
+    if strings.Contains(output, "Pool info overridden") {
+        t.Errorf("Expected the overridden level to drop INFO, got %q", output)
+    }
+}
@@ -0,0 +1,74 @@
+package logger
+
+import "os"
+
+// Namespace is a set of leveled logging functions bound to a named logger, see GetLogger, for
+// package-local logging APIs that spare passing logger instances around:
+//
+//	package dblog
+//
+//	var ns = logger.NewNamespace("db")
+//
+//	var (
+//	    Debug  = ns.Debug
+//	    Info   = ns.Info
+//	    Infof  = ns.Infof
+//	    Errorf = ns.Errorf
+//	)
+//
+// Code in other packages then calls dblog.Info(...). The functions report their own caller, and
+// resolve the named logger on every call so that they follow the re-initialization of the global logger.
+type Namespace struct {
+    Name     string                                // Name of the logger.
+    Trace    func(v ...interface{})                // Logs at the TRACE level.
+    Tracef   func(format string, v ...interface{}) // Logs a formatted message at the TRACE level.
+    Debug    func(v ...interface{})                // Logs at the DEBUG level.
+    Debugf   func(format string, v ...interface{}) // Logs a formatted message at the DEBUG level.
+    Info     func(v ...interface{})                // Logs at the INFO level.
+    Infof    func(format string, v ...interface{}) // Logs a formatted message at the INFO level.
+    Warning  func(v ...interface{})                // Logs at the WARNING level.
+    Warningf func(format string, v ...interface{}) // Logs a formatted message at the WARNING level.
+    Error    func(v ...interface{})                // Logs at the ERROR level.
+    Errorf   func(format string, v ...interface{}) // Logs a formatted message at the ERROR level.
+    Fatal    func(v ...interface{})                // Logs at the FATAL level and exits with status 1.
+    Fatalf   func(format string, v ...interface{}) // Logs a formatted message at the FATAL level and exits with status 1.
+    Logger   func() *Logger                        // Returns the named logger, e.g. to add fields with WithFields.
+}
+
+// NewNamespace returns the logging functions of the named logger.
+//
+// Arguments:
+//   - name (string): Name of the logger, e.g. "db" or "server.http".
+//
+// Returns:
+//   - (Namespace): Logging functions bound to the logger.
+func NewNamespace(name string) Namespace {
+    // log reports the caller of the Namespace function calling it
+    log := func(level string, v ...interface{}) {
+        if l := GetLogger(name); l != nil {
+            l.logSkip(3, level, v...)
+        }
+    }
+    return Namespace{
+        Name:     name,
+        Trace:    func(v ...interface{}) { log("trace", v...) },
+        Tracef:   func(format string, v ...interface{}) { log("trace", sprintf(format, v)) },
+        Debug:    func(v ...interface{}) { log("debug", v...) },
+        Debugf:   func(format string, v ...interface{}) { log("debug", sprintf(format, v)) },
+        Info:     func(v ...interface{}) { log("info", v...) },
+        Infof:    func(format string, v ...interface{}) { log("info", sprintf(format, v)) },
+        Warning:  func(v ...interface{}) { log("warning", v...) },
+        Warningf: func(format string, v ...interface{}) { log("warning", sprintf(format, v)) },
+        Error:    func(v ...interface{}) { log("error", v...) },
+        Errorf:   func(format string, v ...interface{}) { log("error", sprintf(format, v)) },
+        Fatal: func(v ...interface{}) {
+            log("fatal", v...)
+            os.Exit(1)
+        },
+        Fatalf: func(format string, v ...interface{}) {
+            log("fatal", sprintf(format, v))
+            os.Exit(1)
+        },
+        Logger: func() *Logger { return GetLogger(name) },
+    }
+}
@@ -0,0 +1,39 @@
+package logger_test
+
+import (
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+var dblog = logger.NewNamespace("db")
+
+func TestNamespace(t *testing.T) {
+    defer logger.ResetLogger()
+    path := filepath.Join(t.TempDir(), "app.log")
+    if err := logger.InitLogger(logger.LogConfig{FilePath: path, FileLevel: "info", Format: "json"}); err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+
+    info := dblog.Infof
+    info("Connected to %s", "primary")
+    dblog.Debug("Not written")
+    dblog.Logger().WithField("table", "users").Warning("Slow query")
+
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatalf("Failed to read log file: %v", err)
+    }
+    output := string(data)
+    for _, expected := range []string{`"message":"Connected to primary"`, `"component":"db"`, `"file":"namespace_test.go"`, `"table":"users"`} {
+        if !strings.Contains(output, expected) {
+            t.Errorf("Expected %s in %s", expected, output)
+        }
+    }
+    if strings.Contains(output, "Not written") {
+        t.Errorf("Expected the debug entry to be filtered, got %s", output)
+    }
+}
@@ -0,0 +1,87 @@
+package logger
+
+import (
+    "crypto/rand"
+    "encoding/hex"
+    "time"
+)
+
+// Operation tracks a unit of work started with Begin. Entries logged through the operation
+// carry its name and generated ID in the "op" and "op_id" fields.
+type Operation struct {
+    *Logger
+    name  string
+    id    string
+    start time.Time
+}
+
+// Begin starts an operation on the global logger, see (*Logger).Begin.
+//
+// Arguments:
+//   - name (string): Operation name.
+//
+// Returns:
+//   - (*Operation): Operation handle.
+func Begin(name string) *Operation {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return nil
+    }
+    return logInstance.Begin(name)
+}
+
+// Begin starts an operation and logs its start at the DEBUG level.
+// Call End on the returned handle to log a summary entry with the duration and outcome.
+//
+// Example usage:
+//
+//	op := log.Begin("import-users")
+//	op.Info("Importing batch")
+//	op.End(err)
+//
+// Arguments:
+//   - name (string): Operation name.
+//
+// Returns:
+//   - (*Operation): Operation handle.
+func (l *Logger) Begin(name string) *Operation {
+    id := newOperationID()
+    op := &Operation{
+        Logger: l.WithFields(Fields{"op": name, "op_id": id}),
+        name:   name,
+        id:     id,
+        start:  time.Now(),
+    }
+    op.logSkip(2, "debug", "Operation started")
+    return op
+}
+
+// ID returns the generated identifier of the operation.
+func (op *Operation) ID() string {
+    return op.id
+}
+
+// End logs the summary entry of the operation with its duration and outcome:
+// at the INFO level on success, or at the ERROR level with the error if err is not nil.
+//
+// Arguments:
+//   - err (error): Error the operation failed with, or nil on success.
+func (op *Operation) End(err error) {
+    fields := Fields{"duration": time.Since(op.start).String(), "outcome": "success"}
+    level := "info"
+    if err != nil {
+        fields["outcome"] = "failure"
+        fields["error"] = err.Error()
+        level = "error"
+    }
+    op.WithFields(fields).logSkip(2, level, "Operation finished")
+}
+
+// newOperationID returns a random 16-character hexadecimal identifier.
+func newOperationID() string {
+    b := make([]byte, 8)
+    if _, err := rand.Read(b); err != nil {
+        return hex.EncodeToString([]byte(time.Now().Format("150405.000")))[:16]
+    }
+    return hex.EncodeToString(b)
+}
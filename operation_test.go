@@ -0,0 +1,51 @@
+package logger_test
+
+import (
+    "encoding/json"
+    "errors"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestWithFields(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info"})
+    log.WithFields(logger.Fields{"user": "bob", "attempt": 2}).Info("Login")
+
+    output := read()
+    if !strings.Contains(output, "Login attempt=2 user=bob") {
+        t.Errorf("Expected sorted fields after the message, got '%s'", output)
+    }
+}
+
+func TestOperation(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{Format: "json", FileLevel: "debug"})
+
+    op := log.Begin("import-users")
+    op.Info("Importing batch")
+    op.End(errors.New("connection reset"))
+
+    lines := strings.Split(strings.TrimSpace(read()), "\n")
+    if len(lines) != 3 {
+        t.Fatalf("Expected 3 entries, got %d: %v", len(lines), lines)
+    }
+    for _, line := range lines {
+        var entry map[string]interface{}
+        if err := json.Unmarshal([]byte(line), &entry); err != nil {
+            t.Fatalf("Failed to parse entry '%s': %v", line, err)
+        }
+        if entry["op_id"] != op.ID() || entry["op"] != "import-users" {
+            t.Errorf("Expected operation fields in entry, got '%s'", line)
+        }
+    }
+
+    var summary map[string]interface{}
+    json.Unmarshal([]byte(lines[2]), &summary)
+    if summary["level"] != "error" || summary["outcome"] != "failure" || summary["error"] != "connection reset" {
+        t.Errorf("Unexpected summary entry: %s", lines[2])
+    }
+    if _, ok := summary["duration"]; !ok {
+        t.Errorf("Expected duration in summary entry: %s", lines[2])
+    }
+}
@@ -0,0 +1,194 @@
+package logger
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "runtime"
+    "strings"
+)
+
+// Characters not allowed in file names on Windows, besides control characters.
+const windowsInvalidChars = `<>:"|?*`
+
+// windowsReservedNames are device names that cannot be used as file names on Windows, with or
+// without an extension.
+var windowsReservedNames = map[string]bool{
+    "CON": true, "PRN": true, "AUX": true, "NUL": true,
+    "COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+    "LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// ExpandPath resolves a log file path the way the logger resolves LogConfig.FilePath and the other
+// paths of files it writes: a leading "~" is replaced by the home directory, environment variables
+// written as $VAR or ${VAR} (and %VAR% on Windows) are expanded, slashes are converted to the
+// separator of the platform, and a relative path is made absolute against the working directory,
+// or the directory of the executable if relativeToExecutable is set. The result is validated, so
+// that an undefined variable, a path naming a directory or a file name that is invalid on Windows
+// is reported with a clear error rather than when the file is first written.
+//
+// Arguments:
+//   - path (string): Path to resolve.
+//   - relativeToExecutable (bool): Whether relative paths are resolved against the directory of the executable.
+//
+// Returns:
+//   - (string): Absolute, cleaned path.
+//   - error: Error if the path cannot be resolved or is invalid.
+func ExpandPath(path string, relativeToExecutable bool) (string, error) {
+    base, err := os.Getwd()
+    if relativeToExecutable {
+        var exe string
+        if exe, err = os.Executable(); err == nil {
+            exe, err = filepath.EvalSymlinks(exe)
+            base = filepath.Dir(exe)
+        }
+    }
+    if err != nil {
+        return "", fmt.Errorf("log file path %q: failed to get the base directory: %v", path, err)
+    }
+    return expandPath(path, base, runtime.GOOS)
+}
+
+// expandPath resolves the path against the base directory with the rules of the platform, see ExpandPath.
+func expandPath(path, base, goos string) (string, error) {
+    original := path
+    path = strings.TrimSpace(path)
+    if path == "" {
+        return "", fmt.Errorf("log file path is empty")
+    }
+
+    var undefined []string
+    lookup := func(name string) string {
+        value, ok := os.LookupEnv(name)
+        if !ok {
+            undefined = append(undefined, name)
+        }
+        return value
+    }
+    path = os.Expand(path, lookup)
+    if goos == "windows" {
+        path = expandWindowsVars(path, lookup)
+    }
+    if len(undefined) > 0 {
+        return "", fmt.Errorf("log file path %q: undefined environment variable %s", original, strings.Join(undefined, ", "))
+    }
+
+    if path == "~" || strings.HasPrefix(path, "~/") || (goos == "windows" && strings.HasPrefix(path, `~\`)) {
+        home, err := os.UserHomeDir()
+        if err != nil {
+            return "", fmt.Errorf("log file path %q: %v", original, err)
+        }
+        path = home + path[1:]
+    }
+    if strings.HasSuffix(path, "/") || (goos == "windows" && strings.HasSuffix(path, `\`)) {
+        return "", fmt.Errorf("log file path %q: names a directory, expected a file", original)
+    }
+    if goos == "windows" {
+        if err := validateWindowsPath(path); err != nil {
+            return "", fmt.Errorf("log file path %q: %v", original, err)
+        }
+    }
+
+    path = filepath.Clean(filepath.FromSlash(path))
+    if !filepath.IsAbs(path) {
+        path = filepath.Join(base, path)
+    }
+    if info, err := os.Stat(path); err == nil && info.IsDir() {
+        return "", fmt.Errorf("log file path %q: names a directory, expected a file", original)
+    }
+    return path, nil
+}
+
+// expandWindowsVars expands %VAR% environment variables; a % starting no variable name is kept.
+func expandWindowsVars(path string, lookup func(string) string) string {
+    var b strings.Builder
+    for {
+        start := strings.IndexByte(path, '%')
+        if start < 0 {
+            break
+        }
+        end := strings.IndexByte(path[start+1:], '%')
+        if end < 0 {
+            break
+        }
+        name := path[start+1 : start+1+end]
+        if name == "" || strings.ContainsAny(name, `\/`) {
+            // Not a variable, keep the first %
+            b.WriteString(path[:start+1])
+            path = path[start+1:]
+            continue
+        }
+        b.WriteString(path[:start])
+        b.WriteString(lookup(name))
+        path = path[start+2+end:]
+    }
+    b.WriteString(path)
+    return b.String()
+}
+
+// validateWindowsPath reports invalid characters and reserved device names in the components of a
+// Windows path. The drive letter and the prefix of extended-length paths are skipped.
+func validateWindowsPath(path string) error {
+    rest := strings.TrimPrefix(path, `\\?\`)
+    if len(rest) >= 2 && rest[1] == ':' {
+        rest = rest[2:]
+    }
+    for _, name := range strings.FieldsFunc(rest, func(r rune) bool { return r == '/' || r == '\\' }) {
+        if name == "." || name == ".." {
+            continue
+        }
+        for _, r := range name {
+            if r < 32 || strings.ContainsRune(windowsInvalidChars, r) {
+                return fmt.Errorf("invalid character %q in %q", r, name)
+            }
+        }
+        stem, _, _ := strings.Cut(name, ".")
+        if windowsReservedNames[strings.ToUpper(strings.TrimSpace(stem))] {
+            return fmt.Errorf("%q is a reserved device name on Windows", name)
+        }
+        if strings.HasSuffix(name, " ") || strings.HasSuffix(name, ".") {
+            return fmt.Errorf("%q ends with a space or a dot, which Windows drops", name)
+        }
+    }
+    return nil
+}
+
+// resolvePaths resolves the paths of the files written by the logger, see ExpandPath. The maps and
+// slices holding paths are copied so that the configuration of the caller is not modified.
+func (c *LogConfig) resolvePaths() error {
+    resolve := func(path string) (string, error) {
+        if path == "" {
+            return "", nil
+        }
+        return ExpandPath(path, c.RelativeToExe)
+    }
+    var err error
+    if c.FilePath, err = resolve(c.FilePath); err != nil {
+        return err
+    }
+    if len(c.LevelFiles) > 0 {
+        files := make(map[string]string, len(c.LevelFiles))
+        for level, path := range c.LevelFiles {
+            if files[level], err = resolve(path); err != nil {
+                return fmt.Errorf("level file %s: %v", level, err)
+            }
+        }
+        c.LevelFiles = files
+    }
+    if len(c.Sinks) > 0 {
+        c.Sinks = append([]SinkConfig(nil), c.Sinks...)
+        for i := range c.Sinks {
+            if c.Sinks[i].Path, err = resolve(c.Sinks[i].Path); err != nil {
+                return fmt.Errorf("sink %d: %v", i+1, err)
+            }
+        }
+    }
+    if c.Diagnostics != nil && c.Diagnostics.Path != "" {
+        diagnostics := *c.Diagnostics
+        if diagnostics.Path, err = resolve(diagnostics.Path); err != nil {
+            return fmt.Errorf("diagnostics: %v", err)
+        }
+        c.Diagnostics = &diagnostics
+    }
+    return nil
+}
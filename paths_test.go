@@ -0,0 +1,92 @@
+package logger
+
+import (
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+)
+
+func TestExpandPath(t *testing.T) {
+    dir := t.TempDir()
+    t.Setenv("LOGGER_TEST_DIR", dir)
+    t.Setenv("HOME", dir)
+    base := filepath.Join(dir, "base")
+
+    for path, expected := range map[string]string{
+        "$LOGGER_TEST_DIR/app.log":         filepath.Join(dir, "app.log"),
+        "${LOGGER_TEST_DIR}/logs/../a.log": filepath.Join(dir, "a.log"),
+        "~/app.log":                        filepath.Join(dir, "app.log"),
+        "logs/app.log":                     filepath.Join(base, "logs", "app.log"),
+        " /var/log/app.log ":               "/var/log/app.log",
+    } {
+        got, err := expandPath(path, base, "linux")
+        if err != nil || got != expected {
+            t.Errorf("Expected %q to resolve to %q, got %q, %v", path, expected, got, err)
+        }
+    }
+
+    for path, message := range map[string]string{
+        "$LOGGER_TEST_MISSING/app.log": "undefined environment variable LOGGER_TEST_MISSING",
+        "logs/":                        "names a directory",
+        "$LOGGER_TEST_DIR":             "names a directory",
+        "":                             "log file path is empty",
+    } {
+        if _, err := expandPath(path, base, "linux"); err == nil || !strings.Contains(err.Error(), message) {
+            t.Errorf("Expected %q to fail with '%s', got %v", path, message, err)
+        }
+    }
+}
+
+func TestExpandWindowsPath(t *testing.T) {
+    t.Setenv("LOGGER_TEST_NAME", "app")
+    if got := expandWindowsVars(`C:\logs\%LOGGER_TEST_NAME%.log`, os.Getenv); got != `C:\logs\app.log` {
+        t.Errorf("Expected %%VAR%% to be expanded, got %q", got)
+    }
+    if got := expandWindowsVars(`C:\logs\50%\100%%.log`, os.Getenv); got != `C:\logs\50%\100%%.log` {
+        t.Errorf("Expected lone %% to be kept, got %q", got)
+    }
+
+    for path, valid := range map[string]bool{
+        `C:\logs\app.log`:        true,
+        `\\?\C:\logs\app.log`:    true,
+        `..\logs\app.log`:        true,
+        `C:\logs\con.log`:        false,
+        `C:\logs\app|1.log`:      false,
+        `C:\logs\app.log.`:       false,
+        `C:\logs\aux\app.log`:    false,
+        `C:\logs\app.log:stream`: false,
+    } {
+        if err := validateWindowsPath(path); (err == nil) != valid {
+            t.Errorf("Expected %q to be valid: %v, got %v", path, valid, err)
+        }
+    }
+    if _, err := expandPath(`logs\`, `C:\app`, "windows"); err == nil || !strings.Contains(err.Error(), "names a directory") {
+        t.Errorf("Expected a trailing backslash to be rejected, got %v", err)
+    }
+}
+
+func TestNewLoggerResolvesPaths(t *testing.T) {
+    dir := t.TempDir()
+    t.Setenv("LOGGER_TEST_DIR", dir)
+    config := LogConfig{FilePath: "$LOGGER_TEST_DIR/app.log", FileLevel: "info", LevelFiles: map[string]string{"error": "${LOGGER_TEST_DIR}/error.log"}}
+    l, err := NewLogger(config)
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    defer l.Close()
+    l.Error("Resolved")
+
+    for _, name := range []string{"app.log", "error.log"} {
+        if data, err := os.ReadFile(filepath.Join(dir, name)); err != nil || !strings.Contains(string(data), "Resolved") {
+            t.Errorf("Expected the entry in %s, got '%s', %v", name, data, err)
+        }
+    }
+    if l.Config.FilePath != filepath.Join(dir, "app.log") || config.LevelFiles["error"] != "${LOGGER_TEST_DIR}/error.log" {
+        t.Errorf("Expected the resolved path in the logger only, got %q and %v", l.Config.FilePath, config.LevelFiles)
+    }
+
+    if _, err := NewLogger(LogConfig{FilePath: "$LOGGER_TEST_MISSING/app.log"}); err == nil {
+        t.Errorf("Expected an undefined variable to be rejected")
+    }
+}
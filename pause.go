@@ -0,0 +1,105 @@
+package logger
+
+import (
+    "fmt"
+    "sync"
+)
+
+// maxPausedBytes bounds the console output held while the console is paused; later lines are
+// dropped and counted.
+const maxPausedBytes = 1 << 20
+
+// consolePause holds the lines of the console output while it is paused.
+type consolePause struct {
+    mu      sync.Mutex
+    paused  bool
+    held    []byte
+    dropped int
+}
+
+// hold keeps the lines while the console is paused, reporting whether they were held.
+func (p *consolePause) hold(lines []byte) bool {
+    if p == nil {
+        return false
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if !p.paused {
+        return false
+    }
+    if len(p.held)+len(lines) > maxPausedBytes {
+        p.dropped++
+    } else {
+        p.held = append(p.held, lines...)
+    }
+    return true
+}
+
+// PauseConsole holds the console output of the global logger, see (*Logger).PauseConsole.
+func PauseConsole() {
+    ensureLoggerInitialized()
+    if logInstance != nil {
+        logInstance.PauseConsole()
+    }
+}
+
+// ResumeConsole writes the held console output of the global logger and resumes it,
+// see (*Logger).ResumeConsole.
+func ResumeConsole() {
+    ensureLoggerInitialized()
+    if logInstance != nil {
+        logInstance.ResumeConsole()
+    }
+}
+
+// PauseConsole holds the entries written to the console until ResumeConsole, so that a CLI can show
+// an interactive prompt without log lines interleaving with the user input. Other outputs are not
+// affected. Up to 1 MiB of console output is held; later entries are dropped and counted.
+func (l *Logger) PauseConsole() {
+    if s := l.consoleSink(); s != nil {
+        s.pause.mu.Lock()
+        s.pause.paused = true
+        s.pause.mu.Unlock()
+    }
+}
+
+// ResumeConsole writes the console entries held since PauseConsole, followed by a note of the
+// number of dropped entries if any, and resumes the console output. The held entries are also
+// written before a FATAL entry and when the logger is closed.
+func (l *Logger) ResumeConsole() {
+    if s := l.consoleSink(); s != nil {
+        s.resume()
+    }
+}
+
+// resume writes the lines held while the output was paused and resumes it.
+func (s *outputSink) resume() {
+    if s.pause == nil {
+        return
+    }
+    s.pause.mu.Lock()
+    defer s.pause.mu.Unlock()
+    if !s.pause.paused {
+        return
+    }
+    held := s.pause.held
+    if s.pause.dropped > 0 {
+        held = fmt.Appendf(held, "%d console entries dropped while the console was paused\n", s.pause.dropped)
+        diagnose(1, DebugLevel, "console", fmt.Sprintf("%d entries dropped while the console was paused", s.pause.dropped))
+    }
+    s.pause.paused, s.pause.held, s.pause.dropped = false, nil, 0
+    if len(held) > 0 {
+        // Written under the lock so that entries logged meanwhile follow the held ones
+        s.writeOut(held)
+    }
+}
+
+// consoleSink returns the console output of the logger, nil if it is disabled.
+func (l *Logger) consoleSink() *outputSink {
+    if i := l.sinkIndex(destinationConsole); i >= 0 {
+        if s, ok := l.sinks[i].(*outputSink); ok {
+            return s
+        }
+    }
+    return nil
+}
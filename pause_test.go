@@ -0,0 +1,73 @@
+package logger_test
+
+import (
+    "bytes"
+    "os"
+    "os/exec"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestPauseConsole(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info", ConsoleLevel: "info", ConsoleOutput: true})
+    var console bytes.Buffer
+    log.ConsoleLogger.SetOutput(&console)
+
+    log.PauseConsole()
+    log.Info("Logged during the prompt")
+    if console.Len() != 0 {
+        t.Errorf("Expected no console output while paused, got %q", console.String())
+    }
+    if !strings.Contains(read(), "Logged during the prompt") {
+        t.Errorf("Expected the file output to continue while the console is paused")
+    }
+
+    log.ResumeConsole()
+    log.Info("Logged after the prompt")
+    output := console.String()
+    during, after := strings.Index(output, "Logged during the prompt"), strings.Index(output, "Logged after the prompt")
+    if during < 0 || after < during {
+        t.Errorf("Expected the held entry before the later one, got %q", output)
+    }
+}
+
+func TestPauseConsoleClose(t *testing.T) {
+    var console bytes.Buffer
+    log, err := logger.NewLogger(logger.LogConfig{ConsoleLevel: "info", Output: &console})
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    log.PauseConsole()
+    log.Info("Held until closed")
+    log.Close()
+    if !strings.Contains(console.String(), "Held until closed") {
+        t.Errorf("Expected the held entry to be written on Close, got %q", console.String())
+    }
+}
+
+func TestPauseConsoleFatal(t *testing.T) {
+    if os.Getenv("LOGGER_TEST_FATAL") == "1" {
+        log, err := logger.NewLogger(logger.LogConfig{ConsoleLevel: "info", ConsoleOutput: true})
+        if err != nil {
+            t.Fatalf("Failed to create logger: %v", err)
+        }
+        log.PauseConsole()
+        log.Info("Held entry")
+        log.Fatal("Fatal entry")
+        return
+    }
+
+    // The fatal entry exits, so it is logged by a child process
+    cmd := exec.Command(os.Args[0], "-test.run=^TestPauseConsoleFatal$")
+    cmd.Env = append(os.Environ(), "LOGGER_TEST_FATAL=1")
+    output, err := cmd.Output()
+    if _, ok := err.(*exec.ExitError); !ok {
+        t.Fatalf("Expected the child process to exit with an error, got %v", err)
+    }
+    held, fatal := strings.Index(string(output), "Held entry"), strings.Index(string(output), "Fatal entry")
+    if held < 0 || fatal < held {
+        t.Errorf("Expected the held entry before the fatal entry, got %q", output)
+    }
+}
@@ -0,0 +1,124 @@
+package logger
+
+import (
+    "fmt"
+    "regexp"
+)
+
+// Processor transforms or filters an entry on its way to the outputs. It returns the entry to pass on
+// and whether to keep it; returning false drops the entry. Processors must not modify the Fields map
+// of the entry in place, since it is shared with other outputs: use Entry.CloneFields to change fields.
+// The map is reused for later entries once the entry is written, so processors keeping an entry
+// beyond the call keep a copy of its fields made with CloneFields.
+//
+// Processors configured in LogConfig.Processors apply to every entry before any output (including
+// subscribers and the ring buffer), while FileProcessors and ConsoleProcessors apply to a single output,
+// so that e.g. full details go to the file and a redacted summary to the console.
+type Processor func(e Entry) (Entry, bool)
+
+// applyProcessors runs the entry through the processors in order, stopping when one drops it.
+// A processor that panics is reported as the component and skipped, passing the entry on unchanged.
+func applyProcessors(component string, processors []Processor, e Entry) (Entry, bool) {
+    for i, p := range processors {
+        result, keep := e, true
+        guard(fmt.Sprintf("%s %d", component, i+1), func() {
+            result, keep = p(e)
+        })
+        if !keep {
+            return e, false
+        }
+        e = result
+    }
+    return e, true
+}
+
+// CloneFields returns a copy of the fields of the entry that can be modified freely.
+//
+// Returns:
+//   - (Fields): Copy of the fields, never nil.
+func (e Entry) CloneFields() Fields {
+    fields := make(Fields, len(e.Fields))
+    for key, value := range e.Fields {
+        fields[key] = value
+    }
+    return fields
+}
+
+// Filter returns a processor keeping only the entries for which keep returns true.
+//
+// Arguments:
+//   - keep (func(Entry) bool): Function selecting the entries to keep.
+//
+// Returns:
+//   - (Processor): Filtering processor.
+func Filter(keep func(Entry) bool) Processor {
+    return func(e Entry) (Entry, bool) {
+        return e, keep(e)
+    }
+}
+
+// DropFields returns a processor removing the given fields from entries.
+//
+// Arguments:
+//   - keys (...string): Names of the fields to remove.
+//
+// Returns:
+//   - (Processor): Processor removing the fields.
+func DropFields(keys ...string) Processor {
+    return func(e Entry) (Entry, bool) {
+        var fields Fields
+        for _, key := range keys {
+            if _, ok := e.Fields[key]; ok {
+                if fields == nil {
+                    fields = e.CloneFields()
+                }
+                delete(fields, key)
+            }
+        }
+        if fields != nil {
+            e.Fields = fields
+        }
+        return e, true
+    }
+}
+
+// MaskFields returns a processor replacing the values of the given fields with "[REDACTED]".
+//
+// Arguments:
+//   - keys (...string): Names of the fields to mask.
+//
+// Returns:
+//   - (Processor): Masking processor.
+func MaskFields(keys ...string) Processor {
+    return func(e Entry) (Entry, bool) {
+        var fields Fields
+        for _, key := range keys {
+            if _, ok := e.Fields[key]; ok {
+                if fields == nil {
+                    fields = e.CloneFields()
+                }
+                fields[key] = "[REDACTED]"
+            }
+        }
+        if fields != nil {
+            e.Fields = fields
+        }
+        return e, true
+    }
+}
+
+// ReplaceMessage returns a processor replacing matches of the pattern in messages,
+// with the same replacement syntax as regexp.Regexp.ReplaceAllString.
+//
+// Arguments:
+//   - pattern (*regexp.Regexp): Pattern to replace.
+//   - replacement (string): Replacement text.
+//
+// Returns:
+//   - (Processor): Replacing processor.
+func ReplaceMessage(pattern *regexp.Regexp, replacement string) Processor {
+    return func(e Entry) (Entry, bool) {
+        e.Message = pattern.ReplaceAllString(e.Message, replacement)
+        return e, true
+    }
+}
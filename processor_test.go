@@ -0,0 +1,53 @@
+package logger_test
+
+import (
+    "bytes"
+    "io"
+    "os"
+    "regexp"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestPerOutputProcessors(t *testing.T) {
+    originalStdout := os.Stdout
+    r, w, _ := os.Pipe()
+    os.Stdout = w
+
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel:     "info",
+        ConsoleLevel:  "info",
+        ConsoleOutput: true,
+        Processors: []logger.Processor{
+            logger.DropFields("internal"),
+        },
+        ConsoleProcessors: []logger.Processor{
+            logger.MaskFields("email"),
+            logger.ReplaceMessage(regexp.MustCompile(`card \d+`), "card ****"),
+            logger.Filter(func(e logger.Entry) bool { return e.Fields["audit"] == nil }),
+        },
+    })
+    log.WithFields(logger.Fields{"email": "bob@example.com", "internal": 1}).Info("Paid with card 4242")
+    log.WithField("audit", true).Info("Audit only in file")
+
+    w.Close()
+    os.Stdout = originalStdout
+    var console bytes.Buffer
+    io.Copy(&console, r)
+
+    file := read()
+    if !strings.Contains(file, "Paid with card 4242 email=bob@example.com") || !strings.Contains(file, "Audit only in file") {
+        t.Errorf("Expected full details in file, got '%s'", file)
+    }
+    if strings.Contains(file, "internal=") {
+        t.Errorf("Expected global processor to drop the field in file, got '%s'", file)
+    }
+    if !strings.Contains(console.String(), "Paid with card **** email=[REDACTED]") {
+        t.Errorf("Expected redacted entry on console, got '%s'", console.String())
+    }
+    if strings.Contains(console.String(), "Audit only in file") {
+        t.Errorf("Expected audit entry to be filtered from console, got '%s'", console.String())
+    }
+}
@@ -0,0 +1,153 @@
+package logger
+
+import (
+    "fmt"
+    "sync"
+    "time"
+)
+
+// Default throttling of progress entries, see (*ProgressTracker).Throttle.
+var (
+    DefaultProgressInterval = 10 * time.Second // Minimum time between progress entries.
+    DefaultProgressPercent  = 10.0             // Minimum progress, in percent, between entries.
+)
+
+// ProgressTracker reports the progress of a long-running task as throttled INFO entries.
+// It is safe for concurrent use.
+type ProgressTracker struct {
+    l           *Logger
+    name        string
+    total       int64
+    interval    time.Duration
+    percentStep float64
+
+    mu          sync.Mutex
+    done        int64
+    start       time.Time
+    lastTime    time.Time
+    lastPercent float64
+    finished    bool
+}
+
+// Progress creates a progress tracker on the global logger, see (*Logger).Progress.
+//
+// Arguments:
+//   - name (string): Task name.
+//   - total (int64): Total amount of work, 0 if unknown.
+//
+// Returns:
+//   - (*ProgressTracker): Progress tracker.
+func Progress(name string, total int64) *ProgressTracker {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return nil
+    }
+    return logInstance.Progress(name, total)
+}
+
+// Progress creates a progress tracker for a task. Progress entries are logged by Add
+// when DefaultProgressInterval has elapsed or DefaultProgressPercent of the total has been
+// completed since the previous entry, and always when the task completes.
+//
+// Arguments:
+//   - name (string): Task name.
+//   - total (int64): Total amount of work, 0 if unknown.
+//
+// Returns:
+//   - (*ProgressTracker): Progress tracker.
+func (l *Logger) Progress(name string, total int64) *ProgressTracker {
+    now := time.Now()
+    return &ProgressTracker{
+        l:           l.WithField("task", name),
+        name:        name,
+        total:       total,
+        interval:    DefaultProgressInterval,
+        percentStep: DefaultProgressPercent,
+        start:       now,
+        lastTime:    now,
+    }
+}
+
+// Throttle changes how often progress entries are logged.
+//
+// Arguments:
+//   - interval (time.Duration): Minimum time between entries, 0 disables time-based entries.
+//   - percent (float64): Minimum progress in percent between entries, 0 disables percent-based entries.
+//
+// Returns:
+//   - (*ProgressTracker): The tracker, for chaining.
+func (p *ProgressTracker) Throttle(interval time.Duration, percent float64) *ProgressTracker {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    p.interval = interval
+    p.percentStep = percent
+    return p
+}
+
+// Add records n completed units of work and logs a progress entry if the throttling allows it.
+//
+// Arguments:
+//   - n (int64): Completed units of work.
+func (p *ProgressTracker) Add(n int64) {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.finished {
+        return
+    }
+    p.done += n
+    now := time.Now()
+    percent := p.percent()
+
+    complete := p.total > 0 && p.done >= p.total
+    byTime := p.interval > 0 && now.Sub(p.lastTime) >= p.interval
+    byPercent := p.total > 0 && p.percentStep > 0 && percent-p.lastPercent >= p.percentStep
+    if !complete && !byTime && !byPercent {
+        return
+    }
+
+    p.lastTime = now
+    p.lastPercent = percent
+    if complete {
+        p.finished = true
+        p.emit(now, "Progress complete")
+        return
+    }
+    p.emit(now, "Progress")
+}
+
+// Done logs a final progress entry, even if the total has not been reached.
+func (p *ProgressTracker) Done() {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.finished {
+        return
+    }
+    p.finished = true
+    p.emit(time.Now(), "Progress complete")
+}
+
+// percent returns the completed percentage, 0 if the total is unknown.
+func (p *ProgressTracker) percent() float64 {
+    if p.total <= 0 {
+        return 0
+    }
+    percent := float64(p.done) * 100 / float64(p.total)
+    if percent > 100 {
+        percent = 100
+    }
+    return percent
+}
+
+// emit logs a progress entry. It must be called with p.mu held.
+func (p *ProgressTracker) emit(now time.Time, msg string) {
+    elapsed := now.Sub(p.start)
+    fields := Fields{
+        "done":    p.done,
+        "elapsed": elapsed.Round(time.Millisecond).String(),
+    }
+    if p.total > 0 {
+        fields["total"] = p.total
+        fields["percent"] = fmt.Sprintf("%.1f", p.percent())
+    }
+    p.l.WithFields(fields).logSkip(3, "info", msg)
+}
@@ -0,0 +1,30 @@
+package logger_test
+
+import (
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+func TestProgressThrottling(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info"})
+
+    p := log.Progress("reindex", 1000).Throttle(time.Hour, 25)
+    for i := 0; i < 1000; i++ {
+        p.Add(1)
+    }
+    p.Add(1) // Ignored after completion
+
+    output := read()
+    if count := strings.Count(output, "[INFO] Progress"); count != 4 {
+        t.Errorf("Expected 4 progress entries (25%%, 50%%, 75%%, complete), got %d: %s", count, output)
+    }
+    if !strings.Contains(output, "Progress complete") || !strings.Contains(output, "percent=100.0") {
+        t.Errorf("Expected completion entry, got '%s'", output)
+    }
+    if !strings.Contains(output, "task=reindex") {
+        t.Errorf("Expected task field in progress entries, got '%s'", output)
+    }
+}
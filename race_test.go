@@ -0,0 +1,6 @@
+//go:build race
+
+package logger_test
+
+// raceEnabled reports whether the tests run under the race detector, which makes sync.Pool drop values at random.
+const raceEnabled = true
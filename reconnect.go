@@ -0,0 +1,96 @@
+package logger
+
+import (
+    "errors"
+    "fmt"
+    "net"
+    "time"
+)
+
+// Timeouts and reconnection delays of the connections of the remote outputs.
+const (
+    remoteDialTimeout  = 5 * time.Second
+    remoteWriteTimeout = 5 * time.Second
+    remoteMinBackoff   = time.Second
+    remoteMaxBackoff   = time.Minute
+)
+
+// reconnectingConn is the connection of a remote output such as syslog or GELF, which write under
+// their mutex on the logging path. It connects on demand with a timeout and sets a deadline on every
+// write, so that a server that does not answer or stops reading cannot block logging indefinitely.
+// After a failed connection or a timed out write, writes fail without dialing until a backoff delay,
+// doubled after each failure up to a minute, has elapsed. It is not safe for concurrent use.
+type reconnectingConn struct {
+    dial    func(dialer *net.Dialer) (net.Conn, error)
+    timeout time.Duration // Deadline of each write.
+
+    conn    net.Conn
+    err     error         // Error of the last failure, returned during the backoff.
+    backoff time.Duration // Delay after the last failure.
+    retry   time.Time     // Time before which no connection is attempted.
+}
+
+// newReconnectingConn returns a connection dialed with dial.
+func newReconnectingConn(dial func(dialer *net.Dialer) (net.Conn, error)) *reconnectingConn {
+    return &reconnectingConn{dial: dial, timeout: remoteWriteTimeout}
+}
+
+// write calls send with the connection, connecting first if needed. If the connection was lost,
+// it reconnects once; a write that timed out is not retried, as the server stopped reading.
+func (c *reconnectingConn) write(send func(conn net.Conn) error) error {
+    var err error
+    for attempt := 0; attempt < 2; attempt++ {
+        if c.conn == nil {
+            if err = c.connect(); err != nil {
+                return err
+            }
+        }
+        c.conn.SetWriteDeadline(time.Now().Add(c.timeout))
+        if err = send(c.conn); err == nil {
+            return nil
+        }
+        c.close()
+        var netErr net.Error
+        if errors.As(err, &netErr) && netErr.Timeout() {
+            c.fail(fmt.Errorf("write timed out: %v", err))
+            return c.err
+        }
+    }
+    return err
+}
+
+// connect dials the server unless the backoff after the last failure is in progress.
+func (c *reconnectingConn) connect() error {
+    if time.Now().Before(c.retry) {
+        return c.err
+    }
+    conn, err := c.dial(&net.Dialer{Timeout: remoteDialTimeout})
+    if err != nil {
+        c.fail(err)
+        return err
+    }
+    c.conn, c.err, c.backoff, c.retry = conn, nil, 0, time.Time{}
+    return nil
+}
+
+// fail records the failure and starts the next backoff delay.
+func (c *reconnectingConn) fail(err error) {
+    c.backoff = min(max(c.backoff*2, remoteMinBackoff), remoteMaxBackoff)
+    c.retry = time.Now().Add(c.backoff)
+    c.err = err
+}
+
+// connected reports whether the connection is open.
+func (c *reconnectingConn) connected() bool {
+    return c.conn != nil
+}
+
+// close closes the connection, which is opened again by the next write.
+func (c *reconnectingConn) close() error {
+    if c.conn == nil {
+        return nil
+    }
+    err := c.conn.Close()
+    c.conn = nil
+    return err
+}
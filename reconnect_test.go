@@ -0,0 +1,67 @@
+package logger
+
+import (
+    "errors"
+    "net"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestReconnectingConnBackoff(t *testing.T) {
+    dials := 0
+    c := newReconnectingConn(func(dialer *net.Dialer) (net.Conn, error) {
+        dials++
+        if dialer.Timeout <= 0 {
+            t.Error("Expected a dial timeout")
+        }
+        return nil, errors.New("connection refused")
+    })
+    send := func(conn net.Conn) error { return nil }
+
+    for i := 0; i < 3; i++ {
+        if err := c.write(send); err == nil || err.Error() != "connection refused" {
+            t.Errorf("Expected the connection error, got %v", err)
+        }
+    }
+    if dials != 1 {
+        t.Errorf("Expected no dial during the backoff, got %d dials", dials)
+    }
+
+    c.retry = time.Now()
+    c.write(send)
+    if dials != 2 || c.backoff != 2*remoteMinBackoff {
+        t.Errorf("Expected a dial after the backoff and a doubled delay, got %d dials, %v", dials, c.backoff)
+    }
+}
+
+func TestReconnectingConnWriteTimeout(t *testing.T) {
+    dials := 0
+    var peer net.Conn
+    c := newReconnectingConn(func(dialer *net.Dialer) (net.Conn, error) {
+        dials++
+        conn, other := net.Pipe()
+        peer = other // Never read, like a stalled server
+        return conn, nil
+    })
+    c.timeout = 50 * time.Millisecond
+    defer func() { peer.Close() }()
+
+    start := time.Now()
+    err := c.write(func(conn net.Conn) error {
+        _, err := conn.Write([]byte("message"))
+        return err
+    })
+    if err == nil || !strings.Contains(err.Error(), "write timed out") {
+        t.Errorf("Expected the write to time out, got %v", err)
+    }
+    if elapsed := time.Since(start); elapsed > 5*time.Second {
+        t.Errorf("Expected the write to return after its deadline, took %v", elapsed)
+    }
+    if dials != 1 || c.connected() {
+        t.Errorf("Expected no reconnection after a timeout, got %d dials", dials)
+    }
+    if err := c.write(func(conn net.Conn) error { return nil }); err == nil || dials != 1 {
+        t.Errorf("Expected writes to fail during the backoff, got %v after %d dials", err, dials)
+    }
+}
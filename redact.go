@@ -0,0 +1,184 @@
+package logger
+
+import (
+    "fmt"
+    "regexp"
+    "strings"
+)
+
+// Redacted replaces the redacted values of messages and fields.
+const Redacted = "[REDACTED]"
+
+// DefaultRedactedFields are the field names redacted when RedactionConfig.Fields is nil.
+var DefaultRedactedFields = []string{"password", "passwd", "secret", "token", "authorization", "api_key", "apikey", "cookie"}
+
+// RedactionConfig removes secrets and personal data from entries before any output, subscriber or
+// the ring buffer sees them, in every format.
+type RedactionConfig struct {
+    Fields   []string // Substrings of field names whose values are replaced, case-insensitively, also in nested maps (default: DefaultRedactedFields).
+    Patterns []string // Regular expressions replaced in messages and in the text of field values: strings, errors, Stringers and byte slices, also in slices; if a pattern has groups, only the groups are replaced, e.g. `password=(\S+)`.
+}
+
+// redactor is a compiled RedactionConfig.
+type redactor struct {
+    fields   []string
+    patterns []*regexp.Regexp
+}
+
+// Redact returns a processor redacting the values of the configured fields and the matches of the
+// configured patterns with "[REDACTED]".
+//
+// Arguments:
+//   - config (RedactionConfig): Fields and patterns to redact.
+//
+// Returns:
+//   - (Processor): Redacting processor.
+//   - error: Error if a pattern is invalid.
+func Redact(config RedactionConfig) (Processor, error) {
+    names := config.Fields
+    if names == nil {
+        names = DefaultRedactedFields
+    }
+    r := &redactor{}
+    for _, name := range names {
+        if name != "" {
+            r.fields = append(r.fields, strings.ToLower(name))
+        }
+    }
+    for _, p := range config.Patterns {
+        pattern, err := regexp.Compile(p)
+        if err != nil {
+            return nil, fmt.Errorf("invalid redaction pattern %q: %v", p, err)
+        }
+        r.patterns = append(r.patterns, pattern)
+    }
+    return r.process, nil
+}
+
+// process redacts the message and the fields of the entry.
+func (r *redactor) process(e Entry) (Entry, bool) {
+    e.Message = r.redactString(e.Message)
+    e.Fields, _ = r.redactFields(e.Fields)
+    return e, true
+}
+
+// redactFields returns the fields with their values redacted, copied only if a value changes.
+func (r *redactor) redactFields(fields Fields) (Fields, bool) {
+    var result Fields
+    for key, value := range fields {
+        redacted, changed := r.redactValue(key, value)
+        if !changed {
+            continue
+        }
+        if result == nil {
+            result = make(Fields, len(fields))
+            for k, v := range fields {
+                result[k] = v
+            }
+        }
+        result[key] = redacted
+    }
+    if result == nil {
+        return fields, false
+    }
+    return result, true
+}
+
+// redactValue returns the value of the field with the given name redacted, and whether it changed.
+func (r *redactor) redactValue(key string, value interface{}) (interface{}, bool) {
+    if r.sensitive(key) {
+        return Redacted, true
+    }
+    switch v := value.(type) {
+    case string:
+        redacted := r.redactString(v)
+        return redacted, redacted != v
+    case []string:
+        var result []string
+        for i, s := range v {
+            if redacted := r.redactString(s); redacted != s {
+                if result == nil {
+                    result = append([]string(nil), v...)
+                }
+                result[i] = redacted
+            }
+        }
+        if result != nil {
+            return result, true
+        }
+    case Fields:
+        return r.redactFields(v)
+    case map[string]interface{}:
+        redacted, changed := r.redactFields(Fields(v))
+        return map[string]interface{}(redacted), changed
+    case []interface{}:
+        var result []interface{}
+        for i, element := range v {
+            if redacted, changed := r.redactValue(key, element); changed {
+                if result == nil {
+                    result = append([]interface{}(nil), v...)
+                }
+                result[i] = redacted
+            }
+        }
+        if result != nil {
+            return result, true
+        }
+    case []byte:
+        if len(r.patterns) > 0 {
+            s := string(v)
+            if redacted := r.redactString(s); redacted != s {
+                return []byte(redacted), true
+            }
+        }
+    case error, fmt.Stringer:
+        // Outputs render errors and Stringers by their text, which is redacted if a pattern matches it
+        if len(r.patterns) > 0 {
+            s := fmt.Sprint(v)
+            if redacted := r.redactString(s); redacted != s {
+                return redacted, true
+            }
+        }
+    }
+    return value, false
+}
+
+// sensitive reports whether the field name contains one of the redacted names.
+func (r *redactor) sensitive(key string) bool {
+    key = strings.ToLower(key)
+    for _, name := range r.fields {
+        if strings.Contains(key, name) {
+            return true
+        }
+    }
+    return false
+}
+
+// redactString replaces the matches of the patterns, or their groups, in s.
+func (r *redactor) redactString(s string) string {
+    for _, pattern := range r.patterns {
+        if pattern.NumSubexp() == 0 {
+            s = pattern.ReplaceAllLiteralString(s, Redacted)
+            continue
+        }
+        matches := pattern.FindAllStringSubmatchIndex(s, -1)
+        if matches == nil {
+            continue
+        }
+        var b strings.Builder
+        last := 0
+        for _, m := range matches {
+            for g := 2; g+1 < len(m); g += 2 {
+                if m[g] < last {
+                    continue // Unmatched or nested group
+                }
+                b.WriteString(s[last:m[g]])
+                b.WriteString(Redacted)
+                last = m[g+1]
+            }
+        }
+        b.WriteString(s[last:])
+        s = b.String()
+    }
+    return s
+}
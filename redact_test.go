@@ -0,0 +1,69 @@
+package logger_test
+
+import (
+    "errors"
+    "fmt"
+    "net/url"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestRedaction(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel: "info",
+        Format:    "json",
+        Redaction: &logger.RedactionConfig{Patterns: []string{`\b\d{4}-\d{4}-\d{4}-\d{4}\b`, `password=(\S+)`}},
+    })
+    log.WithFields(logger.Fields{
+        "Authorization": "Bearer abc",
+        "db_password":   "hunter2",
+        "request":       logger.Fields{"api_key": "k-123", "path": "/pay"},
+        "note":          "card 1234-5678-9012-3456",
+    }).Info("Login with password=hunter2 and card 1234-5678-9012-3456")
+
+    output := read()
+    for _, secret := range []string{"abc", "hunter2", "k-123", "5678"} {
+        if strings.Contains(output, secret) {
+            t.Errorf("Expected %q to be redacted, got %s", secret, output)
+        }
+    }
+    for _, expected := range []string{
+        `password=[REDACTED] and card [REDACTED]`,
+        `"Authorization":"[REDACTED]"`,
+        `"api_key":"[REDACTED]"`,
+        `"path":"/pay"`,
+        `"note":"card [REDACTED]"`,
+    } {
+        if !strings.Contains(output, expected) {
+            t.Errorf("Expected %s in %s", expected, output)
+        }
+    }
+}
+
+func TestRedactionRenderedValues(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel: "info",
+        Redaction: &logger.RedactionConfig{Patterns: []string{`password=(\S+)`}},
+    })
+    endpoint, _ := url.Parse("https://db.internal/?password=hunter2")
+    log.WithFields(logger.Fields{
+        "error":    errors.New("connect failed: password=hunter2"),
+        "endpoint": endpoint,
+        "body":     []byte("user=bob password=hunter2"),
+        "args":     []interface{}{"--password=hunter2", 42},
+    }).Info("Connection failed")
+
+    output := read()
+    secretBytes := strings.Trim(fmt.Sprint([]byte("hunter2")), "[]")
+    if strings.Contains(output, "hunter2") || strings.Contains(output, secretBytes) || strings.Count(output, "password=[REDACTED]") != 3 {
+        t.Errorf("Expected the text of errors, Stringers, byte slices and slices to be redacted, got %s", output)
+    }
+}
+
+func TestRedactInvalidPattern(t *testing.T) {
+    if _, err := logger.Redact(logger.RedactionConfig{Patterns: []string{"("}}); err == nil {
+        t.Errorf("Expected an error for an invalid pattern")
+    }
+}
@@ -0,0 +1,180 @@
+package logger
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "fmt"
+    "io"
+    "net/http"
+    "time"
+)
+
+// maxRemoteDocument is the largest remote levels document read from the control plane.
+const maxRemoteDocument = 1 << 20
+
+// RemoteConfig configures polling of a control plane for desired log levels and sampling, see WatchRemoteConfig.
+type RemoteConfig struct {
+    URL      string                                    // HTTP endpoint serving a RemoteLevels JSON document, e.g. a Consul KV key with ?raw.
+    Interval time.Duration                             // Polling interval, 30 seconds if zero; each fetch must complete within it.
+    Headers  map[string]string                         // Additional request headers, e.g. authentication tokens.
+    Client   *http.Client                              // HTTP client, http.DefaultClient if nil.
+    Fetch    func(ctx context.Context) ([]byte, error) // Custom fetch function (etcd, files, ...), used instead of URL if set.
+    OnError  func(err error)                           // Called on fetch or apply errors; errors are logged at WARNING if nil.
+}
+
+// RemoteLevels is the document served by the control plane. Fields that are not set keep their current value.
+type RemoteLevels struct {
+    FileLevel    interface{}     `json:"fileLevel,omitempty"`    // Desired level for file output.
+    ConsoleLevel interface{}     `json:"consoleLevel,omitempty"` // Desired level for console output.
+    Verbosity    *int            `json:"verbosity,omitempty"`    // Desired verbosity for V(n) loggers.
+    Sampling     *RemoteSampling `json:"sampling,omitempty"`     // Desired sampling of repeated entries.
+}
+
+// RemoteSampling is the sampling of repeated entries requested by the control plane, see SamplingConfig.
+type RemoteSampling struct {
+    Initial    int    `json:"initial"`            // Number of identical entries written per interval before sampling.
+    Thereafter int    `json:"thereafter"`         // Write every Thereafter-th identical entry after the initial ones.
+    Tick       string `json:"tick,omitempty"`     // Interval the counts are reset at, e.g. "1s" (default: 1 second).
+    Disabled   bool   `json:"disabled,omitempty"` // Whether sampling is turned off.
+}
+
+// WatchRemoteConfig polls the control plane and applies changed levels and sampling to the global
+// logger, see ApplyRemoteLevels, enabling fleet-wide verbosity control. The first poll happens
+// immediately. Each fetch is canceled after Interval, so that a control plane that hangs delays the
+// next poll instead of stopping polling, and documents above maxRemoteDocument bytes are rejected.
+// Polling stops when the context is canceled or the returned stop function is called.
+//
+// Arguments:
+//   - ctx (context.Context): Context controlling the lifetime of the watcher.
+//   - rc (RemoteConfig): Control plane settings.
+//
+// Returns:
+//   - (func()): Function stopping the watcher.
+func WatchRemoteConfig(ctx context.Context, rc RemoteConfig) func() {
+    if rc.Interval <= 0 {
+        rc.Interval = 30 * time.Second
+    }
+    if rc.Client == nil {
+        rc.Client = http.DefaultClient
+    }
+    if rc.Fetch == nil {
+        rc.Fetch = rc.fetchHTTP
+    }
+    if rc.OnError == nil {
+        rc.OnError = func(err error) {
+            Warningf("Remote logger config: %v", err)
+        }
+    }
+
+    ctx, cancel := context.WithCancel(ctx)
+    go func() {
+        var last []byte
+        ticker := time.NewTicker(rc.Interval)
+        defer ticker.Stop()
+        for {
+            fetchCtx, cancelFetch := context.WithTimeout(ctx, rc.Interval)
+            data, err := rc.Fetch(fetchCtx)
+            cancelFetch()
+            if err != nil {
+                if ctx.Err() == nil {
+                    rc.OnError(err)
+                }
+            } else if !bytes.Equal(data, last) {
+                if err := ApplyRemoteLevels(data); err != nil {
+                    rc.OnError(err)
+                } else {
+                    last = data
+                }
+            }
+
+            select {
+            case <-ctx.Done():
+                return
+            case <-ticker.C:
+            }
+        }
+    }()
+    return cancel
+}
+
+// ApplyRemoteLevels applies a RemoteLevels JSON document to the global logger with SetFileLevel,
+// SetConsoleLevel, SetVerbosity and SetSampling. The document is validated before anything changes.
+//
+// Arguments:
+//   - data ([]byte): RemoteLevels JSON document.
+//
+// Returns:
+//   - error: Error if the document is invalid or the logger is not initialized.
+func ApplyRemoteLevels(data []byte) error {
+    var levels RemoteLevels
+    if err := json.Unmarshal(data, &levels); err != nil {
+        return fmt.Errorf("invalid remote levels: %v", err)
+    }
+    l := currentLogger()
+    if l == nil {
+        return fmt.Errorf("logger is not initialized")
+    }
+
+    for _, level := range []interface{}{levels.FileLevel, levels.ConsoleLevel} {
+        if level == nil {
+            continue
+        }
+        if _, err := l.parseLevel(level); err != nil {
+            return fmt.Errorf("invalid remote levels: %v", err)
+        }
+    }
+    var sampling *SamplingConfig
+    if levels.Sampling != nil && !levels.Sampling.Disabled {
+        sampling = &SamplingConfig{Initial: levels.Sampling.Initial, Thereafter: levels.Sampling.Thereafter}
+        if levels.Sampling.Tick != "" {
+            tick, err := time.ParseDuration(levels.Sampling.Tick)
+            if err != nil {
+                return fmt.Errorf("invalid remote sampling tick: %v", err)
+            }
+            sampling.Tick = tick
+        }
+    }
+
+    if levels.FileLevel != nil {
+        l.SetFileLevel(levels.FileLevel)
+    }
+    if levels.ConsoleLevel != nil {
+        l.SetConsoleLevel(levels.ConsoleLevel)
+    }
+    if levels.Verbosity != nil {
+        l.SetVerbosity(*levels.Verbosity)
+    }
+    if levels.Sampling != nil {
+        l.SetSampling(sampling)
+    }
+    diagnose(1, InfoLevel, "remote config", "Applied remote levels")
+    return nil
+}
+
+// fetchHTTP fetches the remote levels document from the configured URL.
+func (rc RemoteConfig) fetchHTTP(ctx context.Context) ([]byte, error) {
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, rc.URL, nil)
+    if err != nil {
+        return nil, err
+    }
+    for key, value := range rc.Headers {
+        req.Header.Set(key, value)
+    }
+    resp, err := rc.Client.Do(req)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode != http.StatusOK {
+        return nil, fmt.Errorf("unexpected status from %s: %s", rc.URL, resp.Status)
+    }
+    data, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteDocument+1))
+    if err != nil {
+        return nil, err
+    }
+    if len(data) > maxRemoteDocument {
+        return nil, fmt.Errorf("remote levels document from %s exceeds %d bytes", rc.URL, maxRemoteDocument)
+    }
+    return data, nil
+}
@@ -0,0 +1,118 @@
+package logger_test
+
+import (
+    "context"
+    "net/http"
+    "os"
+    "path/filepath"
+    "strings"
+    "net/http/httptest"
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+func TestWatchRemoteConfig(t *testing.T) {
+    defer resetLogger()
+    logger.InitLogger(logger.LogConfig{ConsoleLevel: "warning", ConsoleOutput: false})
+
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.Write([]byte(`{"consoleLevel": "debug", "verbosity": 3}`))
+    }))
+    defer server.Close()
+
+    errs := make(chan error, 1)
+    stop := logger.WatchRemoteConfig(context.Background(), logger.RemoteConfig{
+        URL:      server.URL,
+        Interval: 10 * time.Millisecond,
+        OnError:  func(err error) { errs <- err },
+    })
+    defer stop()
+
+    deadline := time.Now().Add(2 * time.Second)
+    for time.Now().Before(deadline) {
+        state := logger.State()
+        if state.ConsoleLevel == "debug" && state.Verbosity == 3 {
+            return
+        }
+        select {
+        case err := <-errs:
+            t.Fatalf("Remote config error: %v", err)
+        case <-time.After(10 * time.Millisecond):
+        }
+    }
+    t.Errorf("Remote levels were not applied: %+v", logger.State())
+}
+
+func TestApplyRemoteLevels(t *testing.T) {
+    defer resetLogger()
+    path := filepath.Join(t.TempDir(), "app.log")
+    if err := logger.InitLogger(logger.LogConfig{FilePath: path, FileLevel: "info"}); err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+    child := logger.WithField("component", "db")
+
+    if err := logger.ApplyRemoteLevels([]byte(`{"fileLevel": "debug", "consoleLevel": "loud"}`)); err == nil {
+        t.Error("Expected an invalid level to be rejected")
+    }
+    if state := logger.State(); state.FileLevel != "info" {
+        t.Errorf("Expected a rejected document to change nothing, got %s", state.FileLevel)
+    }
+    if err := logger.ApplyRemoteLevels([]byte(`{"fileLevel": "debug", "sampling": {"initial": 1, "thereafter": 0, "tick": "1h"}}`)); err != nil {
+        t.Fatalf("Failed to apply remote levels: %v", err)
+    }
+    for i := 0; i < 3; i++ {
+        child.Debug("Sampled")
+    }
+    if err := logger.ApplyRemoteLevels([]byte(`{"sampling": {"disabled": true}}`)); err != nil {
+        t.Fatalf("Failed to disable sampling: %v", err)
+    }
+    child.Debug("Sampled")
+
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatalf("Failed to read log file: %v", err)
+    }
+    if count := strings.Count(string(data), "Sampled"); count != 2 {
+        t.Errorf("Expected the remote level and sampling to apply to the running logger, got %d entries: %s", count, data)
+    }
+}
+
+func TestWatchRemoteConfigStalledEndpoint(t *testing.T) {
+    defer resetLogger()
+    logger.InitLogger(logger.LogConfig{ConsoleLevel: "warning", ConsoleOutput: false})
+
+    release := make(chan struct{})
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.URL.Query().Get("large") != "" {
+            w.Write([]byte(strings.Repeat(" ", 2<<20)))
+            return
+        }
+        <-release
+    }))
+    defer server.Close()
+    defer close(release)
+
+    for _, url := range []string{server.URL, server.URL + "?large=1"} {
+        errs := make(chan error, 10)
+        stop := logger.WatchRemoteConfig(context.Background(), logger.RemoteConfig{
+            URL:      url,
+            Interval: 20 * time.Millisecond,
+            OnError: func(err error) {
+                select {
+                case errs <- err:
+                default:
+                }
+            },
+        })
+        for i := 0; i < 2; i++ {
+            select {
+            case <-errs:
+            case <-time.After(2 * time.Second):
+                t.Fatalf("%s: expected polling to go on with errors", url)
+            }
+        }
+        stop()
+    }
+}
@@ -0,0 +1,122 @@
+package logger
+
+import (
+    "fmt"
+    "sync"
+    "time"
+)
+
+// RepeatedField is the name of the field holding the number of collapsed entries in the summary
+// written by duplicate suppression.
+const RepeatedField = "repeated"
+
+// RepeatConfig collapses consecutive identical entries, like syslogd does. The first entry is
+// written and the following entries with the same level, message and fields are suppressed; a summary
+// "last message repeated N times" at the same level and with the same fields and call site as the
+// repeated entry, plus the field "repeated"=N, is written when a
+// different entry is logged, at the end of every Window with suppressed entries and when the logger
+// is closed. FATAL entries are never suppressed.
+type RepeatConfig struct {
+    Window time.Duration // Maximum delay of the summary of suppressed entries (default: 30 seconds).
+}
+
+// repeatFilter is the state of duplicate suppression, shared by the copies of a logger.
+type repeatFilter struct {
+    window time.Duration
+
+    mu   sync.Mutex
+    last repeatRun // Last entry written and the count of the identical entries suppressed since.
+}
+
+// repeatRun is a run of identical entries: the level, message and fields of the entry, the call site
+// of its first occurrence and the number of suppressed entries.
+type repeatRun struct {
+    level   string
+    message string
+    fields  Fields
+    key     string // Rendered fields, to compare the fields of entries.
+    caller  callerLocation
+    count   uint64
+}
+
+// newRepeatFilter creates the duplicate suppression state, nil if config is nil.
+func newRepeatFilter(config *RepeatConfig) *repeatFilter {
+    if config == nil {
+        return nil
+    }
+    r := &repeatFilter{window: config.Window}
+    if r.window <= 0 {
+        r.window = 30 * time.Second
+    }
+    return r
+}
+
+// check records an entry logged at the call site and reports whether it is suppressed. An entry
+// ending a run of identical entries returns the run to summarize, with a count of 0 if none was
+// suppressed. Entries are identical if their level, message and fields are.
+func (r *repeatFilter) check(level, message string, fields Fields, file string, line int) (bool, repeatRun) {
+    key := formatFields(fields)
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if level == r.last.level && message == r.last.message && key == r.last.key {
+        r.last.count++
+        return true, repeatRun{}
+    }
+    ended := r.last
+    r.last = repeatRun{level: level, message: message, fields: fields, key: key, caller: callerLocation{file: file, line: line}}
+    return false, ended
+}
+
+// pending returns the run of the last entry with the count of the entries suppressed since the last
+// summary, resetting the count so that further identical entries are counted for the next summary.
+func (r *repeatFilter) pending() repeatRun {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    run := r.last
+    r.last.count = 0
+    return run
+}
+
+// logRepeated writes the summary of a run of suppressed entries, with the fields and the call site of
+// the repeated entry.
+func (l *Logger) logRepeated(run repeatRun) {
+    c := *l
+    c.repeats = nil
+    c.fields = withRepeatCount(run.fields, run.count)
+    c.origin = &run.caller
+    c.logSkip(1, run.level, fmt.Sprintf("last message repeated %d times", run.count))
+}
+
+// withRepeatCount returns a copy of the fields with the count of suppressed entries.
+func withRepeatCount(fields Fields, count uint64) Fields {
+    result := make(Fields, len(fields)+1)
+    for key, value := range fields {
+        result[key] = value
+    }
+    result[RepeatedField] = count
+    return result
+}
+
+// flushRepeats writes the summary of the entries suppressed since the last one, if any.
+func (l *Logger) flushRepeats() {
+    if l.repeats == nil {
+        return
+    }
+    if run := l.repeats.pending(); run.count > 0 {
+        l.logRepeated(run)
+    }
+}
+
+// runRepeats writes the summary of suppressed entries every window, until the logger is stopped.
+func (l *Logger) runRepeats() {
+    ticker := time.NewTicker(l.repeats.window)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-l.stop.ch:
+            return
+        case <-ticker.C:
+            l.flushRepeats()
+        }
+    }
+}
@@ -0,0 +1,103 @@
+package logger_test
+
+import (
+    "encoding/json"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+func TestRepeats(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel: "info",
+        Repeats:   &logger.RepeatConfig{Window: time.Hour},
+    })
+    for i := 0; i < 4; i++ {
+        log.Warning("Health check failed")
+    }
+    log.Info("Health check passed")
+    log.Info("Health check passed")
+    if err := log.Close(); err != nil {
+        t.Fatalf("Failed to close logger: %v", err)
+    }
+
+    lines := strings.Split(strings.TrimSpace(read()), "\n")
+    expected := []string{
+        "[WARNING] Health check failed",
+        "[WARNING] last message repeated 3 times repeated=3",
+        "[INFO] Health check passed",
+        "[INFO] last message repeated 1 times repeated=1",
+    }
+    if len(lines) != len(expected) {
+        t.Fatalf("Expected %d entries, got %v", len(expected), lines)
+    }
+    for i, line := range lines {
+        if !strings.HasSuffix(line, expected[i]) {
+            t.Errorf("Expected entry %d to end with %q, got %q", i+1, expected[i], line)
+        }
+    }
+}
+
+func TestRepeatsWindow(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel: "info",
+        Repeats:   &logger.RepeatConfig{Window: 20 * time.Millisecond},
+    })
+    defer log.Close()
+    log.Info("Polling")
+    log.Info("Polling")
+
+    deadline := time.Now().Add(2 * time.Second)
+    for !strings.Contains(read(), "last message repeated 1 times") {
+        if time.Now().After(deadline) {
+            t.Fatalf("Expected a summary after the window, got %q", read())
+        }
+        time.Sleep(5 * time.Millisecond)
+    }
+
+    // Identical entries after the summary are still suppressed
+    log.Info("Polling")
+    if n := strings.Count(read(), "] Polling"); n != 1 {
+        t.Errorf("Expected a single written entry, got %d", n)
+    }
+}
+
+func TestRepeatsFields(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel:  "info",
+        FileFormat: "json",
+        Repeats:    &logger.RepeatConfig{Window: time.Hour},
+    })
+    for _, user := range []string{"alice", "bob", "carol", "carol"} {
+        log.WithField("user", user).Warning("Login failed")
+    }
+    if err := log.Close(); err != nil {
+        t.Fatalf("Failed to close logger: %v", err)
+    }
+
+    var entries []map[string]interface{}
+    for _, line := range strings.Split(strings.TrimSpace(read()), "\n") {
+        var entry map[string]interface{}
+        if err := json.Unmarshal([]byte(line), &entry); err != nil {
+            t.Fatalf("Failed to parse entry '%s': %v", line, err)
+        }
+        entries = append(entries, entry)
+    }
+    if len(entries) != 4 {
+        t.Fatalf("Expected the entries of the three users and a summary, got %v", entries)
+    }
+    for i, user := range []string{"alice", "bob", "carol"} {
+        if entries[i]["user"] != user {
+            t.Errorf("Expected the entry of %s, got %v", user, entries[i])
+        }
+    }
+    summary := entries[3]
+    if summary["user"] != "carol" || summary[logger.RepeatedField] != float64(1) {
+        t.Errorf("Expected the summary with the fields of the repeated entry, got %v", summary)
+    }
+    if summary["file"] != entries[2]["file"] || summary["line"] != entries[2]["line"] {
+        t.Errorf("Expected the summary at the call site of the repeated entry, got %v:%v", summary["file"], summary["line"])
+    }
+}
@@ -0,0 +1,42 @@
+package logger
+
+import "fmt"
+
+// RetentionField is the name of the field holding the retention hint of an entry. Remote sinks map
+// it to retention settings of the destination, such as index lifecycle policies, so that entries with
+// different retention requirements can share one stream.
+const RetentionField = "retention"
+
+// WithRetention returns a logger based on the global logger that adds the retention hint to every entry.
+//
+// Arguments:
+//   - hint (string): Retention hint, e.g. "30d" or "audit-7y".
+//
+// Returns:
+//   - (*Logger): Logger with the retention hint.
+func WithRetention(hint string) *Logger {
+    return WithField(RetentionField, hint)
+}
+
+// WithRetention returns a copy of the logger that adds the retention hint to every entry,
+// overriding LogConfig.Retention and any hint of the logger itself.
+//
+// Arguments:
+//   - hint (string): Retention hint, e.g. "30d" or "audit-7y".
+//
+// Returns:
+//   - (*Logger): Logger with the retention hint.
+func (l *Logger) WithRetention(hint string) *Logger {
+    return l.WithField(RetentionField, hint)
+}
+
+// Retention returns the retention hint of the entry, empty if it has none.
+//
+// Returns:
+//   - (string): Retention hint.
+func (e Entry) Retention() string {
+    if hint, ok := e.Fields[RetentionField]; ok {
+        return fmt.Sprint(hint)
+    }
+    return ""
+}
@@ -0,0 +1,30 @@
+package logger_test
+
+import (
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestRetentionHints(t *testing.T) {
+    log, err := logger.NewLogger(logger.LogConfig{Retention: "30d", RingBufferSize: 10})
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    log.Info("Regular entry")
+    log.WithRetention("audit-7y").Info("Audit entry")
+
+    entries := log.RecentEntries()
+    if len(entries) != 2 {
+        t.Fatalf("Expected 2 entries, got %d", len(entries))
+    }
+    if hint := entries[0].Retention(); hint != "30d" {
+        t.Errorf("Expected the default retention hint, got %q", hint)
+    }
+    if hint := entries[1].Retention(); hint != "audit-7y" {
+        t.Errorf("Expected the per-call retention hint, got %q", hint)
+    }
+    if hint := (logger.Entry{}).Retention(); hint != "" {
+        t.Errorf("Expected no retention hint, got %q", hint)
+    }
+}
@@ -0,0 +1,126 @@
+package logger
+
+import (
+    "fmt"
+    "io"
+    "sync"
+)
+
+// ringBuffer keeps the most recent entries in memory.
+type ringBuffer struct {
+    mu      sync.Mutex
+    entries []Entry
+    next    int
+    full    bool
+    level   int // Most verbose level value recorded.
+}
+
+// newRingBuffer creates a ring buffer holding up to size entries at or above the given level.
+func newRingBuffer(size int, level int) *ringBuffer {
+    return &ringBuffer{entries: make([]Entry, size), level: level}
+}
+
+// accepts reports whether an entry at the given level is recorded by the ring buffer.
+func (r *ringBuffer) accepts(level string, msgLevel int) bool {
+    return r != nil && (level == "print" || msgLevel <= r.level)
+}
+
+// add records an entry, overwriting the oldest one when the buffer is full.
+func (r *ringBuffer) add(entry Entry) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.entries[r.next] = entry
+    r.next = (r.next + 1) % len(r.entries)
+    if r.next == 0 {
+        r.full = true
+    }
+}
+
+// snapshot returns the recorded entries, oldest first.
+func (r *ringBuffer) snapshot() []Entry {
+    if r == nil {
+        return nil
+    }
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if !r.full {
+        return append([]Entry(nil), r.entries[:r.next]...)
+    }
+    result := make([]Entry, 0, len(r.entries))
+    result = append(result, r.entries[r.next:]...)
+    return append(result, r.entries[:r.next]...)
+}
+
+// RecentEntries returns the entries kept in the ring buffer of the global logger, oldest first.
+//
+// Returns:
+//   - ([]Entry): Recent entries, nil if the ring buffer is disabled.
+func RecentEntries() []Entry {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return nil
+    }
+    return logInstance.RecentEntries()
+}
+
+// RecentEntries returns the entries kept in the ring buffer, oldest first.
+// The ring buffer is enabled with LogConfig.RingBufferSize and records entries down to
+// LogConfig.RingBufferLevel regardless of the file and console levels.
+//
+// Returns:
+//   - ([]Entry): Recent entries, nil if the ring buffer is disabled.
+func (l *Logger) RecentEntries() []Entry {
+    return l.ring.snapshot()
+}
+
+// DumpRecent writes the entries kept in the ring buffer of the global logger to w, see
+// (*Logger).DumpRecent.
+//
+// Arguments:
+//   - w (io.Writer): Destination of the entries, e.g. an HTTP response.
+//   - level (interface{}): Least severe level written: can be a Level, a string or a number.
+//
+// Returns:
+//   - error: Error if the level is invalid, the ring buffer is disabled or writing fails.
+func DumpRecent(w io.Writer, level interface{}) error {
+    l := currentLogger()
+    if l == nil {
+        return fmt.Errorf("logger is not initialized")
+    }
+    return l.DumpRecent(w, level)
+}
+
+// DumpRecent writes the entries kept in the ring buffer at the level or more severe to w, oldest
+// first, in the format of the file output. Since the ring buffer records entries regardless of the
+// levels of the outputs, an operator can grab the recent TRACE entries from a debug endpoint while
+// the outputs only store INFO and above:
+//
+//	http.HandleFunc("/debug/logs", func(w http.ResponseWriter, r *http.Request) {
+//	    log.DumpRecent(w, "trace")
+//	})
+//
+// Arguments:
+//   - w (io.Writer): Destination of the entries.
+//   - level (interface{}): Least severe level written: can be a Level, a string or a number.
+//
+// Returns:
+//   - error: Error if the level is invalid, the ring buffer is disabled or writing fails.
+func (l *Logger) DumpRecent(w io.Writer, level interface{}) error {
+    maxLevel, err := l.parseLevel(level)
+    if err != nil {
+        return err
+    }
+    if l.ring == nil {
+        return fmt.Errorf("ring buffer is disabled, see LogConfig.RingBufferSize")
+    }
+    format := l.fileFormat()
+    var buf []byte
+    for _, e := range l.ring.snapshot() {
+        if value, ok := l.LogLevelMap[e.Level]; ok && value > maxLevel {
+            continue
+        }
+        buf = append(e.appendFormat(buf, format, nil), '\n')
+    }
+    _, err = w.Write(buf)
+    return err
+}
@@ -0,0 +1,46 @@
+package logger_test
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestDumpRecent(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info", RingBufferSize: 10})
+
+    log.Trace("Trace detail")
+    log.Debug("Debug detail")
+    log.Info("Request served")
+
+    var dump bytes.Buffer
+    if err := log.DumpRecent(&dump, "debug"); err != nil {
+        t.Fatalf("Failed to dump recent entries: %v", err)
+    }
+    output := dump.String()
+    if strings.Contains(output, "Trace detail") {
+        t.Errorf("Expected TRACE entries to be filtered out, got %q", output)
+    }
+    debug, info := strings.Index(output, "[DEBUG] Debug detail"), strings.Index(output, "[INFO] Request served")
+    if debug < 0 || info < debug {
+        t.Errorf("Expected the DEBUG and INFO entries oldest first, got %q", output)
+    }
+    if strings.Contains(read(), "Debug detail") {
+        t.Errorf("Expected the file output to keep only INFO entries")
+    }
+
+    dump.Reset()
+    if err := log.DumpRecent(&dump, logger.TraceLevel); err != nil || !strings.Contains(dump.String(), "Trace detail") {
+        t.Errorf("Expected TRACE entries in the dump, got %q, %v", dump.String(), err)
+    }
+    if err := log.DumpRecent(&dump, "verbose"); err == nil {
+        t.Errorf("Expected an error for an invalid level")
+    }
+
+    plain, _ := newFileLogger(t, logger.LogConfig{FileLevel: "info"})
+    if err := plain.DumpRecent(&dump, "trace"); err == nil {
+        t.Errorf("Expected an error without a ring buffer")
+    }
+}
@@ -0,0 +1,394 @@
+package logger
+
+import (
+    "compress/gzip"
+    "fmt"
+    "io"
+    "os"
+    "path/filepath"
+    "sort"
+    "strings"
+    "sync"
+    "time"
+)
+
+// backupTimeFormat is the timestamp format of rotated backups, compatible with lumberjack.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotatorFunc creates the writer of a log file with rotation, replacing the built-in rotation.
+// It allows plugging in a third-party rotation package without making it a dependency of the logger,
+// for example lumberjack:
+//
+//	config.FileSink.Rotator = func(path string, rc logger.RotationConfig) (io.WriteCloser, error) {
+//	    return &lumberjack.Logger{Filename: path, MaxSize: rc.MaxSize, MaxBackups: rc.MaxBackups,
+//	        MaxAge: rc.MaxAge, Compress: rc.Compress}, nil
+//	}
+//
+// A writer implementing RotatingWriter can also be rotated on demand, see (*Logger).Rotate.
+type RotatorFunc func(path string, rc RotationConfig) (io.WriteCloser, error)
+
+// RotatingWriter is a rotation backend of log files, such as the built-in RotatingFile or a writer
+// returned by a RotatorFunc, whose rotation can be forced with Rotate. lumberjack.Logger implements it.
+// Writers of a RotatorFunc without a Rotate method still rotate by themselves, but are not rotated by
+// (*Logger).Rotate and not returned by (*Logger).RotatingWriters.
+type RotatingWriter interface {
+    io.WriteCloser
+    // Rotate moves the current file to a backup and starts a new file.
+    Rotate() error
+}
+
+var _ RotatingWriter = (*RotatingFile)(nil)
+
+// RotationHooks drive the built-in rotation deterministically, so that rotation tests do not need to
+// write megabytes of data and sleep while backups are cleaned up in the background:
+//
+//	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+//	config.FileSink.RotationHooks = logger.RotationHooks{
+//	    Now:          func() time.Time { now = now.Add(time.Second); return now },
+//	    MaxSizeBytes: 1024,
+//	    Synchronous:  true,
+//	}
+//
+// The zero value keeps the default behavior.
+type RotationHooks struct {
+    Now          func() time.Time    // Clock naming backups and applying MaxAge, time.Now if nil.
+    MaxSizeBytes int64               // Maximum file size in bytes, overriding RotationConfig.MaxSize if positive.
+    Synchronous  bool                // Whether backups are removed and compressed before the rotating call returns.
+    OnRotate     func(backup string) // Called after each rotation with the path of the new backup.
+}
+
+// RotatingFile is the built-in size-based rotation of log files. When a write would grow the file
+// beyond RotationConfig.MaxSize megabytes, the file is renamed to a backup named like
+// "app-2006-01-02T15-04-05.000.log" (UTC time of the rotation) and a new file is started.
+// Backups beyond MaxBackups or older than MaxAge days are removed, and the rest compressed with
+// gzip if Compress is set, in the background. Backups are compatible with lumberjack.
+// With RotationConfig.Interval the file is also rotated on the first write after each interval;
+// with AlignInterval the intervals start at midnight in RotationConfig.TimeZone, so that an hourly
+// rotation happens at the top of every hour on all replicas.
+// It is used by the file output when rotation is enabled, and can be used directly as an io.WriteCloser.
+// It is safe for concurrent use.
+type RotatingFile struct {
+    mu    sync.Mutex
+    path  string
+    rc    RotationConfig
+    hooks RotationHooks
+    file  *os.File
+    size  int64
+    last  time.Time // Time of the last rotation.
+    next  time.Time // Time of the next interval rotation, zero until the first write.
+    loc   *time.Location
+
+    millMu sync.Mutex // Serializes cleanup of backups.
+}
+
+// NewRotatingFile returns a rotating writer of the log file at path, opened on first write.
+//
+// Arguments:
+//   - path (string): Path of the log file.
+//   - rc (RotationConfig): Rotation settings.
+//   - hooks (RotationHooks): Hooks driving the rotation, the zero value for the default behavior.
+//
+// Returns:
+//   - (*RotatingFile): Rotating writer.
+func NewRotatingFile(path string, rc RotationConfig, hooks RotationHooks) *RotatingFile {
+    loc, err := rc.location()
+    if err != nil {
+        loc = time.UTC
+    }
+    return &RotatingFile{path: path, rc: rc, hooks: hooks, loc: loc}
+}
+
+// location returns the time zone of the interval boundaries.
+func (rc RotationConfig) location() (*time.Location, error) {
+    if rc.TimeZone == "" {
+        return time.UTC, nil
+    }
+    return time.LoadLocation(rc.TimeZone)
+}
+
+// nextRotation returns the time of the interval rotation following t: t plus the interval, or
+// with alignment the next multiple of the interval since midnight in the time zone. Intervals longer
+// than a day are counted from the first midnight of 1970 so that they line up across restarts.
+func (r *RotatingFile) nextRotation(t time.Time) time.Time {
+    interval := r.rc.Interval
+    if !r.rc.AlignInterval {
+        return t.Add(interval)
+    }
+    local := t.In(r.loc)
+    base := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, r.loc)
+    if interval > 24*time.Hour {
+        base = time.Date(1970, 1, 1, 0, 0, 0, 0, r.loc)
+    }
+    return base.Add((t.Sub(base)/interval + 1) * interval)
+}
+
+// lastRotation returns the time of the last rotation, zero if none.
+func (r *RotatingFile) lastRotation() time.Time {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    return r.last
+}
+
+// now returns the current time of the rotation clock.
+func (r *RotatingFile) now() time.Time {
+    if r.hooks.Now != nil {
+        return r.hooks.Now()
+    }
+    return time.Now()
+}
+
+// maxSize returns the maximum file size in bytes.
+func (r *RotatingFile) maxSize() int64 {
+    if r.hooks.MaxSizeBytes > 0 {
+        return r.hooks.MaxSizeBytes
+    }
+    if r.rc.MaxSize <= 0 {
+        return 100 * 1024 * 1024
+    }
+    return int64(r.rc.MaxSize) * 1024 * 1024
+}
+
+// maxWrite returns the size of the largest write accepted by the rotated log files of the
+// configuration, which reject writes larger than the maximum file size, 0 without rotation.
+func (c LogConfig) maxWrite() int64 {
+    if !c.EnableRotation {
+        return 0
+    }
+    return NewRotatingFile("", c.RotationConfig, c.FileSink.RotationHooks).maxSize()
+}
+
+// Write writes p to the file, rotating it first if it would grow beyond the maximum size or the
+// rotation interval has elapsed.
+//
+// Arguments:
+//   - p ([]byte): Data to write.
+//
+// Returns:
+//   - (int): Number of bytes written.
+//   - error: Error if p exceeds the maximum size or the file cannot be rotated or written.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    length := int64(len(p))
+    if length > r.maxSize() {
+        return 0, fmt.Errorf("write length %d exceeds maximum file size %d", length, r.maxSize())
+    }
+    if r.file == nil {
+        if err := r.openExisting(length); err != nil {
+            return 0, err
+        }
+    }
+    rotate := r.size+length > r.maxSize()
+    if r.rc.Interval > 0 {
+        now := r.now()
+        if r.next.IsZero() {
+            r.next = r.nextRotation(now)
+        } else if !now.Before(r.next) {
+            rotate = true
+            r.next = r.nextRotation(now)
+        }
+    }
+    if rotate {
+        if err := r.rotate(); err != nil {
+            return 0, err
+        }
+    }
+    n, err := r.file.Write(p)
+    r.size += int64(n)
+    return n, err
+}
+
+// Rotate moves the current file to a backup and starts a new file, regardless of its size.
+//
+// Returns:
+//   - error: Error if the file cannot be rotated.
+func (r *RotatingFile) Rotate() error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    return r.rotate()
+}
+
+// Sync flushes the current file to stable storage.
+//
+// Returns:
+//   - error: Error syncing the file.
+func (r *RotatingFile) Sync() error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if r.file == nil {
+        return nil
+    }
+    return r.file.Sync()
+}
+
+// Close closes the current file. A later write opens it again.
+//
+// Returns:
+//   - error: Error closing the file.
+func (r *RotatingFile) Close() error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    return r.close()
+}
+
+// close closes the current file. It must be called with r.mu held.
+func (r *RotatingFile) close() error {
+    if r.file == nil {
+        return nil
+    }
+    err := r.file.Close()
+    r.file = nil
+    return err
+}
+
+// openExisting opens the log file for appending, or rotates it if the write would not fit.
+func (r *RotatingFile) openExisting(length int64) error {
+    info, err := os.Stat(r.path)
+    if os.IsNotExist(err) {
+        return r.openNew()
+    } else if err != nil {
+        return fmt.Errorf("failed to stat log file: %v", err)
+    }
+    if info.Size()+length >= r.maxSize() {
+        return r.rotate()
+    }
+    file, err := os.OpenFile(r.path, os.O_APPEND|os.O_WRONLY, 0644)
+    if err != nil {
+        return r.openNew()
+    }
+    r.file = file
+    r.size = info.Size()
+    return nil
+}
+
+// rotate closes the current file, moves it to a backup and starts a new file.
+// It must be called with r.mu held.
+func (r *RotatingFile) rotate() error {
+    if err := r.close(); err != nil {
+        return err
+    }
+    if err := r.openNew(); err != nil {
+        return err
+    }
+    if r.hooks.Synchronous {
+        r.mill()
+    } else {
+        go r.mill()
+    }
+    return nil
+}
+
+// openNew moves an existing log file to a backup, keeping its mode for the new file, and creates the new file.
+func (r *RotatingFile) openNew() error {
+    if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+        return fmt.Errorf("failed to create log directory: %v", err)
+    }
+    mode := os.FileMode(0600)
+    if info, err := os.Stat(r.path); err == nil {
+        mode = info.Mode()
+        now := r.now()
+        backup := r.backupName(now)
+        if err := os.Rename(r.path, backup); err != nil {
+            return fmt.Errorf("failed to rotate log file: %v", err)
+        }
+        r.last = now
+        if r.hooks.OnRotate != nil {
+            defer r.hooks.OnRotate(backup)
+        }
+    }
+    file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+    if err != nil {
+        return fmt.Errorf("failed to open log file: %v", err)
+    }
+    r.file = file
+    r.size = 0
+    return nil
+}
+
+// backupName returns the name of a backup rotated at t.
+func (r *RotatingFile) backupName(t time.Time) string {
+    ext := filepath.Ext(r.path)
+    prefix := strings.TrimSuffix(r.path, ext)
+    return fmt.Sprintf("%s-%s%s", prefix, t.UTC().Format(backupTimeFormat), ext)
+}
+
+// backup is a rotated backup of the log file.
+type backup struct {
+    path string
+    time time.Time
+}
+
+// backups returns the backups of the log file, newest first.
+func (r *RotatingFile) backups() []backup {
+    ext := filepath.Ext(r.path)
+    prefix := strings.TrimSuffix(filepath.Base(r.path), ext) + "-"
+    var result []backup
+    for _, path := range rotatedFiles(r.path) {
+        name := strings.TrimSuffix(filepath.Base(path), ".gz")
+        stamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+        t, err := time.Parse(backupTimeFormat, stamp)
+        if err != nil {
+            continue
+        }
+        result = append(result, backup{path: path, time: t})
+    }
+    sort.Slice(result, func(i, j int) bool { return result[i].time.After(result[j].time) })
+    return result
+}
+
+// mill removes backups beyond MaxBackups or older than MaxAge days and compresses the rest if enabled.
+func (r *RotatingFile) mill() {
+    r.millMu.Lock()
+    defer r.millMu.Unlock()
+
+    cutoff := r.now().AddDate(0, 0, -r.rc.MaxAge)
+    kept := map[string]bool{}
+    for _, b := range r.backups() {
+        name := strings.TrimSuffix(b.path, ".gz")
+        _, seen := kept[name]
+        tooMany := !seen && r.rc.MaxBackups > 0 && len(kept) >= r.rc.MaxBackups
+        if tooMany || (r.rc.MaxAge > 0 && b.time.Before(cutoff)) {
+            os.Remove(b.path)
+            continue
+        }
+        kept[name] = true
+        if r.rc.Compress && !strings.HasSuffix(b.path, ".gz") {
+            if err := compressFile(b.path); err != nil {
+                reportError("rotation", err)
+            }
+        }
+    }
+}
+
+// compressFile compresses the file with gzip into path.gz, keeping its mode, and removes the original.
+func compressFile(path string) error {
+    src, err := os.Open(path)
+    if err != nil {
+        return err
+    }
+    defer src.Close()
+    info, err := src.Stat()
+    if err != nil {
+        return err
+    }
+    dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode())
+    if err != nil {
+        return err
+    }
+    zw := gzip.NewWriter(dst)
+    if _, err := io.Copy(zw, src); err != nil {
+        dst.Close()
+        os.Remove(path + ".gz")
+        return err
+    }
+    if err := zw.Close(); err != nil {
+        dst.Close()
+        os.Remove(path + ".gz")
+        return err
+    }
+    if err := dst.Close(); err != nil {
+        return err
+    }
+    src.Close()
+    return os.Remove(path)
+}
@@ -0,0 +1,274 @@
+package logger_test
+
+import (
+    "bytes"
+    "io"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+// newRotationClock returns a clock advancing by a second on every call, for distinct backup names.
+func newRotationClock() func() time.Time {
+    now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    return func() time.Time {
+        now = now.Add(time.Second)
+        return now
+    }
+}
+
+func TestRotationMaxBackups(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "app.log")
+    var rotations []string
+    log, err := logger.NewLogger(logger.LogConfig{
+        FilePath:       path,
+        FileLevel:      "info",
+        EnableRotation: true,
+        RotationConfig: logger.RotationConfig{MaxBackups: 1},
+        FileSink: logger.FileSinkConfig{
+            RotationHooks: logger.RotationHooks{
+                Now:          newRotationClock(),
+                MaxSizeBytes: 1024,
+                Synchronous:  true,
+                OnRotate:     func(backup string) { rotations = append(rotations, filepath.Base(backup)) },
+            },
+        },
+    })
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+
+    message := strings.Repeat("A", 300)
+    for i := 0; i < 10; i++ {
+        log.Info(message)
+    }
+
+    // Two entries fit in the file
+    if len(rotations) != 4 {
+        t.Fatalf("Expected 4 rotations, got %v", rotations)
+    }
+    backups, _ := filepath.Glob(filepath.Join(filepath.Dir(path), "app-*.log"))
+    if len(backups) != 1 || filepath.Base(backups[0]) != rotations[3] {
+        t.Errorf("Expected only the newest backup, got %v", backups)
+    }
+    info, err := os.Stat(path)
+    if err != nil || info.Size() > 1024 {
+        t.Errorf("Expected the current file to stay below the maximum size, got %v, %v", info, err)
+    }
+}
+
+func TestRotatingFileRotate(t *testing.T) {
+    dir := t.TempDir()
+    path := filepath.Join(dir, "app.log")
+    clock := newRotationClock()
+    r := logger.NewRotatingFile(path, logger.RotationConfig{MaxAge: 1, Compress: true}, logger.RotationHooks{
+        Now:         func() time.Time { return clock().Add(-72 * time.Hour) },
+        Synchronous: true,
+    })
+    defer r.Close()
+
+    r.Write([]byte("first\n"))
+    if err := r.Rotate(); err != nil {
+        t.Fatalf("Failed to rotate: %v", err)
+    }
+    if _, err := os.Stat(filepath.Join(dir, "app-2023-12-29T00-00-01.000.log.gz")); err != nil {
+        t.Errorf("Expected a compressed backup, got %v", err)
+    }
+
+    // Backups older than MaxAge days by the injected clock are removed on the next rotation
+    clock = func() time.Time { return time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC) }
+    r.Write([]byte("second\n"))
+    if err := r.Rotate(); err != nil {
+        t.Fatalf("Failed to rotate: %v", err)
+    }
+    backups, _ := filepath.Glob(filepath.Join(dir, "app-*"))
+    if len(backups) != 1 || filepath.Base(backups[0]) != "app-2024-01-02T00-00-00.000.log.gz" {
+        t.Errorf("Expected only the new backup, got %v", backups)
+    }
+}
+
+func TestRotatingFileInterval(t *testing.T) {
+    tests := []struct {
+        name     string
+        rc       logger.RotationConfig
+        expected []string
+    }{
+        {
+            name:     "Unaligned",
+            rc:       logger.RotationConfig{Interval: time.Hour},
+            expected: []string{"app-2024-01-01T11-10-00.000.log"},
+        },
+        {
+            // Boundaries at the top of the hour in +05:30, i.e. at half past in UTC
+            name:     "Aligned",
+            rc:       logger.RotationConfig{Interval: time.Hour, AlignInterval: true, TimeZone: "Asia/Kolkata"},
+            expected: []string{"app-2024-01-01T10-31-00.000.log", "app-2024-01-01T11-30-00.000.log"},
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if _, err := time.LoadLocation("Asia/Kolkata"); err != nil {
+                t.Skip("time zone database not available")
+            }
+            dir := t.TempDir()
+            var now time.Time
+            r := logger.NewRotatingFile(filepath.Join(dir, "app.log"), tt.rc, logger.RotationHooks{
+                Now:         func() time.Time { return now },
+                Synchronous: true,
+            })
+            defer r.Close()
+
+            for _, minutes := range []int{10, 20, 31, 40, 70, 89, 90} {
+                now = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC).Add(time.Duration(minutes) * time.Minute)
+                if _, err := r.Write([]byte("entry\n")); err != nil {
+                    t.Fatalf("Failed to write: %v", err)
+                }
+            }
+            backups, _ := filepath.Glob(filepath.Join(dir, "app-*"))
+            var names []string
+            for _, backup := range backups {
+                names = append(names, filepath.Base(backup))
+            }
+            if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+                t.Errorf("Expected backups %v, got %v", tt.expected, names)
+            }
+        })
+    }
+}
+
+type nopCloser struct {
+    io.Writer
+}
+
+func (nopCloser) Close() error {
+    return nil
+}
+
+func TestCustomRotator(t *testing.T) {
+    var buf bytes.Buffer
+    var rotated string
+    log, err := logger.NewLogger(logger.LogConfig{
+        FilePath:       filepath.Join(t.TempDir(), "app.log"),
+        FileLevel:      "info",
+        EnableRotation: true,
+        FileSink: logger.FileSinkConfig{
+            Rotator: func(path string, rc logger.RotationConfig) (io.WriteCloser, error) {
+                rotated = path
+                return nopCloser{&buf}, nil
+            },
+        },
+    })
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    log.Info("Through custom rotator")
+
+    if !strings.HasSuffix(rotated, "app.log") || !strings.Contains(buf.String(), "Through custom rotator") {
+        t.Errorf("Expected the entry to go through the custom rotator, got '%s'", buf.String())
+    }
+}
+
+// countingRotator is a rotation backend counting forced rotations.
+type countingRotator struct {
+    nopCloser
+    rotations int
+}
+
+func (r *countingRotator) Rotate() error {
+    r.rotations++
+    return nil
+}
+
+func TestCustomRotatingWriter(t *testing.T) {
+    backend := &countingRotator{nopCloser: nopCloser{io.Discard}}
+    log, err := logger.NewLogger(logger.LogConfig{
+        FilePath:       filepath.Join(t.TempDir(), "app.log"),
+        EnableRotation: true,
+        FileSink: logger.FileSinkConfig{
+            Rotator: func(path string, rc logger.RotationConfig) (io.WriteCloser, error) {
+                return backend, nil
+            },
+        },
+    })
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    if err := log.Rotate(); err != nil || backend.rotations != 1 {
+        t.Errorf("Expected Rotate to rotate the custom backend, got %d rotations, %v", backend.rotations, err)
+    }
+    if writers := log.RotatingWriters(); len(writers) != 1 || writers[0] != backend {
+        t.Errorf("Expected the custom backend, got %v", writers)
+    }
+}
+
+func TestBuiltinRotatingWriters(t *testing.T) {
+    log, err := logger.NewLogger(logger.LogConfig{FilePath: filepath.Join(t.TempDir(), "app.log"), EnableRotation: true})
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    defer log.Close()
+    writers := log.RotatingWriters()
+    if len(writers) != 1 {
+        t.Fatalf("Expected one rotating writer, got %v", writers)
+    }
+    if _, ok := writers[0].(*logger.RotatingFile); !ok {
+        t.Errorf("Expected the built-in RotatingFile, got %T", writers[0])
+    }
+}
+
+func TestLoggerRotate(t *testing.T) {
+    dir := t.TempDir()
+    path := filepath.Join(dir, "app.log")
+    log, err := logger.NewLogger(logger.LogConfig{
+        FilePath:       path,
+        FileLevel:      "info",
+        EnableRotation: true,
+        RotationConfig: logger.RotationConfig{MaxSize: 1, MaxBackups: 2},
+        FileSink: logger.FileSinkConfig{
+            RotationHooks: logger.RotationHooks{Now: newRotationClock(), Synchronous: true},
+        },
+    })
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    defer log.Close()
+
+    log.Info("Before rotation")
+    if err := log.Rotate(); err != nil {
+        t.Fatalf("Failed to rotate: %v", err)
+    }
+    log.Info("After rotation")
+
+    backups, _ := filepath.Glob(filepath.Join(dir, "app-*"))
+    if len(backups) != 1 {
+        t.Fatalf("Expected one backup, got %v", backups)
+    }
+    rotated, _ := os.ReadFile(backups[0])
+    current, _ := os.ReadFile(path)
+    if !strings.Contains(string(rotated), "Before rotation") || strings.Contains(string(current), "Before rotation") ||
+        !strings.Contains(string(current), "After rotation") {
+        t.Errorf("Unexpected files after rotation: '%s' and '%s'", rotated, current)
+    }
+}
+
+func TestLoggerRotateDisabled(t *testing.T) {
+    log, _ := newFileLogger(t, logger.LogConfig{FileLevel: "info"})
+    if err := log.Rotate(); err == nil || !strings.Contains(err.Error(), "rotation is not enabled") {
+        t.Errorf("Expected an error without rotation, got %v", err)
+    }
+}
+
+func TestRotationInvalidTimeZone(t *testing.T) {
+    _, err := logger.NewLogger(logger.LogConfig{
+        FilePath:       filepath.Join(t.TempDir(), "app.log"),
+        EnableRotation: true,
+        RotationConfig: logger.RotationConfig{Interval: time.Hour, AlignInterval: true, TimeZone: "Mars/Olympus"},
+    })
+    if err == nil || !strings.Contains(err.Error(), "invalid rotation time zone") {
+        t.Errorf("Expected an invalid time zone error, got %v", err)
+    }
+}
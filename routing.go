@@ -0,0 +1,87 @@
+package logger
+
+import (
+    "fmt"
+    "strings"
+)
+
+// ComponentField is the name of the field holding the component of an entry, matched by RouteRule.
+const ComponentField = "component"
+
+// RouteRule directs the entries it matches to a set of sinks, for example security events to
+// a SIEM sink only. Rules are evaluated in order for every entry and the first matching rule decides
+// the outputs of the entry; the levels of the outputs still apply. An entry matched by no rule is
+// written to the file, console and syslog outputs and to the sinks with SinkConfig.Default set.
+type RouteRule struct {
+    Level     string   // Least severe level matched, e.g. "warning" for warnings, errors and fatal entries; empty for any level.
+    Component string   // Value of the "component" field matched, empty for any component.
+    Condition string   // Field condition as in EscalationRule, e.g. "category==security"; empty for any fields.
+    Sinks     []string // Names of the sinks of the matching entries, e.g. "file", "console", "syslog" or a configured sink.
+}
+
+// route is the set of sinks an entry is written to.
+type route struct {
+    sinks []int // Indexes of the sinks in Logger.sinks.
+}
+
+// routeRule is a compiled RouteRule.
+type routeRule struct {
+    slot      int // Least severe level slot matched, -1 for any level.
+    component string
+    condition condition
+    route     *route
+}
+
+// compileRoutes validates the routing rules against the sinks of the logger and compiles them.
+func (l *Logger) compileRoutes(rules []RouteRule) error {
+    for i, rule := range rules {
+        r := routeRule{slot: -1, component: rule.Component, route: &route{}}
+        if rule.Level != "" {
+            slot, ok := l.LogLevelMap[strings.ToLower(rule.Level)]
+            if !ok {
+                return fmt.Errorf("route %d: invalid level %q", i+1, rule.Level)
+            }
+            r.slot = slot
+        }
+        if rule.Condition != "" {
+            c, err := parseCondition(rule.Condition)
+            if err != nil {
+                return fmt.Errorf("route %d: %v", i+1, err)
+            }
+            r.condition = c
+        }
+        if len(rule.Sinks) == 0 {
+            return fmt.Errorf("route %d: at least one sink is required", i+1)
+        }
+        for _, name := range rule.Sinks {
+            index := l.sinkIndex(name)
+            if index >= 0 {
+                r.route.sinks = append(r.route.sinks, index)
+            } else if name != destinationFile && name != destinationConsole && name != syslogSinkName &&
+                name != etwSinkName && name != gelfSinkName && name != lokiSinkName {
+                // The built-in outputs may be disabled
+                return fmt.Errorf("route %d: unknown sink %q", i+1, name)
+            }
+        }
+        l.routes = append(l.routes, r)
+    }
+    return nil
+}
+
+// routeOf returns the sinks of an entry: those of the first matching routing rule,
+// or the default route.
+func (l *Logger) routeOf(level string, msgLevel int, fields Fields) *route {
+    for _, r := range l.routes {
+        if r.slot >= 0 && (level == "print" || msgLevel > r.slot) {
+            continue
+        }
+        if r.component != "" && fmt.Sprint(fields[ComponentField]) != r.component {
+            continue
+        }
+        if !r.condition.matches(fields) {
+            continue
+        }
+        return r.route
+    }
+    return l.defaultRoute
+}
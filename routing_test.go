@@ -0,0 +1,87 @@
+package logger_test
+
+import (
+    "bytes"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestRouting(t *testing.T) {
+    var siem, alerts bytes.Buffer
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel:       "info",
+        Format:          "json",
+        StackTraceLevel: logger.StackTraceNone,
+        Sinks: []logger.SinkConfig{
+            {Name: "siem", Writer: &siem, Level: "info"},
+            {Name: "alerts", Writer: &alerts, Level: "error", Format: "standard", Default: true},
+        },
+        Routes: []logger.RouteRule{
+            {Component: "auth", Condition: "event==login_failed", Sinks: []string{"siem"}},
+            {Level: "error", Component: "db", Sinks: []string{"file", "alerts"}},
+        },
+    })
+
+    auth := log.WithField("component", "auth")
+    auth.WithField("event", "login_failed").Warning("Invalid password")
+    auth.Info("Login succeeded")
+    log.WithField("component", "db").Error("Connection lost")
+    log.Error("Disk full")
+
+    file := read()
+    if strings.Contains(file, "Invalid password") {
+        t.Errorf("Expected the security event to reach the SIEM sink only, got '%s'", file)
+    }
+    for _, msg := range []string{"Login succeeded", "Connection lost", "Disk full"} {
+        if !strings.Contains(file, msg) {
+            t.Errorf("Expected '%s' in the file, got '%s'", msg, file)
+        }
+    }
+    if got := siem.String(); !strings.Contains(got, `"message":"Invalid password"`) || strings.Count(got, "\n") != 1 {
+        t.Errorf("Unexpected SIEM output: '%s'", got)
+    }
+    if got := alerts.String(); !strings.Contains(got, "[ERROR] Connection lost") || !strings.Contains(got, "[ERROR] Disk full") || strings.Count(got, "\n") != 2 {
+        t.Errorf("Unexpected alerts output: '%s'", got)
+    }
+}
+
+func TestRoutingPathSink(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "audit.log")
+    log, err := logger.NewLogger(logger.LogConfig{
+        ConsoleLevel: "fatal",
+        Sinks:        []logger.SinkConfig{{Name: "audit", Path: path, Level: "debug", Default: true}},
+    })
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    log.Debug("Audited")
+    if err := log.Close(); err != nil {
+        t.Fatalf("Failed to close logger: %v", err)
+    }
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatalf("Failed to read sink file: %v", err)
+    }
+    if !strings.Contains(string(data), "[DEBUG] Audited") {
+        t.Errorf("Expected the entry in the sink file, got '%s'", data)
+    }
+}
+
+func TestRoutingInvalid(t *testing.T) {
+    configs := []logger.LogConfig{
+        {Sinks: []logger.SinkConfig{{Name: "file", Writer: &bytes.Buffer{}}}},
+        {Sinks: []logger.SinkConfig{{Name: "siem"}}},
+        {Routes: []logger.RouteRule{{Sinks: []string{"missing"}}}},
+        {Routes: []logger.RouteRule{{Level: "loud", Sinks: []string{"file"}}}},
+        {Routes: []logger.RouteRule{{Condition: "event", Sinks: []string{"file"}}}},
+    }
+    for i, config := range configs {
+        if _, err := logger.NewLogger(config); err == nil {
+            t.Errorf("Expected config %d to be rejected", i+1)
+        }
+    }
+}
@@ -0,0 +1,103 @@
+package logger
+
+import (
+    "context"
+    "time"
+)
+
+// RPC tracks a remote procedure call started with StartRPC. Entries logged through the call carry
+// its method and peer in the "rpc_method" and "peer" fields.
+//
+// gRPC server interceptors built on StartRPC are provided by the github.com/nir0k/logger/grpclog module.
+type RPC struct {
+    *Logger
+    start time.Time
+}
+
+// loggerKey is the context key of the logger of a call.
+type loggerKey struct{}
+
+// StartRPC starts a call on the global logger, see (*Logger).StartRPC.
+//
+// Arguments:
+//   - ctx (context.Context): Context of the call.
+//   - method (string): Full method name, e.g. "/pkg.Service/Method".
+//   - peer (string): Address of the peer, empty if unknown.
+//
+// Returns:
+//   - (context.Context): Context carrying the logger of the call.
+//   - (*RPC): Call handle, nil if the logger is not initialized.
+func StartRPC(ctx context.Context, method, peer string) (context.Context, *RPC) {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return ctx, nil
+    }
+    return logInstance.StartRPC(ctx, method, peer)
+}
+
+// StartRPC starts a call, for use in RPC server interceptors. The returned context carries the
+// logger of the call for handlers, see FromContext. Call End on the returned handle when the call
+// completes to log a summary entry with the method, status code, duration and peer.
+//
+// Arguments:
+//   - ctx (context.Context): Context of the call.
+//   - method (string): Full method name, e.g. "/pkg.Service/Method".
+//   - peer (string): Address of the peer, empty if unknown.
+//
+// Returns:
+//   - (context.Context): Context carrying the logger of the call.
+//   - (*RPC): Call handle.
+func (l *Logger) StartRPC(ctx context.Context, method, peer string) (context.Context, *RPC) {
+    fields := Fields{"rpc_method": method}
+    if peer != "" {
+        fields["peer"] = peer
+    }
+    call := &RPC{Logger: l.WithFields(fields), start: time.Now()}
+    return ContextWithLogger(ctx, call.Logger), call
+}
+
+// End logs the summary entry of the call with its status code and duration: at the INFO level on
+// success, or at the ERROR level with the error if err is not nil. End is a no-op on a nil RPC.
+//
+// Arguments:
+//   - code (string): Status code of the call, e.g. "OK" or "NotFound".
+//   - err (error): Error the call failed with, or nil on success.
+func (r *RPC) End(code string, err error) {
+    if r == nil {
+        return
+    }
+    fields := Fields{"code": code, "duration": time.Since(r.start).String()}
+    level := "info"
+    if err != nil {
+        fields["error"] = err.Error()
+        level = "error"
+    }
+    r.WithFields(fields).logSkip(2, level, "RPC finished")
+}
+
+// ContextWithLogger returns a copy of the context carrying the logger.
+//
+// Arguments:
+//   - ctx (context.Context): Parent context.
+//   - l (*Logger): Logger to carry.
+//
+// Returns:
+//   - (context.Context): Context with the logger.
+func ContextWithLogger(ctx context.Context, l *Logger) context.Context {
+    return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// FromContext returns the logger carried by the context, for example the logger of the current
+// call started with StartRPC, or the global logger if the context has none.
+//
+// Arguments:
+//   - ctx (context.Context): Context.
+//
+// Returns:
+//   - (*Logger): Logger of the context.
+func FromContext(ctx context.Context) *Logger {
+    if l, ok := ctx.Value(loggerKey{}).(*Logger); ok && l != nil {
+        return l
+    }
+    return currentLogger()
+}
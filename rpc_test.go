@@ -0,0 +1,55 @@
+package logger_test
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestStartRPC(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{Format: "json", FileLevel: "info"})
+
+    ctx, call := log.StartRPC(context.Background(), "/users.Users/Get", "10.0.0.7:51234")
+    logger.FromContext(ctx).Info("Loading user")
+    call.End("NotFound", errors.New("user not found"))
+
+    lines := strings.Split(strings.TrimSpace(read()), "\n")
+    if len(lines) != 2 {
+        t.Fatalf("Expected 2 entries, got %d: %v", len(lines), lines)
+    }
+    for _, line := range lines {
+        var entry map[string]interface{}
+        if err := json.Unmarshal([]byte(line), &entry); err != nil {
+            t.Fatalf("Failed to parse entry '%s': %v", line, err)
+        }
+        if entry["rpc_method"] != "/users.Users/Get" || entry["peer"] != "10.0.0.7:51234" {
+            t.Errorf("Expected call fields in entry, got '%s'", line)
+        }
+    }
+
+    var summary map[string]interface{}
+    json.Unmarshal([]byte(lines[1]), &summary)
+    if summary["level"] != "error" || summary["code"] != "NotFound" || summary["error"] != "user not found" {
+        t.Errorf("Unexpected summary entry: %s", lines[1])
+    }
+    if _, ok := summary["duration"]; !ok {
+        t.Errorf("Expected duration in summary entry: %s", lines[1])
+    }
+}
+
+func TestFromContextDefault(t *testing.T) {
+    resetLogger()
+    defer resetLogger()
+    if err := logger.InitLogger(logger.LogConfig{}); err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+    if l := logger.FromContext(context.Background()); l == nil || l.Name() != "" {
+        t.Errorf("Expected the global logger for a context without logger")
+    }
+    var call *logger.RPC
+    call.End("OK", nil) // No-op on a nil call
+}
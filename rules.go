@@ -0,0 +1,134 @@
+package logger
+
+import (
+    "fmt"
+    "regexp"
+    "strings"
+    "sync"
+    "time"
+)
+
+// Rule triggers an action in-process when a matching entry is logged, for self-healing automation
+// driven by log events such as resetting a connection pool or flipping a feature flag:
+//
+//	logger.AddRule(logger.Rule{
+//	    Level:      "error",
+//	    Conditions: []string{"component==payments"},
+//	    Cooldown:   time.Minute,
+//	    Action:     func(e logger.Entry) { pool.Reset() },
+//	})
+//
+// An entry matches when it is at the level or more severe, satisfies all the conditions and matches
+// the pattern. Actions run on their own goroutine so that they never slow down logging and may log
+// themselves; matches are ignored while the action of the rule is running or within the cooldown.
+type Rule struct {
+    Level      string        // Least severe level of the matching entries, e.g. "error"; empty for all levels.
+    Conditions []string      // Field conditions that must all hold, as "field<op>value" like in EscalationRule, e.g. "component==payments".
+    Pattern    string        // Regular expression matched against the message, empty to match any message.
+    Cooldown   time.Duration // Minimum time between two runs of the action, 0 for none.
+    Action     func(Entry)   `json:"-"` // Function called with the matching entry.
+}
+
+// rule is a compiled Rule with the state of its action.
+type rule struct {
+    conditions []condition
+    pattern    *regexp.Regexp
+    cooldown   time.Duration
+    action     func(Entry)
+
+    mu      sync.Mutex
+    running bool      // Whether the action is running.
+    last    time.Time // Time the action was last started.
+}
+
+// AddRule adds a rule matching the entries logged through the global logger, also across
+// re-initialization with InitLogger, see Rule.
+//
+// Arguments:
+//   - r (Rule): Rule to add.
+//
+// Returns:
+//   - (func()): Function removing the rule.
+//   - error: Error if the rule is invalid.
+func AddRule(r Rule) (func(), error) {
+    return globalHub.addRule(r, levelMap())
+}
+
+// AddRule adds a rule matching the entries logged through the logger (and loggers derived from it
+// with WithFields) that pass level filtering for at least one output, see Rule.
+//
+// Arguments:
+//   - r (Rule): Rule to add.
+//
+// Returns:
+//   - (func()): Function removing the rule.
+//   - error: Error if the rule is invalid.
+func (l *Logger) AddRule(r Rule) (func(), error) {
+    return l.hub.addRule(r, l.LogLevelMap)
+}
+
+// addRule compiles the rule and adds it to the hub as a hook on its levels.
+func (h *entryHub) addRule(r Rule, levelMap map[string]int) (func(), error) {
+    if r.Action == nil {
+        return nil, fmt.Errorf("rule action is required")
+    }
+    var levels []string
+    if r.Level != "" {
+        least, ok := levelMap[strings.ToLower(r.Level)]
+        if !ok {
+            return nil, fmt.Errorf("invalid rule level: %s", r.Level)
+        }
+        for name, value := range levelMap {
+            if value <= least {
+                levels = append(levels, name)
+            }
+        }
+    }
+    compiled := &rule{cooldown: r.Cooldown, action: r.Action}
+    for _, s := range r.Conditions {
+        c, err := parseCondition(s)
+        if err != nil {
+            return nil, fmt.Errorf("invalid rule: %v", err)
+        }
+        compiled.conditions = append(compiled.conditions, c)
+    }
+    if r.Pattern != "" {
+        pattern, err := regexp.Compile(r.Pattern)
+        if err != nil {
+            return nil, fmt.Errorf("invalid rule pattern: %v", err)
+        }
+        compiled.pattern = pattern
+    }
+    return h.addHook(levels, compiled.trigger), nil
+}
+
+// trigger starts the action with the entry if it matches the rule, unless the action is running
+// or the cooldown has not elapsed.
+func (r *rule) trigger(e Entry) {
+    if r.pattern != nil && !r.pattern.MatchString(e.Message) {
+        return
+    }
+    for _, c := range r.conditions {
+        if !c.matches(e.Fields) {
+            return
+        }
+    }
+
+    now := time.Now()
+    r.mu.Lock()
+    if r.running || (r.cooldown > 0 && !r.last.IsZero() && now.Sub(r.last) < r.cooldown) {
+        r.mu.Unlock()
+        return
+    }
+    r.running, r.last = true, now
+    r.mu.Unlock()
+
+    go func() {
+        defer func() {
+            r.mu.Lock()
+            r.running = false
+            r.mu.Unlock()
+        }()
+        guard("rule", func() { r.action(e) })
+    }()
+}
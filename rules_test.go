@@ -0,0 +1,49 @@
+package logger_test
+
+import (
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+func TestRules(t *testing.T) {
+    log, _ := newFileLogger(t, logger.LogConfig{FileLevel: "info"})
+    triggered := make(chan logger.Entry, 10)
+    remove, err := log.AddRule(logger.Rule{
+        Level:      "error",
+        Conditions: []string{"component==payments"},
+        Cooldown:   time.Hour,
+        Action:     func(e logger.Entry) { triggered <- e },
+    })
+    if err != nil {
+        t.Fatalf("Failed to add rule: %v", err)
+    }
+
+    log.WithField("component", "payments").Warning("Slow payment")
+    log.WithField("component", "search").Error("Index unavailable")
+    log.WithField("component", "payments").Error("Connection pool exhausted")
+    select {
+    case e := <-triggered:
+        if e.Message != "Connection pool exhausted" {
+            t.Errorf("Expected the matching entry, got '%s'", e.Message)
+        }
+    case <-time.After(time.Second):
+        t.Fatalf("Expected the rule to trigger")
+    }
+
+    log.WithField("component", "payments").Error("Connection pool exhausted again")
+    remove()
+    select {
+    case e := <-triggered:
+        t.Errorf("Expected no trigger within the cooldown, got '%s'", e.Message)
+    case <-time.After(50 * time.Millisecond):
+    }
+
+    if _, err := log.AddRule(logger.Rule{Level: "loud", Action: func(logger.Entry) {}}); err == nil {
+        t.Errorf("Expected an invalid level to be rejected")
+    }
+    if _, err := log.AddRule(logger.Rule{Conditions: []string{"payments"}, Action: func(logger.Entry) {}}); err == nil {
+        t.Errorf("Expected an invalid condition to be rejected")
+    }
+}
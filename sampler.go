@@ -0,0 +1,75 @@
+package logger
+
+import (
+    "context"
+    "math/rand/v2"
+)
+
+// SamplerFunc decides whether an entry logged through a logger returned by WithContext is written.
+// For such entries the sampler replaces the level thresholds of the outputs: returning true writes
+// the entry to all outputs, returning false drops it. Entries logged without a context are filtered
+// by the output levels as usual. See TraceSampler for aligning log volume with trace sampling.
+// As with processors, a sampler keeping the entry beyond the call keeps a copy of its fields.
+type SamplerFunc func(ctx context.Context, e Entry) bool
+
+// WithContext returns a logger based on the global logger whose entries are passed to
+// LogConfig.Sampler with the context.
+//
+// Arguments:
+//   - ctx (context.Context): Context of the entries, e.g. of the current request.
+//
+// Returns:
+//   - (*Logger): Logger bound to the context.
+func WithContext(ctx context.Context) *Logger {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return nil
+    }
+    return logInstance.WithContext(ctx)
+}
+
+// WithContext returns a copy of the logger whose entries are passed to LogConfig.Sampler with the context.
+// The copy shares outputs with the original logger.
+//
+// Arguments:
+//   - ctx (context.Context): Context of the entries, e.g. of the current request.
+//
+// Returns:
+//   - (*Logger): Logger bound to the context.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+    child := *l
+    child.ctx = ctx
+    return &child
+}
+
+// TraceSampler returns a sampler aligning log volume with trace sampling decisions: entries of sampled
+// traces are written down to the DEBUG level (TRACE entries are dropped), while for unsampled traces
+// DEBUG and TRACE entries are dropped, INFO entries are kept with the given probability and more
+// severe entries are always kept.
+//
+// Arguments:
+//   - isSampled (func(context.Context) bool): Reports whether the trace of the context is sampled,
+//     e.g. using trace.SpanContextFromContext(ctx).IsSampled() with OpenTelemetry.
+//   - infoRate (float64): Fraction of INFO entries of unsampled traces to keep, from 0 to 1.
+//
+// Returns:
+//   - (SamplerFunc): Trace-aware sampler.
+func TraceSampler(isSampled func(ctx context.Context) bool, infoRate float64) SamplerFunc {
+    return func(ctx context.Context, e Entry) bool {
+        levels := levelMap()
+        slot, ok := levels[e.Level]
+        if !ok {
+            return true
+        }
+        if isSampled(ctx) {
+            return slot <= levels["debug"]
+        }
+        switch {
+        case slot < levels["info"]:
+            return true
+        case slot == levels["info"]:
+            return rand.Float64() < infoRate
+        }
+        return false
+    }
+}
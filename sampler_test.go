@@ -0,0 +1,61 @@
+package logger_test
+
+import (
+    "context"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+type sampledKey struct{}
+
+func TestTraceSampler(t *testing.T) {
+    isSampled := func(ctx context.Context) bool {
+        sampled, _ := ctx.Value(sampledKey{}).(bool)
+        return sampled
+    }
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel: "warning",
+        Sampler:   logger.TraceSampler(isSampled, 0),
+    })
+
+    sampled := log.WithContext(context.WithValue(context.Background(), sampledKey{}, true))
+    unsampled := log.WithContext(context.Background())
+
+    sampled.Debug("Sampled debug")
+    sampled.Trace("Sampled trace")
+    unsampled.Debug("Unsampled debug")
+    unsampled.Info("Unsampled info")
+    unsampled.Warning("Unsampled warning")
+    log.Info("Without context")
+
+    content := read()
+    for _, expected := range []string{"[DEBUG] Sampled debug", "[WARNING] Unsampled warning"} {
+        if !strings.Contains(content, expected) {
+            t.Errorf("Expected log to contain '%s', got '%s'", expected, content)
+        }
+    }
+    for _, unexpected := range []string{"Sampled trace", "Unsampled debug", "Unsampled info", "Without context"} {
+        if strings.Contains(content, unexpected) {
+            t.Errorf("Expected log not to contain '%s', got '%s'", unexpected, content)
+        }
+    }
+}
+
+func TestSamplerFunc(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel: "info",
+        Sampler: func(ctx context.Context, e logger.Entry) bool {
+            return !strings.Contains(e.Message, "noisy")
+        },
+    })
+    ctx := context.Background()
+    log.WithContext(ctx).Info("noisy entry")
+    log.WithContext(ctx).Info("useful entry")
+
+    content := read()
+    if strings.Contains(content, "noisy") || !strings.Contains(content, "useful entry") {
+        t.Errorf("Expected the sampler to drop the noisy entry, got '%s'", content)
+    }
+}
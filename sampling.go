@@ -0,0 +1,113 @@
+package logger
+
+import (
+    "hash/fnv"
+    "sync/atomic"
+    "time"
+)
+
+// Fields added to the entries written by message sampling after the initial ones.
+const (
+    SampledField = "sampled"
+    DroppedField = "dropped"
+)
+
+// samplingBuckets is the number of counters of message sampling. Messages are hashed to a counter,
+// so memory stays bounded for any number of distinct messages; colliding messages share a counter.
+const samplingBuckets = 4096
+
+// SamplingConfig limits the volume of repeated entries, for example INFO entries logged in a hot loop.
+// In every Tick interval, the first Initial entries with the same level and message are written,
+// then every Thereafter-th entry, carrying the fields "sampled"=true and "dropped" with the number
+// of identical entries dropped since the previous one written. FATAL entries are never sampled.
+type SamplingConfig struct {
+    Initial    int           // Number of identical entries written per interval before sampling.
+    Thereafter int           // Write every Thereafter-th identical entry after the initial ones, 0 to drop them all.
+    Tick       time.Duration // Interval the counts are reset at (default: 1 second).
+}
+
+// messageSampler is the state of message sampling, shared by the copies of a logger.
+type messageSampler struct {
+    config   SamplingConfig
+    counters [samplingBuckets]samplingCounter
+}
+
+// samplingCounter counts the entries of a message in the current interval.
+type samplingCounter struct {
+    window  atomic.Int64  // Start of the interval in Unix nanoseconds.
+    count   atomic.Uint64 // Entries counted in the interval.
+    dropped atomic.Uint64 // Entries dropped since the last written one.
+}
+
+// samplingState holds the current message sampling state, shared by the copies of a logger and
+// replaced by SetSampling while logging.
+type samplingState struct {
+    current atomic.Pointer[messageSampler]
+}
+
+// newSamplingState creates the message sampling of a logger, disabled if config is nil.
+func newSamplingState(config *SamplingConfig) *samplingState {
+    m := &samplingState{}
+    m.current.Store(newMessageSampler(config))
+    return m
+}
+
+// load returns the current message sampling state, nil if disabled.
+func (m *samplingState) load() *messageSampler {
+    if m == nil {
+        return nil
+    }
+    return m.current.Load()
+}
+
+// SetSampling changes the sampling of repeated entries of the logger and the loggers derived from it
+// while the application runs, with counts starting over, see SamplingConfig.
+//
+// Arguments:
+//   - config (*SamplingConfig): New sampling, nil to disable it.
+func (l *Logger) SetSampling(config *SamplingConfig) {
+    l.messages.current.Store(newMessageSampler(config))
+}
+
+// newMessageSampler creates the message sampling state, nil if config is nil.
+func newMessageSampler(config *SamplingConfig) *messageSampler {
+    if config == nil {
+        return nil
+    }
+    s := &messageSampler{config: *config}
+    if s.config.Tick <= 0 {
+        s.config.Tick = time.Second
+    }
+    return s
+}
+
+// check counts an entry and reports whether it is written. For entries written after the initial
+// ones, sampled is set and dropped is the number of identical entries dropped before it.
+func (s *messageSampler) check(level, message string) (keep, sampled bool, dropped uint64) {
+    h := fnv.New64a()
+    h.Write([]byte(level))
+    h.Write([]byte{0})
+    h.Write([]byte(message))
+    c := &s.counters[h.Sum64()%samplingBuckets]
+
+    now := time.Now().UnixNano()
+    window := now - now%int64(s.config.Tick)
+    if previous := c.window.Load(); previous != window && c.window.CompareAndSwap(previous, window) {
+        c.count.Store(0)
+    }
+    n := c.count.Add(1)
+    if n <= uint64(s.config.Initial) {
+        return true, false, 0
+    }
+    if s.config.Thereafter > 0 && (n-uint64(s.config.Initial))%uint64(s.config.Thereafter) == 0 {
+        return true, true, c.dropped.Swap(0)
+    }
+    c.dropped.Add(1)
+    return false, false, 0
+}
+
+// addSamplingInfo adds the sampling fields to the fields of an entry.
+func addSamplingInfo(fields Fields, dropped uint64) {
+    fields[SampledField] = true
+    fields[DroppedField] = dropped
+}
@@ -0,0 +1,52 @@
+package logger_test
+
+import (
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+func TestSampling(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel: "info",
+        Sampling:  &logger.SamplingConfig{Initial: 2, Thereafter: 3, Tick: time.Hour},
+    })
+    for i := 0; i < 10; i++ {
+        log.Info("Cache miss")
+        if i == 0 {
+            log.Warning("Slow request")
+        }
+    }
+
+    lines := strings.Split(strings.TrimSpace(read()), "\n")
+    expected := []string{
+        "[INFO] Cache miss",
+        "[WARNING] Slow request",
+        "[INFO] Cache miss",
+        "[INFO] Cache miss dropped=2 sampled=true",
+        "[INFO] Cache miss dropped=2 sampled=true",
+    }
+    if len(lines) != len(expected) {
+        t.Fatalf("Expected %d entries, got %v", len(expected), lines)
+    }
+    for i, line := range lines {
+        if !strings.HasSuffix(line, expected[i]) {
+            t.Errorf("Expected entry %d to end with %q, got %q", i+1, expected[i], line)
+        }
+    }
+}
+
+func TestSamplingDropAll(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel: "info",
+        Sampling:  &logger.SamplingConfig{Initial: 1, Tick: time.Hour},
+    })
+    for i := 0; i < 5; i++ {
+        log.Info("Polling")
+    }
+    if n := strings.Count(read(), "Polling"); n != 1 {
+        t.Errorf("Expected only the initial entry, got %d", n)
+    }
+}
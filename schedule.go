@@ -0,0 +1,186 @@
+package logger
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// LevelWindow changes the levels of the file and console outputs during a recurring time window,
+// for example to log at the TRACE level during nightly batch runs and at the WARNING level otherwise.
+// Outside of the windows the outputs have the levels they had when the window started, unless their
+// level was changed during the window, e.g. with SetFileLevel, in which case the change is kept.
+type LevelWindow struct {
+    Schedule string        // Start of the window as a cron expression "minute hour day-of-month month day-of-week" in local time, e.g. "0 2 * * *".
+    Duration time.Duration // Length of the window.
+    Level    interface{}   // Level of the file and console outputs during the window: can be a string or a number.
+}
+
+// cronSpec is a parsed cron expression, with the allowed values of each field as bits.
+type cronSpec struct {
+    minute, hour, dom, month, dow uint64
+    domAny, dowAny                bool // Whether the day fields are "*".
+}
+
+// cronFields are the bounds of the fields of a cron expression.
+var cronFields = []struct {
+    name     string
+    min, max int
+}{
+    {"minute", 0, 59}, {"hour", 0, 23}, {"day of month", 1, 31}, {"month", 1, 12}, {"day of week", 0, 7},
+}
+
+// parseCron parses a cron expression of five fields, each "*", a value, a range "a-b" or a list of
+// them separated by commas, optionally with a step such as "*/15" or "8-18/2". Sunday is 0 or 7.
+func parseCron(expr string) (cronSpec, error) {
+    parts := strings.Fields(expr)
+    if len(parts) != len(cronFields) {
+        return cronSpec{}, fmt.Errorf("invalid schedule %q: expected 5 fields", expr)
+    }
+    var bits [5]uint64
+    for i, part := range parts {
+        f := cronFields[i]
+        for _, item := range strings.Split(part, ",") {
+            rangePart, step := item, 1
+            if j := strings.IndexByte(item, '/'); j >= 0 {
+                n, err := strconv.Atoi(item[j+1:])
+                if err != nil || n <= 0 {
+                    return cronSpec{}, fmt.Errorf("invalid schedule %q: bad step in %s", expr, f.name)
+                }
+                rangePart, step = item[:j], n
+            }
+            lo, hi := f.min, f.max
+            if rangePart != "*" {
+                bounds := strings.SplitN(rangePart, "-", 2)
+                var err error
+                if lo, err = strconv.Atoi(bounds[0]); err != nil {
+                    return cronSpec{}, fmt.Errorf("invalid schedule %q: bad %s", expr, f.name)
+                }
+                hi = lo
+                if len(bounds) == 2 {
+                    if hi, err = strconv.Atoi(bounds[1]); err != nil {
+                        return cronSpec{}, fmt.Errorf("invalid schedule %q: bad %s", expr, f.name)
+                    }
+                }
+            }
+            if lo < f.min || hi > f.max || lo > hi {
+                return cronSpec{}, fmt.Errorf("invalid schedule %q: %s out of range", expr, f.name)
+            }
+            for v := lo; v <= hi; v += step {
+                bits[i] |= 1 << uint(v)
+            }
+        }
+    }
+    if bits[4]&(1<<7) != 0 {
+        bits[4] |= 1 // Sunday
+    }
+    return cronSpec{
+        minute: bits[0], hour: bits[1], dom: bits[2], month: bits[3], dow: bits[4],
+        domAny: parts[2] == "*", dowAny: parts[4] == "*",
+    }, nil
+}
+
+// matches reports whether the minute of t matches the expression. As in cron, a day matches either
+// day field when both are restricted.
+func (c cronSpec) matches(t time.Time) bool {
+    if c.minute&(1<<uint(t.Minute())) == 0 || c.hour&(1<<uint(t.Hour())) == 0 || c.month&(1<<uint(t.Month())) == 0 {
+        return false
+    }
+    dom := c.dom&(1<<uint(t.Day())) != 0
+    dow := c.dow&(1<<uint(t.Weekday())) != 0
+    if c.domAny || c.dowAny {
+        return dom && dow
+    }
+    return dom || dow
+}
+
+// levelWindow is a compiled LevelWindow.
+type levelWindow struct {
+    spec     cronSpec
+    duration time.Duration
+    level    int
+}
+
+// levelSchedule applies the level windows of a logger.
+type levelSchedule struct {
+    l       *Logger
+    now     func() time.Time // Clock of the schedule.
+    windows []levelWindow
+    active  bool  // Whether a window is in progress.
+    level   int   // Level of the window in progress.
+    file    int64 // Levels of the outputs before the window, restored after it.
+    console int64
+}
+
+// newLevelSchedule compiles the level windows, nil if there are none.
+func (l *Logger) newLevelSchedule(windows []LevelWindow) (*levelSchedule, error) {
+    if len(windows) == 0 {
+        return nil, nil
+    }
+    s := &levelSchedule{l: l, now: time.Now}
+    for i, w := range windows {
+        spec, err := parseCron(w.Schedule)
+        if err != nil {
+            return nil, fmt.Errorf("level window %d: %v", i+1, err)
+        }
+        if w.Duration <= 0 {
+            return nil, fmt.Errorf("level window %d: duration must be positive", i+1)
+        }
+        level, err := l.parseLevel(w.Level)
+        if err != nil {
+            return nil, fmt.Errorf("level window %d: %v", i+1, err)
+        }
+        s.windows = append(s.windows, levelWindow{spec: spec, duration: w.Duration, level: level})
+    }
+    return s, nil
+}
+
+// levelAt returns the level of the first window in progress at t.
+func (s *levelSchedule) levelAt(t time.Time) (int, bool) {
+    t = t.Truncate(time.Minute)
+    for _, w := range s.windows {
+        // A window is in progress if it started less than its duration ago
+        for start := t; t.Sub(start) < w.duration; start = start.Add(-time.Minute) {
+            if w.spec.matches(start) {
+                return w.level, true
+            }
+        }
+    }
+    return 0, false
+}
+
+// apply sets the levels of the outputs for time t, saving them when a window starts and restoring
+// them when it ends. An output whose level was changed during the window keeps the new level.
+func (s *levelSchedule) apply(t time.Time) {
+    levels := s.l.levels
+    level, inWindow := s.levelAt(t)
+    switch {
+    case inWindow && !s.active:
+        s.active, s.level = true, level
+        s.file, s.console = levels.file.Swap(int64(level)), levels.console.Swap(int64(level))
+    case inWindow && level != s.level:
+        levels.file.CompareAndSwap(int64(s.level), int64(level))
+        levels.console.CompareAndSwap(int64(s.level), int64(level))
+        s.level = level
+    case !inWindow && s.active:
+        s.active = false
+        levels.file.CompareAndSwap(int64(s.level), s.file)
+        levels.console.CompareAndSwap(int64(s.level), s.console)
+    }
+}
+
+// run applies the level windows now and at the start of every minute, until the logger is stopped.
+func (s *levelSchedule) run() {
+    for {
+        now := s.now()
+        s.apply(now)
+        timer := time.NewTimer(now.Truncate(time.Minute).Add(time.Minute).Sub(now))
+        select {
+        case <-s.l.stop.ch:
+            timer.Stop()
+            return
+        case <-timer.C:
+        }
+    }
+}
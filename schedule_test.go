@@ -0,0 +1,102 @@
+package logger
+
+import (
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestParseCron(t *testing.T) {
+    spec, err := parseCron("*/15 2-4 * * 1-5")
+    if err != nil {
+        t.Fatalf("Failed to parse schedule: %v", err)
+    }
+    monday := time.Date(2026, 10, 12, 3, 30, 0, 0, time.Local)
+    if !spec.matches(monday) {
+        t.Errorf("Expected %v to match", monday)
+    }
+    for _, tm := range []time.Time{monday.Add(time.Minute), monday.Add(2 * time.Hour), monday.AddDate(0, 0, 5)} {
+        if spec.matches(tm) {
+            t.Errorf("Expected %v not to match", tm)
+        }
+    }
+
+    // Restricted day fields match either day
+    spec, err = parseCron("0 0 1 * 7")
+    if err != nil {
+        t.Fatalf("Failed to parse schedule: %v", err)
+    }
+    sunday := time.Date(2026, 10, 18, 0, 0, 0, 0, time.Local)
+    first := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+    if !spec.matches(sunday) || !spec.matches(first) || spec.matches(first.AddDate(0, 0, 1)) {
+        t.Errorf("Expected the schedule to match Sundays and first days of months")
+    }
+
+    for _, expr := range []string{"", "* * * *", "60 * * * *", "* * * * mon", "*/0 * * * *", "5-1 * * * *"} {
+        if _, err := parseCron(expr); err == nil {
+            t.Errorf("Expected an error for %q", expr)
+        }
+    }
+}
+
+func TestLevelWindows(t *testing.T) {
+    // The schedule is driven by its own clock, without the background goroutine of the logger
+    l, err := NewLogger(LogConfig{ConsoleLevel: "warning", FileLevel: "info"})
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    defer l.Close()
+    schedule, err := l.newLevelSchedule([]LevelWindow{{Schedule: "30 1 * * *", Duration: 2 * time.Hour, Level: "trace"}})
+    if err != nil {
+        t.Fatalf("Failed to compile level windows: %v", err)
+    }
+    day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.Local)
+    var now time.Time
+    schedule.now = func() time.Time { return now }
+
+    steps := []struct {
+        at      time.Duration
+        console string
+        file    string
+    }{
+        {time.Hour, "warning", "info"},
+        {90 * time.Minute, "trace", "trace"},
+        {2 * time.Hour, "trace", "error"}, // Set during the window
+        {3*time.Hour + 29*time.Minute, "trace", "error"},
+        {3*time.Hour + 30*time.Minute, "warning", "error"},
+    }
+    for _, step := range steps {
+        now = day.Add(step.at)
+        if step.file == "error" {
+            l.SetFileLevel("error")
+        }
+        schedule.apply(schedule.now())
+        console, file := levelName(int(l.levels.console.Load())), levelName(int(l.levels.file.Load()))
+        if console != step.console || file != step.file {
+            t.Errorf("Expected levels %s/%s at %v, got %s/%s", step.console, step.file, step.at, console, file)
+        }
+    }
+}
+
+func TestLevelWindowsInvalid(t *testing.T) {
+    windows := [][]LevelWindow{
+        {{Schedule: "0 2 * *", Duration: time.Hour, Level: "trace"}},
+        {{Schedule: "0 2 * * *", Level: "trace"}},
+        {{Schedule: "0 2 * * *", Duration: time.Hour, Level: "verbose"}},
+    }
+    for _, w := range windows {
+        if _, err := NewLogger(LogConfig{LevelWindows: w}); err == nil || !strings.Contains(err.Error(), "level window 1") {
+            t.Errorf("Expected a level window error for %+v, got %v", w[0], err)
+        }
+    }
+}
+
+func TestParseConfigLevelWindows(t *testing.T) {
+    config, err := ParseConfig([]byte(`{"levelWindows": [{"schedule": "0 2 * * *", "duration": "90m", "level": "trace"}]}`))
+    if err != nil {
+        t.Fatalf("Failed to parse config: %v", err)
+    }
+    if len(config.LevelWindows) != 1 || config.LevelWindows[0].Duration != 90*time.Minute {
+        t.Errorf("Unexpected level windows: %+v", config.LevelWindows)
+    }
+}
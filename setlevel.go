@@ -0,0 +1,133 @@
+package logger
+
+import (
+    "fmt"
+    "sync/atomic"
+)
+
+// outputLevels holds the current levels of the file and console outputs and the verbosity of V,
+// shared by the copies of a logger and changed atomically while logging.
+type outputLevels struct {
+    file      atomic.Int64
+    console   atomic.Int64
+    verbosity atomic.Int64
+}
+
+// allow reports whether the file or the console level allows the level value. Entries allowed
+// only by these levels still reach subscribers without a file or console output.
+func (o *outputLevels) allow(value int) bool {
+    return int64(value) <= o.file.Load() || int64(value) <= o.console.Load()
+}
+
+// newLevel returns an atomic level holding the value.
+func newLevel(value int) *atomic.Int64 {
+    level := new(atomic.Int64)
+    level.Store(int64(value))
+    return level
+}
+
+// SetLevel changes the levels of the file and console outputs of the global logger, see (*Logger).SetLevel.
+//
+// Arguments:
+//   - level (interface{}): New log level: can be a Level, a string or a number.
+//
+// Returns:
+//   - error: Error if the level is invalid.
+func SetLevel(level interface{}) error {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return fmt.Errorf("logger is not initialized")
+    }
+    return logInstance.SetLevel(level)
+}
+
+// SetFileLevel changes the level of the file output of the global logger, see (*Logger).SetFileLevel.
+//
+// Arguments:
+//   - level (interface{}): New log level: can be a Level, a string or a number.
+//
+// Returns:
+//   - error: Error if the level is invalid.
+func SetFileLevel(level interface{}) error {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return fmt.Errorf("logger is not initialized")
+    }
+    return logInstance.SetFileLevel(level)
+}
+
+// SetConsoleLevel changes the level of the console output of the global logger, see (*Logger).SetConsoleLevel.
+//
+// Arguments:
+//   - level (interface{}): New log level: can be a Level, a string or a number.
+//
+// Returns:
+//   - error: Error if the level is invalid.
+func SetConsoleLevel(level interface{}) error {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return fmt.Errorf("logger is not initialized")
+    }
+    return logInstance.SetConsoleLevel(level)
+}
+
+// SetLevel changes the levels of both the file and console outputs, see SetFileLevel.
+//
+// Arguments:
+//   - level (interface{}): New log level: can be a Level, a string or a number.
+//
+// Returns:
+//   - error: Error if the level is invalid.
+func (l *Logger) SetLevel(level interface{}) error {
+    value, err := l.parseLevel(level)
+    if err != nil {
+        return err
+    }
+    l.levels.file.Store(int64(value))
+    l.levels.console.Store(int64(value))
+    return nil
+}
+
+// SetFileLevel changes the level of the file output while the application runs, for example to
+// bump verbosity to DEBUG in production without restarting. It is safe to call concurrently with
+// logging, and applies to the loggers derived from this one as well. The current levels are
+// reported by State; the deprecated FileLogLevel field is not updated.
+//
+// Arguments:
+//   - level (interface{}): New log level: can be a Level, a string or a number.
+//
+// Returns:
+//   - error: Error if the level is invalid.
+func (l *Logger) SetFileLevel(level interface{}) error {
+    value, err := l.parseLevel(level)
+    if err != nil {
+        return err
+    }
+    l.levels.file.Store(int64(value))
+    return nil
+}
+
+// SetConsoleLevel changes the level of the console output while the application runs, see SetFileLevel.
+//
+// Arguments:
+//   - level (interface{}): New log level: can be a Level, a string or a number.
+//
+// Returns:
+//   - error: Error if the level is invalid.
+func (l *Logger) SetConsoleLevel(level interface{}) error {
+    value, err := l.parseLevel(level)
+    if err != nil {
+        return err
+    }
+    l.levels.console.Store(int64(value))
+    return nil
+}
+
+// SetVerbosity changes the maximum verbosity enabled for the V loggers of the logger and the loggers
+// derived from it while the application runs, see SetFileLevel.
+//
+// Arguments:
+//   - n (int): New maximum verbosity.
+func (l *Logger) SetVerbosity(n int) {
+    l.levels.verbosity.Store(int64(n))
+}
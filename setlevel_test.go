@@ -0,0 +1,61 @@
+package logger_test
+
+import (
+    "strings"
+    "sync"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestSetFileLevel(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "warning"})
+    child := log.WithField("component", "db")
+
+    log.Debug("Before")
+    if err := log.SetFileLevel("debug"); err != nil {
+        t.Fatalf("Failed to set the file level: %v", err)
+    }
+    child.Debug("After")
+    if err := log.SetFileLevel("loud"); err == nil {
+        t.Error("Expected an invalid level to be rejected")
+    }
+
+    file := read()
+    if strings.Contains(file, "Before") || !strings.Contains(file, "[DEBUG] After") {
+        t.Errorf("Expected only the entry after the level change, got '%s'", file)
+    }
+    if state := log.State(); state.FileLevel != "debug" {
+        t.Errorf("Expected the state to report the new file level, got %s", state.FileLevel)
+    }
+}
+
+func TestSetLevelConcurrent(t *testing.T) {
+    log, _ := newFileLogger(t, logger.LogConfig{FileLevel: "info"})
+    var wg sync.WaitGroup
+    for i := 0; i < 4; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for j := 0; j < 100; j++ {
+                log.Debug("Concurrent entry")
+            }
+        }()
+    }
+    for _, level := range []string{"debug", "error", "trace", "info"} {
+        log.SetLevel(level)
+        logger.SetConsoleLevel(level)
+    }
+    wg.Wait()
+}
+
+func TestSetLevelGlobal(t *testing.T) {
+    resetLogger()
+    defer resetLogger()
+    if err := logger.SetFileLevel("debug"); err != nil {
+        t.Errorf("Failed to set the file level of the global logger: %v", err)
+    }
+    if err := logger.SetLevel(7.0); err != nil {
+        t.Errorf("Failed to set a numeric level: %v", err)
+    }
+}
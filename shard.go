@@ -0,0 +1,157 @@
+package logger
+
+import (
+    "bufio"
+    "encoding/json"
+    "fmt"
+    "io"
+    "os"
+    "path/filepath"
+    "regexp"
+    "strings"
+    "time"
+)
+
+// standardTimePattern extracts the timestamp from a line in the standard format.
+var standardTimePattern = regexp.MustCompile(`^\[([^\]]+)\]`)
+
+// shardedWriter spreads log lines across several files, each with its own lock,
+// so goroutines logging concurrently do not contend on a single file.
+type shardedWriter struct {
+    files []*logFile
+}
+
+// ShardPath returns the path of a file shard: "app.log" becomes "app.2.log" for shard 2.
+//
+// Arguments:
+//   - path (string): Log file path from the configuration.
+//   - index (int): Shard index, starting at 0.
+//
+// Returns:
+//   - (string): Path of the shard.
+func ShardPath(path string, index int) string {
+    ext := filepath.Ext(path)
+    return fmt.Sprintf("%s.%d%s", strings.TrimSuffix(path, ext), index, ext)
+}
+
+// newShardedWriter opens the FileShards files of the configuration, each rotated independently.
+func newShardedWriter(config LogConfig) (*shardedWriter, error) {
+    sw := &shardedWriter{}
+    for i := 0; i < config.FileShards; i++ {
+        file, err := openLogFile(ShardPath(config.FilePath, i), config)
+        if err != nil {
+            return nil, err
+        }
+        sw.files = append(sw.files, file)
+    }
+    return sw, nil
+}
+
+// Write writes p to the shard of the calling goroutine, keeping the entries
+// of each goroutine in order within one file.
+func (sw *shardedWriter) Write(p []byte) (int, error) {
+    return sw.files[goroutineID()%uint64(len(sw.files))].Write(p)
+}
+
+// MergeShards merges the shard files of a sharded file output (see LogConfig.FileShards) into
+// a single stream ordered by timestamp. Entries with equal timestamps keep the order of their shard,
+// and lines without a timestamp stay with the entry they follow.
+//
+// Arguments:
+//   - path (string): Log file path from the configuration.
+//   - shards (int): Number of shards.
+//   - w (io.Writer): Writer receiving the merged lines.
+//
+// Returns:
+//   - error: Error if a shard cannot be read or the output cannot be written.
+func MergeShards(path string, shards int, w io.Writer) error {
+    type cursor struct {
+        scanner *bufio.Scanner
+        lines   []string // Current entry: first line and continuation lines.
+        time    time.Time
+        next    string // First line of the following entry, if already read.
+        done    bool
+    }
+
+    var cursors []*cursor
+    for i := 0; i < shards; i++ {
+        file, err := os.Open(ShardPath(path, i))
+        if os.IsNotExist(err) {
+            continue
+        } else if err != nil {
+            return err
+        }
+        defer file.Close()
+        scanner := bufio.NewScanner(file)
+        scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+        cursors = append(cursors, &cursor{scanner: scanner})
+    }
+
+    // advance reads the next entry of the cursor with its continuation lines.
+    advance := func(c *cursor) {
+        c.lines = c.lines[:0]
+        if c.next == "" {
+            if !c.scanner.Scan() {
+                c.done = true
+                return
+            }
+            c.next = c.scanner.Text()
+        }
+        c.lines = append(c.lines, c.next)
+        c.time, _ = lineTime(c.next)
+        c.next = ""
+        for c.scanner.Scan() {
+            line := c.scanner.Text()
+            if _, ok := lineTime(line); ok {
+                c.next = line
+                return
+            }
+            c.lines = append(c.lines, line)
+        }
+    }
+
+    for _, c := range cursors {
+        advance(c)
+    }
+    for {
+        var earliest *cursor
+        for _, c := range cursors {
+            if !c.done && (earliest == nil || c.time.Before(earliest.time)) {
+                earliest = c
+            }
+        }
+        if earliest == nil {
+            break
+        }
+        for _, line := range earliest.lines {
+            if _, err := io.WriteString(w, line+"\n"); err != nil {
+                return err
+            }
+        }
+        advance(earliest)
+    }
+    for _, c := range cursors {
+        if err := c.scanner.Err(); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// lineTime returns the timestamp of a log line in the standard or JSON format.
+func lineTime(line string) (time.Time, bool) {
+    var value string
+    if m := standardTimePattern.FindStringSubmatch(line); m != nil {
+        value = m[1]
+    } else {
+        var data struct {
+            Timestamp string `json:"timestamp"`
+        }
+        if json.Unmarshal([]byte(line), &data) != nil {
+            return time.Time{}, false
+        }
+        value = data.Timestamp
+    }
+    t, err := time.Parse(time.RFC3339Nano, value)
+    return t, err == nil
+}
@@ -0,0 +1,70 @@
+package logger_test
+
+import (
+    "bytes"
+    "os"
+    "path/filepath"
+    "strings"
+    "sync"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestFileShards(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "app.log")
+    log, err := logger.NewLogger(logger.LogConfig{FilePath: path, FileLevel: "info", FileShards: 4})
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+
+    var wg sync.WaitGroup
+    for g := 0; g < 8; g++ {
+        wg.Add(1)
+        go func(g int) {
+            defer wg.Done()
+            for i := 0; i < 50; i++ {
+                log.Infof("goroutine %d message %d", g, i)
+            }
+        }(g)
+    }
+    wg.Wait()
+
+    total := 0
+    for i := 0; i < 4; i++ {
+        data, err := os.ReadFile(logger.ShardPath(path, i))
+        if err != nil {
+            t.Fatalf("Failed to read shard %d: %v", i, err)
+        }
+        total += strings.Count(string(data), "\n")
+    }
+    if total != 400 {
+        t.Errorf("Expected 400 lines across shards, got %d", total)
+    }
+
+    var merged bytes.Buffer
+    if err := logger.MergeShards(path, 4, &merged); err != nil {
+        t.Fatalf("Failed to merge shards: %v", err)
+    }
+    if count := strings.Count(merged.String(), "\n"); count != 400 {
+        t.Errorf("Expected 400 merged lines, got %d", count)
+    }
+}
+
+func TestMergeShardsOrder(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "app.log")
+    os.WriteFile(logger.ShardPath(path, 0), []byte("[2024-01-01T00:00:01Z] [INFO] b\n  b continued\n[2024-01-01T00:00:03Z] [INFO] d\n"), 0644)
+    os.WriteFile(logger.ShardPath(path, 1), []byte(`{"timestamp":"2024-01-01T00:00:00Z","message":"a"}`+"\n"+`{"timestamp":"2024-01-01T00:00:02Z","message":"c"}`+"\n"), 0644)
+
+    var merged bytes.Buffer
+    if err := logger.MergeShards(path, 2, &merged); err != nil {
+        t.Fatalf("Failed to merge shards: %v", err)
+    }
+    expected := `{"timestamp":"2024-01-01T00:00:00Z","message":"a"}` + "\n" +
+        "[2024-01-01T00:00:01Z] [INFO] b\n  b continued\n" +
+        `{"timestamp":"2024-01-01T00:00:02Z","message":"c"}` + "\n" +
+        "[2024-01-01T00:00:03Z] [INFO] d\n"
+    if merged.String() != expected {
+        t.Errorf("Unexpected merge order: %s", merged.String())
+    }
+}
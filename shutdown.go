@@ -0,0 +1,123 @@
+package logger
+
+import (
+    "context"
+    "errors"
+    "reflect"
+)
+
+// Shutdown flushes and closes the global logger, see (*Logger).Shutdown.
+//
+// Arguments:
+//   - ctx (context.Context): Context limiting how long to wait, e.g. with the shutdown grace period.
+//
+// Returns:
+//   - error: Error closing the log files, or the context error if it was done first.
+func Shutdown(ctx context.Context) error {
+    mu.Lock()
+    l := logInstance
+    mu.Unlock()
+    if l == nil {
+        return nil
+    }
+    return l.Shutdown(ctx)
+}
+
+// Shutdown flushes and closes the logger, see Close. It returns when the logger is closed or when the
+// context is done, whichever comes first; closing then continues in the background. Its signature
+// matches the stop hooks of lifecycle managers such as uber-fx:
+//
+//	lc.Append(fx.Hook{OnStop: log.Shutdown})
+//
+// Arguments:
+//   - ctx (context.Context): Context limiting how long to wait, e.g. with the shutdown grace period.
+//
+// Returns:
+//   - error: Error closing the log files, or the context error if it was done first.
+func (l *Logger) Shutdown(ctx context.Context) error {
+    done := make(chan error, 1)
+    go func() {
+        done <- l.Close()
+    }()
+    select {
+    case err := <-done:
+        return err
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+}
+
+// Close writes the summary of suppressed duplicate entries, drains the entries queued in async mode,
+// stops the background jobs of the logger, then syncs and closes its log files and the files of its
+// sinks. Later entries are no longer written to the files, and later calls of Close are no-ops. Loggers derived with WithFields share the files and are closed too.
+//
+// Returns:
+//   - error: Error syncing or closing a log file.
+func (l *Logger) Close() error {
+    l.flushRepeats()
+    l.stopBackground()
+    var errs []error
+    for _, file := range l.files {
+        if err := file.Close(); err != nil {
+            errs = append(errs, err)
+        }
+    }
+    if err := closeSinks(l.sinks); err != nil {
+        errs = append(errs, err)
+    }
+    return errors.Join(errs...)
+}
+
+// release closes the files and sinks of a global logger replaced by InitLogger, except the custom
+// sinks that the next logger, nil if initialization failed, carries over.
+func (l *Logger) release(next *Logger) {
+    for _, file := range l.files {
+        if err := file.Close(); err != nil {
+            reportError("outputs", err)
+        }
+    }
+    var sinks []Sink
+    for _, s := range l.sinks {
+        if custom, ok := s.(*namedSink); ok && next != nil && next.carries(custom.Sink) {
+            continue
+        }
+        sinks = append(sinks, s)
+    }
+    if err := closeSinks(sinks); err != nil {
+        reportError("outputs", err)
+    }
+}
+
+// carries reports whether the custom sink is one of the sinks of the logger.
+func (l *Logger) carries(sink Sink) bool {
+    for _, s := range l.sinks {
+        if custom, ok := s.(*namedSink); ok && reflect.TypeOf(custom.Sink).Comparable() && custom.Sink == sink {
+            return true
+        }
+    }
+    return false
+}
+
+// ProvideLogger creates a logger for dependency injection, with a cleanup function closing it. Its
+// signature is that of a google/wire provider with cleanup; uber-fx applications use the Module of
+// the github.com/nir0k/logger/fxlog module instead, which registers Shutdown as a stop hook.
+//
+// Arguments:
+//   - config (LogConfig): Logger configuration, see NewLogger.
+//
+// Returns:
+//   - (*Logger): New logger.
+//   - (func()): Cleanup function flushing and closing the logger, reporting close errors to the error handler.
+//   - error: Error if the configuration is invalid or the log file is inaccessible.
+func ProvideLogger(config LogConfig) (*Logger, func(), error) {
+    l, err := NewLogger(config)
+    if err != nil {
+        return nil, nil, err
+    }
+    cleanup := func() {
+        if err := l.Close(); err != nil {
+            reportError("close", err)
+        }
+    }
+    return l, cleanup, nil
+}
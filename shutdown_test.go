@@ -0,0 +1,81 @@
+package logger_test
+
+import (
+    "context"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+func TestClose(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info", Async: true})
+    log.Info("Before close")
+    if err := log.Close(); err != nil {
+        t.Fatalf("Failed to close logger: %v", err)
+    }
+    if err := log.Close(); err != nil {
+        t.Errorf("Expected closing again to be a no-op, got %v", err)
+    }
+    log.Info("After close")
+
+    content := read()
+    if !strings.Contains(content, "Before close") || strings.Contains(content, "After close") {
+        t.Errorf("Expected only entries before close, got '%s'", content)
+    }
+}
+
+func TestShutdown(t *testing.T) {
+    defer logger.ResetLogger()
+    path := filepath.Join(t.TempDir(), "app.log")
+    if err := logger.InitLogger(logger.LogConfig{FilePath: path, FileLevel: "info", Async: true}); err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+    for i := 0; i < 50; i++ {
+        logger.Info("Pending entry")
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+    if err := logger.Shutdown(ctx); err != nil {
+        t.Fatalf("Failed to shut down logger: %v", err)
+    }
+    if err := logger.Shutdown(ctx); err != nil {
+        t.Errorf("Expected a second shutdown to be a no-op, got %v", err)
+    }
+
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatalf("Failed to read log file: %v", err)
+    }
+    if count := strings.Count(string(data), "Pending entry"); count != 50 {
+        t.Errorf("Expected all 50 pending entries to be written, got %d", count)
+    }
+}
+
+func TestProvideLogger(t *testing.T) {
+    discardStdout(t)
+    path := filepath.Join(t.TempDir(), "app.log")
+    log, cleanup, err := logger.ProvideLogger(logger.LogConfig{FilePath: path, FileLevel: "info", Async: true})
+    if err != nil {
+        t.Fatalf("Failed to provide logger: %v", err)
+    }
+    log.Info("Provided entry")
+    cleanup()
+    log.Info("After cleanup")
+
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatalf("Failed to read log file: %v", err)
+    }
+    if !strings.Contains(string(data), "Provided entry") || strings.Contains(string(data), "After cleanup") {
+        t.Errorf("Expected the cleanup to flush and close the logger, got '%s'", data)
+    }
+
+    if _, _, err := logger.ProvideLogger(logger.LogConfig{FileLevel: "loud"}); err == nil {
+        t.Errorf("Expected an invalid configuration to be rejected")
+    }
+}
@@ -0,0 +1,184 @@
+package logger
+
+import (
+    "errors"
+    "os"
+    "os/signal"
+    "syscall"
+)
+
+// exitFunc terminates the process after a termination signal; replaced in tests.
+var exitFunc = os.Exit
+
+// Reopen reopens the log files of the global logger, see (*Logger).Reopen.
+//
+// Returns:
+//   - error: Error if a log file cannot be reopened.
+func Reopen() error {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return nil
+    }
+    return logInstance.Reopen()
+}
+
+// Reopen closes and reopens the log files, so that writes go to new files after the current ones
+// were moved away by an external tool such as logrotate.
+//
+// Returns:
+//   - error: Error if a log file cannot be reopened.
+func (l *Logger) Reopen() error {
+    var errs []error
+    for _, file := range l.files {
+        if err := file.Reopen(); err != nil {
+            errs = append(errs, err)
+        }
+    }
+    return errors.Join(errs...)
+}
+
+// Rotate rotates the log files of the global logger, see (*Logger).Rotate.
+//
+// Returns:
+//   - error: Error if a log file cannot be rotated.
+func Rotate() error {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return nil
+    }
+    return logInstance.Rotate()
+}
+
+// Rotate moves the current log files to backups and starts new files regardless of their size,
+// for operators and tools forcing a rotation. Rotation must be enabled in the configuration.
+//
+// Returns:
+//   - error: Error if a log file cannot be rotated or rotation is not enabled.
+func (l *Logger) Rotate() error {
+    var errs []error
+    for _, file := range l.files {
+        if err := file.Rotate(); err != nil {
+            errs = append(errs, err)
+        }
+    }
+    return errors.Join(errs...)
+}
+
+// RotatingWriters returns the rotation backends of the log files of the logger: the built-in
+// RotatingFile of each file, or the writers of LogConfig.FileSink.Rotator implementing RotatingWriter.
+// It is empty if rotation is disabled. The writers are shared with the logger, so closing one of them
+// stops logging to its file until it is reopened.
+//
+// Returns:
+//   - ([]RotatingWriter): Rotation backends of the log files.
+func (l *Logger) RotatingWriters() []RotatingWriter {
+    var writers []RotatingWriter
+    for _, file := range l.files {
+        if w := file.rotatingWriter(); w != nil {
+            writers = append(writers, w)
+        }
+    }
+    return writers
+}
+
+// RotateOnSignal rotates the log files of the global logger on the signals, see (*Logger).RotateOnSignal.
+//
+// Arguments:
+//   - signals (...os.Signal): Signals triggering a rotation (default: SIGHUP).
+//
+// Returns:
+//   - (func()): Function uninstalling the handler.
+func RotateOnSignal(signals ...os.Signal) func() {
+    return handleRotateSignal(signals, Rotate, Errorf)
+}
+
+// RotateOnSignal installs a handler rotating the log files on the signals, SIGHUP by default:
+//
+//	defer log.RotateOnSignal()()
+//
+// It replaces the reopening on SIGHUP of InstallSignalHandlers when both handle the same signal,
+// and the two should not be combined.
+//
+// Arguments:
+//   - signals (...os.Signal): Signals triggering a rotation (default: SIGHUP).
+//
+// Returns:
+//   - (func()): Function uninstalling the handler.
+func (l *Logger) RotateOnSignal(signals ...os.Signal) func() {
+    return handleRotateSignal(signals, l.Rotate, l.Errorf)
+}
+
+// handleRotateSignal calls rotate on the signals until uninstalled, logging its errors with errorf.
+func handleRotateSignal(signals []os.Signal, rotate func() error, errorf func(string, ...interface{})) func() {
+    if len(signals) == 0 {
+        signals = []os.Signal{syscall.SIGHUP}
+    }
+    received := make(chan os.Signal, 1)
+    signal.Notify(received, signals...)
+    done := make(chan struct{})
+
+    go func() {
+        for {
+            select {
+            case <-done:
+                return
+            case <-received:
+                if err := rotate(); err != nil {
+                    errorf("Failed to rotate log files: %v", err)
+                }
+            }
+        }
+    }()
+
+    return func() {
+        signal.Stop(received)
+        close(done)
+    }
+}
+
+// InstallSignalHandlers installs process supervision handlers for the global logger in one call:
+//   - SIGHUP reopens the log files (see Reopen), for use with logrotate;
+//   - SIGTERM and SIGINT flush and close the log files, then exit with status 128+signal,
+//     so that container stop grace periods are not spent waiting on unwritten logs.
+//
+// Applications with their own graceful shutdown should handle SIGTERM themselves and call Shutdown last.
+//
+// Returns:
+//   - (func()): Function uninstalling the handlers.
+func InstallSignalHandlers() func() {
+    signals := make(chan os.Signal, 1)
+    signal.Notify(signals, syscall.SIGHUP, syscall.SIGTERM, syscall.SIGINT)
+    done := make(chan struct{})
+
+    go func() {
+        for {
+            select {
+            case <-done:
+                return
+            case sig := <-signals:
+                if sig == syscall.SIGHUP {
+                    if err := Reopen(); err != nil {
+                        Errorf("Failed to reopen log files: %v", err)
+                    }
+                    continue
+                }
+                mu.Lock()
+                if logInstance != nil {
+                    logInstance.Close()
+                }
+                mu.Unlock()
+                code := 1
+                if s, ok := sig.(syscall.Signal); ok {
+                    code = 128 + int(s)
+                }
+                exitFunc(code)
+                return
+            }
+        }
+    }()
+
+    return func() {
+        signal.Stop(signals)
+        close(done)
+    }
+}
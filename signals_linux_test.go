@@ -0,0 +1,86 @@
+package logger
+
+import (
+    "os"
+    "path/filepath"
+    "strings"
+    "syscall"
+    "testing"
+    "time"
+)
+
+func TestInstallSignalHandlers(t *testing.T) {
+    defer ResetLogger()
+    path := filepath.Join(t.TempDir(), "app.log")
+    if err := InitLogger(LogConfig{FilePath: path, FileLevel: "info"}); err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+
+    exited := make(chan int, 1)
+    exitFunc = func(code int) { exited <- code }
+    defer func() { exitFunc = os.Exit }()
+
+    uninstall := InstallSignalHandlers()
+    defer uninstall()
+
+    Info("Before rotation")
+    os.Rename(path, path+".1")
+    syscall.Kill(os.Getpid(), syscall.SIGHUP)
+    deadline := time.Now().Add(2 * time.Second)
+    for time.Now().Before(deadline) {
+        if _, err := os.Stat(path); err == nil {
+            break
+        }
+        time.Sleep(5 * time.Millisecond)
+    }
+    Info("After rotation")
+
+    syscall.Kill(os.Getpid(), syscall.SIGTERM)
+    select {
+    case code := <-exited:
+        if code != 128+int(syscall.SIGTERM) {
+            t.Errorf("Unexpected exit code: %d", code)
+        }
+    case <-time.After(2 * time.Second):
+        t.Fatalf("SIGTERM was not handled")
+    }
+    Info("After close")
+
+    rotated, _ := os.ReadFile(path + ".1")
+    current, _ := os.ReadFile(path)
+    if !strings.Contains(string(rotated), "Before rotation") || !strings.Contains(string(current), "After rotation") {
+        t.Errorf("Expected the file to be reopened on SIGHUP, got '%s' and '%s'", rotated, current)
+    }
+    if strings.Contains(string(current), "After close") {
+        t.Errorf("Expected writes after SIGTERM to be discarded, got '%s'", current)
+    }
+}
+
+func TestRotateOnSignal(t *testing.T) {
+    dir := t.TempDir()
+    log, err := NewLogger(LogConfig{
+        FilePath:       filepath.Join(dir, "app.log"),
+        FileLevel:      "info",
+        EnableRotation: true,
+        RotationConfig: RotationConfig{MaxSize: 1},
+        FileSink:       FileSinkConfig{RotationHooks: RotationHooks{Synchronous: true}},
+    })
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    defer log.Close()
+
+    uninstall := log.RotateOnSignal(syscall.SIGUSR1)
+    defer uninstall()
+
+    log.Info("Before rotation")
+    syscall.Kill(os.Getpid(), syscall.SIGUSR1)
+    deadline := time.Now().Add(2 * time.Second)
+    for time.Now().Before(deadline) {
+        if backups, _ := filepath.Glob(filepath.Join(dir, "app-*")); len(backups) == 1 {
+            return
+        }
+        time.Sleep(5 * time.Millisecond)
+    }
+    t.Errorf("Expected the log file to be rotated on the signal")
+}
@@ -0,0 +1,339 @@
+package logger
+
+import (
+    "errors"
+    "fmt"
+    "io"
+    "log"
+    "os"
+    "strings"
+    "sync"
+    "sync/atomic"
+    "time"
+)
+
+// Sink is an output of a logger with its own level, format and writer. The file, console and syslog
+// outputs are the sinks named "file", "console" and "syslog"; more are configured with LogConfig.Sinks,
+// for example to send JSON to a file, colored text to the console and errors to a third writer.
+// Sinks are called concurrently and must be safe for concurrent use.
+type Sink interface {
+    // Name returns the name of the sink, referenced by routing rules.
+    Name() string
+    // Enabled reports whether the sink writes entries at the level, given with its numeric value.
+    Enabled(level string, value int) bool
+    // WriteEntry renders the entry and writes it.
+    WriteEntry(e Entry) error
+}
+
+// SinkConfig configures a named output written in addition to the file and console outputs.
+// Entries reach a sink when a routing rule directs them to it, see RouteRule, and entries matched by
+// no rule reach it if Default is set. A sink implementing io.Closer is closed with the logger.
+type SinkConfig struct {
+    Name    string      // Name of the sink referenced by routing rules, other than "file", "console", "syslog", "etw", "gelf", "loki" and "file:*".
+    Path    string      // Path of a file the sink appends to, used if Writer is not set.
+    Writer  io.Writer   `json:"-"` // Writer of the sink.
+    Level   interface{} // Log level of the sink: can be a string or a number (default: "info").
+    Format  string      // Log format of the sink, Format if empty.
+    Default bool        // Whether the sink receives the entries matched by no routing rule.
+    Sink    Sink        `json:"-"` // Custom sink used instead of Path, Writer, Level and Format, named after Name if set.
+    // Dedupe replaces consecutive entries with identical content within this window by small reference
+    // records, for sinks shipping to remote collectors; 0 disables it. Entries written in full carry
+    // their content hash in the "content_hash" field and reference records carry it in "dedup_ref".
+    Dedupe time.Duration
+}
+
+// lineSink is a sink rendering entries as lines into pooled buffers, which lets the logger write
+// without allocating and batches collect the lines of a sink into a single write.
+type lineSink interface {
+    Sink
+    // appendLine appends the rendered entry with a trailing newline to buf, false if it is dropped.
+    appendLine(buf []byte, e Entry) ([]byte, bool)
+    // writeLines writes rendered lines, returning the write error.
+    writeLines(p []byte) error
+    // afterWrite is called for every entry written, with its level.
+    afterWrite(level string, value int)
+    // maxWrite returns the size of the largest write the writer accepts, 0 if unlimited.
+    maxWrite() int64
+}
+
+// outputSink is the sink implementation of the built-in outputs and of the sinks configured with
+// a path or a writer.
+type outputSink struct {
+    name       string
+    out        *log.Logger   // Writer of the sink, read on every write so that SetOutput applies.
+    mu         sync.Mutex    // Serializes writes, as writers provided by the caller need not be safe for concurrent use.
+    closer     io.Closer     // File opened for the sink, nil if the writer was provided.
+    level      *atomic.Int64 // Level of the sink, shared with the logger for the file and console outputs.
+    format     string
+    theme      *consoleTheme     // Styles of the lines by level, nil for uncolored lines.
+    glyphs     bool              // Whether lines in the standard format start with the glyph of their level.
+    width      *consoleWidth     // Width lines in the standard format are fitted to, nil to keep long lines.
+    pause      *consolePause     // Lines held while the console is paused, nil for other outputs.
+    levelNames map[string]string // Level names shown in the standard format, nil for the upper-case level.
+    processors []Processor       // Processors applied to entries written to the sink.
+    dedupe     *dedupeFilter     // Replacement of repeated entries by reference records, nil if disabled.
+    syncer     *fileSyncer       // Fsync policy applied after writes, nil if disabled.
+    writeLimit int64             // Size of the largest write the writer accepts, 0 if unlimited.
+    burst      *burstCapture     // Burst capture extending the level after errors, nil if disabled.
+    processor  string            // Component names for error reports.
+    formatter  string
+    output     string
+    health     sinkHealth
+}
+
+// newOutputSink creates a sink writing to out, reporting its errors as the component.
+func newOutputSink(name, component string, out *log.Logger, level *atomic.Int64, format string) *outputSink {
+    return &outputSink{
+        name:      name,
+        out:       out,
+        level:     level,
+        format:    format,
+        processor: component + " processor",
+        formatter: component + " formatter",
+        output:    component + " output",
+    }
+}
+
+// Name returns the name of the sink.
+func (s *outputSink) Name() string {
+    return s.name
+}
+
+// Enabled reports whether the level of the sink, or a burst in progress, allows the level.
+func (s *outputSink) Enabled(level string, value int) bool {
+    return int64(value) <= s.level.Load() || s.burst.allows(value)
+}
+
+// WriteEntry renders the entry and writes it.
+func (s *outputSink) WriteEntry(e Entry) error {
+    buf := getLineBuffer()
+    defer putLineBuffer(buf)
+    line, ok := s.appendLine(*buf, e)
+    *buf = line
+    if !ok || s.pause.hold(line) {
+        return nil
+    }
+    s.mu.Lock()
+    _, err := s.out.Writer().Write(line)
+    s.mu.Unlock()
+    s.health.record(err)
+    return err
+}
+
+// appendLine applies the processors of the sink and appends the rendered entry to buf.
+func (s *outputSink) appendLine(buf []byte, e Entry) ([]byte, bool) {
+    e, keep := applyProcessors(s.processor, s.processors, e)
+    if !keep {
+        return buf, false
+    }
+    e = s.dedupe.apply(e)
+    start := len(buf)
+    if strings.EqualFold(s.format, FormatPrettyJSON) {
+        // Pretty JSON styles its keys and level itself
+        if !guard(s.formatter, func() { buf = e.appendPrettyJSON(buf, s.theme) }) {
+            return buf[:start], false
+        }
+        return append(buf, '\n'), true
+    }
+    buf, styled := s.theme.appendLevelStart(buf, e.Level)
+    text := len(buf)
+    if s.glyphs && !strings.EqualFold(s.format, "json") {
+        buf = appendGlyph(buf, e.Level)
+    }
+    if !guard(s.formatter, func() { buf = e.appendFormat(buf, s.format, s.levelNames) }) {
+        return buf[:start], false
+    }
+    if s.width != nil && !strings.EqualFold(s.format, "json") {
+        buf = s.width.appendFitted(buf[:text], string(buf[text:]))
+    }
+    if styled {
+        buf = appendColorEnd(buf)
+    }
+    return append(buf, '\n'), true
+}
+
+// writeLines writes rendered lines, or holds them while the console is paused.
+func (s *outputSink) writeLines(p []byte) error {
+    if s.pause.hold(p) {
+        return nil
+    }
+    return s.writeOut(p)
+}
+
+// writeOut writes to the writer of the sink, isolating a panicking writer and recording write errors.
+func (s *outputSink) writeOut(p []byte) (err error) {
+    guard(s.output, func() {
+        s.mu.Lock()
+        defer s.mu.Unlock()
+        _, err = s.out.Writer().Write(p)
+        s.health.record(err)
+    })
+    return err
+}
+
+// afterWrite applies the fsync policy of the sink.
+func (s *outputSink) afterWrite(level string, value int) {
+    s.syncer.afterWrite(level, value)
+}
+
+// maxWrite returns the size of the largest write the writer of the sink accepts.
+func (s *outputSink) maxWrite() int64 {
+    return s.writeLimit
+}
+
+// state returns the state of the sink.
+func (s *outputSink) state() SinkState {
+    state := SinkState{Name: s.name, Level: levelName(int(s.level.Load())), Connected: true}
+    if conn, ok := s.out.Writer().(connectionState); ok {
+        state.Connected = conn.connected()
+    }
+    s.health.fill(&state)
+    return state
+}
+
+// Close writes the lines held while the console was paused and closes the file opened for the
+// sink, once.
+func (s *outputSink) Close() error {
+    s.resume()
+    if s.closer == nil {
+        return nil
+    }
+    err := s.closer.Close()
+    s.closer = nil
+    return err
+}
+
+// Sinks returns the sinks of the logger: the file, console and syslog outputs if enabled, then the
+// configured sinks.
+//
+// Returns:
+//   - ([]Sink): Sinks of the logger.
+func (l *Logger) Sinks() []Sink {
+    return append([]Sink(nil), l.sinks...)
+}
+
+// addSink adds a sink to the logger, to the default route if isDefault is set.
+func (l *Logger) addSink(s Sink, isDefault bool) {
+    if l.defaultRoute == nil {
+        l.defaultRoute = &route{}
+    }
+    if isDefault {
+        l.defaultRoute.sinks = append(l.defaultRoute.sinks, len(l.sinks))
+    }
+    l.sinks = append(l.sinks, s)
+}
+
+// openSinks opens the configured sinks and adds them to the logger.
+func (l *Logger) openSinks(configs []SinkConfig) error {
+    for i, config := range configs {
+        name := strings.TrimSpace(config.Name)
+        if name == "" && config.Sink != nil {
+            name = config.Sink.Name()
+        }
+        if name == "" || name == destinationFile || name == destinationConsole || name == syslogSinkName || name == etwSinkName ||
+            name == gelfSinkName || name == lokiSinkName || strings.HasPrefix(name, levelFileSinkPrefix) {
+            return fmt.Errorf("sink %d: invalid name %q", i+1, name)
+        }
+        if l.sinkIndex(name) >= 0 {
+            return fmt.Errorf("sink %d: duplicate name %q", i+1, name)
+        }
+        if config.Sink != nil {
+            l.addSink(&namedSink{Sink: config.Sink, name: name}, config.Default)
+            continue
+        }
+
+        levelValue := config.Level
+        if levelValue == nil {
+            levelValue = "info"
+        }
+        level, err := l.parseLevel(levelValue)
+        if err != nil {
+            return fmt.Errorf("sink %s: invalid log level: %v", name, err)
+        }
+        format := config.Format
+        if format == "" {
+            format = l.Config.Format
+        }
+
+        writer := config.Writer
+        var closer io.Closer
+        if writer == nil {
+            if config.Path == "" {
+                return fmt.Errorf("sink %s: path or writer is required", name)
+            }
+            file, err := os.OpenFile(config.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+            if err != nil {
+                return fmt.Errorf("sink %s: failed to open %s: %v", name, config.Path, err)
+            }
+            writer, closer = file, file
+        }
+        s := newOutputSink(name, "sink "+name, log.New(writer, "", 0), newLevel(level), format)
+        s.closer = closer
+        s.dedupe = newDedupeFilter(config.Dedupe)
+        l.addSink(s, config.Default)
+    }
+    return nil
+}
+
+// namedSink renames a custom sink after its configured name.
+type namedSink struct {
+    Sink
+    name   string
+    closed bool
+}
+
+// Name returns the configured name of the sink.
+func (s *namedSink) Name() string {
+    return s.name
+}
+
+// Close closes the custom sink once if it implements io.Closer.
+func (s *namedSink) Close() error {
+    c, ok := s.Sink.(io.Closer)
+    if !ok || s.closed {
+        return nil
+    }
+    s.closed = true
+    return c.Close()
+}
+
+// sinkIndex returns the index of the named sink in Logger.sinks, -1 if there is none.
+func (l *Logger) sinkIndex(name string) int {
+    for i, s := range l.sinks {
+        if s.Name() == name {
+            return i
+        }
+    }
+    return -1
+}
+
+// writeEntry writes the entry to a sink that is not a line sink, isolating a panicking sink.
+func writeEntry(s Sink, e Entry) (err error) {
+    guard("sink "+s.Name(), func() {
+        err = s.WriteEntry(e)
+    })
+    return err
+}
+
+// closeSinks closes the sinks implementing io.Closer.
+func closeSinks(sinks []Sink) error {
+    var errs []error
+    for _, s := range sinks {
+        if c, ok := s.(io.Closer); ok {
+            if err := c.Close(); err != nil {
+                errs = append(errs, err)
+            }
+        }
+    }
+    return errors.Join(errs...)
+}
+
+// enabled reports whether an entry at the level is written to at least one sink of the logger.
+func (l *Logger) enabled(level string, value int) bool {
+    for _, s := range l.sinks {
+        if (l.destination == "" || s.Name() == l.destination) && s.Enabled(level, value) {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,115 @@
+package logger_test
+
+import (
+    "bytes"
+    "strings"
+    "sync"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+// memorySink is a custom sink keeping the messages of the entries it receives.
+type memorySink struct {
+    mu       sync.Mutex
+    messages []string
+    closed   int
+}
+
+func (s *memorySink) Name() string                         { return "memory" }
+func (s *memorySink) Enabled(level string, value int) bool { return level == "warning" }
+func (s *memorySink) WriteEntry(e logger.Entry) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.messages = append(s.messages, e.Message)
+    return nil
+}
+func (s *memorySink) Close() error {
+    s.closed++
+    return nil
+}
+
+func TestSinks(t *testing.T) {
+    var console, errs bytes.Buffer
+    memory := &memorySink{}
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel:       "info",
+        FileFormat:      "json",
+        ConsoleOutput:   true,
+        ConsoleLevel:    "info",
+        StackTraceLevel: logger.StackTraceNone,
+        Sinks: []logger.SinkConfig{
+            {Name: "errors", Writer: &errs, Level: "error", Default: true},
+            {Sink: memory, Default: true},
+        },
+    })
+    log.ConsoleLogger.SetOutput(&console)
+
+    var names []string
+    for _, s := range log.Sinks() {
+        names = append(names, s.Name())
+    }
+    if got := strings.Join(names, ","); got != "file,console,errors,memory" {
+        t.Errorf("Unexpected sinks: %s", got)
+    }
+
+    log.Info("Started")
+    log.Warning("Slow request")
+    log.Error("Request failed")
+
+    if file := read(); !strings.Contains(file, `"message":"Started"`) || !strings.Contains(file, `"message":"Request failed"`) {
+        t.Errorf("Expected JSON entries in the file, got '%s'", file)
+    }
+    if got := console.String(); !strings.Contains(got, "[INFO] Started") || !strings.Contains(got, "[ERROR] Request failed") {
+        t.Errorf("Expected text entries on the console, got '%s'", got)
+    }
+    if got := errs.String(); strings.Count(got, "\n") != 1 || !strings.Contains(got, "[ERROR] Request failed") {
+        t.Errorf("Expected only the error in the errors sink, got '%s'", got)
+    }
+    if got := strings.Join(memory.messages, ","); got != "Slow request" {
+        t.Errorf("Expected only the warning in the custom sink, got '%s'", got)
+    }
+
+    log.Batch(func(b *logger.BatchLogger) {
+        b.Warning("Batched warning")
+        b.Error("Batched error")
+    })
+    if got := strings.Join(memory.messages, ","); got != "Slow request,Batched warning" {
+        t.Errorf("Expected the batched warning in the custom sink, got '%s'", got)
+    }
+    if got := errs.String(); !strings.Contains(got, "[ERROR] Batched error") {
+        t.Errorf("Expected the batched error in the errors sink, got '%s'", got)
+    }
+
+    log.Close()
+    log.Close()
+    if memory.closed != 1 {
+        t.Errorf("Expected the custom sink to be closed once, got %d", memory.closed)
+    }
+}
+
+func TestSinkWriterConcurrentWrites(t *testing.T) {
+    var out bytes.Buffer
+    log, err := logger.NewLogger(logger.LogConfig{
+        Sinks: []logger.SinkConfig{{Name: "buffer", Writer: &out, Default: true}},
+    })
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    defer log.Close()
+
+    var wg sync.WaitGroup
+    for i := 0; i < 8; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for j := 0; j < 100; j++ {
+                log.Info("Concurrent entry")
+            }
+        }()
+    }
+    wg.Wait()
+    if got := strings.Count(out.String(), "Concurrent entry\n"); got != 800 {
+        t.Errorf("Expected 800 entries in the writer, got %d", got)
+    }
+}
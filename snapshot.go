@@ -0,0 +1,154 @@
+package logger
+
+// currentLogger returns the global logger, initializing it with the default settings if needed.
+// Unlike reading logInstance, it does not race with InitLogger and ResetLogger.
+func currentLogger() *Logger {
+    mu.Lock()
+    l := logInstance
+    mu.Unlock()
+    if l != nil {
+        return l
+    }
+    ensureLoggerInitialized()
+    mu.Lock()
+    defer mu.Unlock()
+    return logInstance
+}
+
+// Clone returns a deep copy of the configuration: its slices, maps and pointed-to settings are
+// copied, so that changing the copy does not change the configuration of a running logger.
+// Processors, samplers, sinks and writers are shared since they are not plain data.
+//
+// Returns:
+//   - (LogConfig): Copy of the configuration.
+func (c LogConfig) Clone() LogConfig {
+    c.Processors = append([]Processor(nil), c.Processors...)
+    c.FileProcessors = append([]Processor(nil), c.FileProcessors...)
+    c.ConsoleProcessors = append([]Processor(nil), c.ConsoleProcessors...)
+    c.Transforms = append([]Transform(nil), c.Transforms...)
+    c.Escalations = append([]EscalationRule(nil), c.Escalations...)
+    c.Sinks = append([]SinkConfig(nil), c.Sinks...)
+    c.LevelWindows = append([]LevelWindow(nil), c.LevelWindows...)
+    if c.Routes != nil {
+        routes := make([]RouteRule, len(c.Routes))
+        for i, route := range c.Routes {
+            route.Sinks = append([]string(nil), route.Sinks...)
+            routes[i] = route
+        }
+        c.Routes = routes
+    }
+    if c.Syslog != nil {
+        syslog := *c.Syslog
+        c.Syslog = &syslog
+    }
+    if c.ETW != nil {
+        etw := *c.ETW
+        c.ETW = &etw
+    }
+    if c.GELF != nil {
+        gelf := *c.GELF
+        gelf.Fields = cloneMap(gelf.Fields)
+        c.GELF = &gelf
+    }
+    if c.Loki != nil {
+        loki := *c.Loki
+        loki.Labels = cloneMap(loki.Labels)
+        loki.Headers = cloneMap(loki.Headers)
+        c.Loki = &loki
+    }
+    if c.Sampling != nil {
+        sampling := *c.Sampling
+        c.Sampling = &sampling
+    }
+    if c.Repeats != nil {
+        repeats := *c.Repeats
+        c.Repeats = &repeats
+    }
+    if c.Redaction != nil {
+        redaction := RedactionConfig{
+            Fields:   append([]string(nil), c.Redaction.Fields...),
+            Patterns: append([]string(nil), c.Redaction.Patterns...),
+        }
+        c.Redaction = &redaction
+    }
+    if c.Hashing != nil {
+        hashing := HashingConfig{Fields: append([]string(nil), c.Hashing.Fields...), Salt: c.Hashing.Salt}
+        c.Hashing = &hashing
+    }
+    if c.Diagnostics != nil {
+        diagnostics := *c.Diagnostics
+        c.Diagnostics = &diagnostics
+    }
+    c.LevelFiles = cloneMap(c.LevelFiles)
+    c.ColorStyles = cloneMap(c.ColorStyles)
+    c.LevelNames = cloneMap(c.LevelNames)
+    c.LoggerLevels = cloneMap(c.LoggerLevels)
+    if c.DebugTargets != nil {
+        targets := make(map[string][]string, len(c.DebugTargets))
+        for key, values := range c.DebugTargets {
+            targets[key] = append([]string(nil), values...)
+        }
+        c.DebugTargets = targets
+    }
+    return c
+}
+
+// cloneMap returns a copy of the map, nil for a nil map.
+func cloneMap[K comparable, V any](m map[K]V) map[K]V {
+    if m == nil {
+        return nil
+    }
+    c := make(map[K]V, len(m))
+    for k, v := range m {
+        c[k] = v
+    }
+    return c
+}
+
+// GetConfig returns a deep copy of the configuration of the logger, see LogConfig.Clone.
+//
+// Returns:
+//   - (LogConfig): Copy of the logger configuration.
+func (l *Logger) GetConfig() LogConfig {
+    return l.Config.Clone()
+}
+
+// EffectiveLevels returns the global logger's effective numeric levels of the sinks, see
+// (*Logger).EffectiveLevels.
+//
+// Returns:
+//   - (map[string]Level): Levels by sink name.
+func EffectiveLevels() map[string]Level {
+    l := currentLogger()
+    if l == nil {
+        return map[string]Level{}
+    }
+    return l.EffectiveLevels()
+}
+
+// EffectiveLevels returns the levels the sinks currently write at, by sink name: the level set for
+// the sink, or the burst level while a burst extends it. Custom sinks, whose level is decided by
+// their Enabled method, are not included.
+//
+// Returns:
+//   - (map[string]Level): Levels by sink name.
+func (l *Logger) EffectiveLevels() map[string]Level {
+    levels := make(map[string]Level, len(l.sinks))
+    for _, s := range l.sinks {
+        switch s := s.(type) {
+        case *outputSink:
+            value := int(s.level.Load())
+            if b := s.burst; b != nil && b.level > value && b.allows(b.level) {
+                value = b.level
+            }
+            levels[s.name] = Level(value)
+        case *syslogSink:
+            levels[syslogSinkName] = Level(s.level)
+        case *gelfSink:
+            levels[gelfSinkName] = Level(s.level)
+        case *lokiSink:
+            levels[lokiSinkName] = Level(s.level)
+        }
+    }
+    return levels
+}
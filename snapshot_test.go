@@ -0,0 +1,79 @@
+package logger_test
+
+import (
+    "path/filepath"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+func TestConfigSnapshot(t *testing.T) {
+    defer logger.ResetLogger()
+    config := logger.LogConfig{
+        FilePath:     filepath.Join(t.TempDir(), "app.log"),
+        FileLevel:    "info",
+        LevelNames:   map[string]string{"warning": "WRN"},
+        DebugTargets: map[string][]string{"user_id": {"42"}},
+        Redaction:    &logger.RedactionConfig{Fields: []string{"token"}},
+    }
+    if err := logger.InitLogger(config); err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+
+    snapshot := logger.GetLoggerConfig()
+    snapshot.LevelNames["warning"] = "WARN"
+    snapshot.DebugTargets["user_id"][0] = "7"
+    snapshot.Redaction.Fields[0] = "secret"
+
+    current := logger.GetLoggerConfig()
+    if current.LevelNames["warning"] != "WRN" || current.DebugTargets["user_id"][0] != "42" ||
+        current.Redaction.Fields[0] != "token" {
+        t.Errorf("Expected changes to a snapshot not to change the logger configuration, got %+v", current)
+    }
+    if config.Redaction.Fields[0] != "token" {
+        t.Errorf("Expected the caller configuration to be unchanged")
+    }
+
+    // Snapshots are safe to take while the logger is re-initialized
+    var wg sync.WaitGroup
+    wg.Add(2)
+    go func() {
+        defer wg.Done()
+        for i := 0; i < 20; i++ {
+            logger.InitLogger(config)
+        }
+    }()
+    go func() {
+        defer wg.Done()
+        for i := 0; i < 200; i++ {
+            logger.GetLoggerConfig()
+            logger.State()
+        }
+    }()
+    wg.Wait()
+}
+
+func TestEffectiveLevels(t *testing.T) {
+    discardStdout(t)
+    log, _ := newFileLogger(t, logger.LogConfig{
+        FileLevel:     "info",
+        ConsoleOutput: true,
+        ConsoleLevel:  "error",
+        Burst:         logger.BurstConfig{Duration: time.Minute, Level: "debug"},
+    })
+
+    levels := log.EffectiveLevels()
+    if levels["file"] != logger.InfoLevel || levels["console"] != logger.ErrorLevel {
+        t.Errorf("Expected file INFO and console ERROR, got %v", levels)
+    }
+    if err := log.SetConsoleLevel("warning"); err != nil {
+        t.Fatalf("Failed to set the console level: %v", err)
+    }
+    log.Error("Starting a burst")
+    levels = log.EffectiveLevels()
+    if levels["console"] != logger.WarningLevel || levels["file"] != logger.DebugLevel {
+        t.Errorf("Expected console WARNING and file DEBUG during the burst, got %v", levels)
+    }
+}
@@ -0,0 +1,73 @@
+package logger
+
+import (
+    "reflect"
+    "runtime"
+    "strconv"
+    "strings"
+)
+
+// StackTraceField is the name of the field holding the stack trace of an entry, see LogConfig.StackTraceLevel.
+const StackTraceField = "stacktrace"
+
+// StackTraceNone is the LogConfig.StackTraceLevel disabling stack traces.
+const StackTraceNone = "none"
+
+// maxStackDepth is the maximum number of frames of a captured stack trace.
+const maxStackDepth = 32
+
+// stackTraceLevel returns the least severe level of the entries with a stack trace, -1 if disabled.
+func (l *Logger) stackTraceLevel(level interface{}) (int, error) {
+    if s, ok := level.(string); ok && strings.EqualFold(s, StackTraceNone) {
+        return -1, nil
+    }
+    return l.parseLevel(level)
+}
+
+// captureStack returns the stack trace of the goroutine logging an entry, starting at the function
+// calling the logger, with the function and the file:line of each frame on two lines like debug.Stack.
+func captureStack() string {
+    var pcs [maxStackDepth + 8]uintptr
+    n := runtime.Callers(2, pcs[:])
+    frames := runtime.CallersFrames(pcs[:n])
+
+    var b strings.Builder
+    inLogger, depth := true, 0
+    for more := true; more && depth < maxStackDepth; {
+        var frame runtime.Frame
+        frame, more = frames.Next()
+        // Skip the frames of the logger above the caller
+        if inLogger && strings.HasPrefix(frame.Function, loggerPackage+".") {
+            continue
+        }
+        inLogger = false
+        if b.Len() > 0 {
+            b.WriteByte('\n')
+        }
+        b.WriteString(frame.Function)
+        b.WriteString("\n\t")
+        b.WriteString(frame.File)
+        b.WriteByte(':')
+        b.WriteString(strconv.Itoa(frame.Line))
+        depth++
+    }
+    return b.String()
+}
+
+// loggerPackage is the import path of the logger package.
+var loggerPackage = reflect.TypeOf(Logger{}).PkgPath()
+
+// appendStackTrace appends the stack trace as a block of lines indented by a tab to buf.
+func appendStackTrace(buf []byte, stack string) []byte {
+    for len(stack) > 0 {
+        line := stack
+        if i := strings.IndexByte(stack, '\n'); i >= 0 {
+            line, stack = stack[:i], stack[i+1:]
+        } else {
+            stack = ""
+        }
+        buf = append(buf, "\n\t"...)
+        buf = append(buf, line...)
+    }
+    return buf
+}
@@ -0,0 +1,57 @@
+package logger_test
+
+import (
+    "encoding/json"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func failRequest(log *logger.Logger) {
+    log.Error("Request failed")
+}
+
+func TestStackTrace(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info"})
+    failRequest(log)
+    log.Warning("Below the stack trace level")
+
+    lines := strings.Split(strings.TrimSpace(read()), "\n")
+    if len(lines) < 4 || !strings.HasSuffix(lines[0], "[ERROR] Request failed") {
+        t.Fatalf("Expected the error followed by its stack trace, got %v", lines)
+    }
+    if lines[1] != "\tgithub.com/nir0k/logger_test.failRequest" || !strings.HasPrefix(lines[2], "\t\t") ||
+        !strings.Contains(lines[2], "stacktrace_test.go:12") {
+        t.Errorf("Expected the stack trace to start at the caller, got %q", lines[1:3])
+    }
+    if last := lines[len(lines)-1]; !strings.HasSuffix(last, "[WARNING] Below the stack trace level") {
+        t.Errorf("Expected no stack trace for warnings, got %q", last)
+    }
+}
+
+func TestStackTraceJSON(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{Format: "json", FileLevel: "info", StackTraceLevel: "warning"})
+    log.Warning("Slow query")
+
+    var entry map[string]interface{}
+    if err := json.Unmarshal([]byte(read()), &entry); err != nil {
+        t.Fatalf("Failed to parse entry: %v", err)
+    }
+    stack, _ := entry[logger.StackTraceField].(string)
+    if !strings.HasPrefix(stack, "github.com/nir0k/logger_test.TestStackTraceJSON\n\t") {
+        t.Errorf("Unexpected stack trace: %q", stack)
+    }
+}
+
+func TestStackTraceDisabled(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info", StackTraceLevel: logger.StackTraceNone})
+    log.Error("Request failed")
+    if file := read(); strings.Count(file, "\n") != 1 {
+        t.Errorf("Expected no stack trace, got '%s'", file)
+    }
+
+    if _, err := logger.NewLogger(logger.LogConfig{StackTraceLevel: "loud"}); err == nil {
+        t.Errorf("Expected an invalid stack trace level to be rejected")
+    }
+}
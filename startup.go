@@ -0,0 +1,66 @@
+package logger
+
+import "fmt"
+
+// startupHolder is the logger holding the entries logged before InitLogger, see HoldStartupLogs.
+// It is protected by mu.
+var startupHolder *Logger
+
+// HoldStartupLogs keeps the entries logged through the global logger before InitLogger in memory,
+// instead of writing them to the console of a default logger, and writes them to the outputs of the
+// logger created by the next InitLogger. Windows services and daemons have no console at startup,
+// and programs often read their logging configuration only after logging; this keeps their early
+// entries. Up to size entries are held, the oldest are dropped first. FATAL entries are written to
+// the console right away since the process exits. If InitLogger fails, the entries stay held.
+//
+// Arguments:
+//   - size (int): Maximum number of held entries.
+//
+// Returns:
+//   - error: Error if the global logger is already initialized or size is not positive.
+func HoldStartupLogs(size int) error {
+    if size <= 0 {
+        return fmt.Errorf("invalid startup log size: %d", size)
+    }
+    mu.Lock()
+    defer mu.Unlock()
+    if logInstance != nil {
+        return fmt.Errorf("logger is already initialized")
+    }
+    l, err := NewLogger(LogConfig{
+        FileLevel:      "fatal",
+        ConsoleLevel:   "fatal",
+        ConsoleOutput:  true,
+        RingBufferSize: size,
+    })
+    if err != nil {
+        return err
+    }
+    logInstance, startupHolder = l, l
+    return nil
+}
+
+// replay writes entries held before the logger was created to the outputs allowing their levels,
+// after the processors of the logger, and records them in its ring buffer.
+func (l *Logger) replay(entries []Entry) {
+    for _, e := range entries {
+        value, ok := l.LogLevelMap[e.Level]
+        if !ok && e.Level != "print" {
+            continue
+        }
+        passes, _, toRing, _ := l.admit(e.Level, value)
+        if !passes && !toRing {
+            continue
+        }
+        e, keep := applyProcessors("processor", l.processors, e)
+        if !keep {
+            continue
+        }
+        if toRing {
+            l.ring.add(e)
+        }
+        if passes {
+            l.writeOutputs(e, e.Level, value, false)
+        }
+    }
+}
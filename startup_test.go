@@ -0,0 +1,51 @@
+package logger_test
+
+import (
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestHoldStartupLogs(t *testing.T) {
+    discardStdout(t)
+    logger.ResetLogger()
+    defer logger.ResetLogger()
+    if err := logger.HoldStartupLogs(10); err != nil {
+        t.Fatalf("Failed to hold startup logs: %v", err)
+    }
+    if err := logger.HoldStartupLogs(10); err == nil {
+        t.Errorf("Expected an error while the global logger is initialized")
+    }
+
+    logger.Debug("Reading configuration")
+    logger.Info("Service starting")
+
+    // A failed initialization keeps the entries held
+    if err := logger.InitLogger(logger.LogConfig{FileLevel: "verbose"}); err == nil {
+        t.Fatalf("Expected an invalid configuration to fail")
+    }
+    logger.Warning("Configuration retried")
+
+    path := filepath.Join(t.TempDir(), "service.log")
+    if err := logger.InitLogger(logger.LogConfig{FilePath: path, FileLevel: "info"}); err != nil {
+        t.Fatalf("Failed to initialize logger: %v", err)
+    }
+    logger.Info("Service started")
+
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatalf("Failed to read log file: %v", err)
+    }
+    output := string(data)
+    starting, retried, started := strings.Index(output, "[INFO] Service starting"),
+        strings.Index(output, "[WARNING] Configuration retried"), strings.Index(output, "[INFO] Service started")
+    if starting < 0 || retried < starting || started < retried {
+        t.Errorf("Expected the held entries first, in order, got %q", output)
+    }
+    if strings.Contains(output, "Reading configuration") {
+        t.Errorf("Expected held entries below the file level to be dropped, got %q", output)
+    }
+}
@@ -0,0 +1,135 @@
+package logger
+
+import (
+    "strconv"
+    "sync"
+    "time"
+)
+
+// LoggerState is a snapshot of the runtime state of a logger, for health endpoints and admin UIs.
+type LoggerState struct {
+    FileLevel     string      // Current level of the file output, see SetFileLevel.
+    ConsoleLevel  string      // Current level of the console output, see SetConsoleLevel.
+    Verbosity     int         // Current maximum verbosity of V, see SetVerbosity.
+    Sinks         []SinkState // State of each sink, see (*Logger).Sinks.
+    QueueDepth    int         // Entries waiting for the background writer in async mode, in both lanes.
+    QueueCapacity int         // Capacity of the queue of the background writer, and of its priority lane, 0 unless async.
+    LastRotation  time.Time   // Time of the last rotation of a log file, zero if none since the start.
+    Disabled      bool        // Whether output is turned off, see Disable.
+}
+
+// SinkState is the state of a sink.
+type SinkState struct {
+    Name          string    // Name of the sink.
+    Level         string    // Current level of the sink, empty for custom sinks.
+    Connected     bool      // Whether the file or the connection of the sink is open.
+    LastError     string    // Last write error of the sink, empty if none.
+    LastErrorTime time.Time // Time of the last write error, zero if none.
+}
+
+// sinkStater is implemented by sinks reporting their state.
+type sinkStater interface {
+    state() SinkState
+}
+
+// connectionState is implemented by writers that can be closed or disconnected.
+type connectionState interface {
+    connected() bool
+}
+
+// sinkHealth records the last write error of a sink.
+type sinkHealth struct {
+    mu      sync.Mutex
+    lastErr error
+    errTime time.Time
+}
+
+// record records a write error, ignoring nil.
+func (h *sinkHealth) record(err error) {
+    if err == nil {
+        return
+    }
+    h.mu.Lock()
+    h.lastErr, h.errTime = err, time.Now()
+    h.mu.Unlock()
+}
+
+// fill sets the error fields of the state.
+func (h *sinkHealth) fill(state *SinkState) {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    if h.lastErr != nil {
+        state.LastError, state.LastErrorTime = h.lastErr.Error(), h.errTime
+    }
+}
+
+// State returns the runtime state of the global logger, see (*Logger).State.
+//
+// Returns:
+//   - (LoggerState): Snapshot of the logger state.
+func State() LoggerState {
+    l := currentLogger()
+    if l == nil {
+        return LoggerState{}
+    }
+    return l.State()
+}
+
+// State returns a snapshot of the current levels, the health of the sinks, the depth of the async
+// queue and the time of the last rotation.
+//
+// Returns:
+//   - (LoggerState): Snapshot of the logger state.
+func (l *Logger) State() LoggerState {
+    state := LoggerState{
+        FileLevel:    levelName(int(l.levels.file.Load())),
+        ConsoleLevel: levelName(int(l.levels.console.Load())),
+        Verbosity:    int(l.levels.verbosity.Load()),
+        Disabled:     outputDisabled.Load() || l.Config.Disabled,
+    }
+    for _, s := range l.sinks {
+        if stater, ok := s.(sinkStater); ok {
+            state.Sinks = append(state.Sinks, stater.state())
+        } else {
+            state.Sinks = append(state.Sinks, SinkState{Name: s.Name(), Connected: true})
+        }
+    }
+    if l.async != nil {
+        state.QueueDepth = len(l.async.queue) + len(l.async.urgent)
+        state.QueueCapacity = cap(l.async.queue)
+    }
+    for _, file := range l.files {
+        if t := file.lastRotation(); t.After(state.LastRotation) {
+            state.LastRotation = t
+        }
+    }
+    return state
+}
+
+// builtinLevels are the names of the built-in levels.
+var builtinLevels = map[string]struct{}{
+    "trace": {}, "debug": {}, "info": {}, "warning": {}, "error": {}, "fatal": {},
+}
+
+// levelName returns the name of the level value, preferring built-in levels over custom levels
+// sharing the value.
+func levelName(value int) string {
+    levelsMu.RLock()
+    defer levelsMu.RUnlock()
+    name := ""
+    for n, info := range levels {
+        if info.value != value {
+            continue
+        }
+        if _, builtin := builtinLevels[n]; builtin {
+            return n
+        }
+        if name == "" || n < name {
+            name = n
+        }
+    }
+    if name == "" {
+        return strconv.Itoa(value)
+    }
+    return name
+}
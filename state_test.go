@@ -0,0 +1,69 @@
+package logger_test
+
+import (
+    "errors"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+    return 0, errors.New("disk full")
+}
+
+func TestState(t *testing.T) {
+    discardStdout(t)
+    now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    log, err := logger.NewLogger(logger.LogConfig{
+        FilePath:       filepath.Join(t.TempDir(), "app.log"),
+        FileLevel:      "info",
+        ConsoleLevel:   "error",
+        EnableRotation: true,
+        Async:          true,
+        QueueSize:      16,
+        Sinks:          []logger.SinkConfig{{Name: "broken", Writer: failingWriter{}, Level: "warning", Default: true}},
+        FileSink: logger.FileSinkConfig{
+            RotationHooks: logger.RotationHooks{Now: func() time.Time { return now }, MaxSizeBytes: 300, Synchronous: true},
+        },
+    })
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    defer log.Close()
+
+    log.SetFileLevel("debug")
+    log.Warning(strings.Repeat("A", 150))
+    log.Warning(strings.Repeat("B", 150))
+    log.Flush()
+
+    state := log.State()
+    if state.FileLevel != "debug" || state.ConsoleLevel != "error" {
+        t.Errorf("Unexpected levels: %+v", state)
+    }
+    if state.QueueCapacity != 16 || state.QueueDepth != 0 {
+        t.Errorf("Unexpected queue state: %d/%d", state.QueueDepth, state.QueueCapacity)
+    }
+    if !state.LastRotation.Equal(now) {
+        t.Errorf("Expected the last rotation at %v, got %v", now, state.LastRotation)
+    }
+    if len(state.Sinks) != 2 {
+        t.Fatalf("Expected 2 sinks, got %+v", state.Sinks)
+    }
+    if file := state.Sinks[0]; file.Name != "file" || file.Level != "debug" || !file.Connected || file.LastError != "" {
+        t.Errorf("Unexpected file sink state: %+v", file)
+    }
+    if broken := state.Sinks[1]; broken.Name != "broken" || broken.Level != "warning" || broken.LastError != "disk full" || broken.LastErrorTime.IsZero() {
+        t.Errorf("Unexpected broken sink state: %+v", broken)
+    }
+
+    log.Close()
+    if state := log.State(); state.Sinks[0].Connected {
+        t.Error("Expected the file sink to be disconnected after Close")
+    }
+}
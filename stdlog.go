@@ -0,0 +1,59 @@
+package logger
+
+import (
+    "io"
+    "log"
+)
+
+// RedirectStdLog points the output of the standard library log package at the global logger, so that
+// libraries logging with log.Printf reach the file, rotation and sinks of the logger, see
+// (*Logger).RedirectStdLog.
+//
+// Arguments:
+//   - level (string): Level of the redirected lines; "fatal" is not allowed.
+//
+// Returns:
+//   - (func()): Function restoring the previous output, flags and prefix of the log package.
+func RedirectStdLog(level string) func() {
+    w, err := WriterLevel(level)
+    if err != nil {
+        reportError("stdlog", err)
+        return func() {}
+    }
+    return redirectStdLog(w)
+}
+
+// RedirectStdLog points the output of the standard library log package at the logger, logging every
+// line at the level. The flags and prefix of the log package are cleared while redirected, since
+// entries carry their own time and caller. An invalid level is reported to the error handler and
+// leaves the log package unchanged.
+//
+// Arguments:
+//   - level (string): Level of the redirected lines; "fatal" is not allowed.
+//
+// Returns:
+//   - (func()): Function restoring the previous output, flags and prefix of the log package.
+func (l *Logger) RedirectStdLog(level string) func() {
+    w, err := l.WriterLevel(level)
+    if err != nil {
+        reportError("stdlog", err)
+        return func() {}
+    }
+    return redirectStdLog(w)
+}
+
+// redirectStdLog sets the writer as the output of the log package and returns the restore function.
+func redirectStdLog(w *LineWriter) func() {
+    output, flags, prefix := log.Writer(), log.Flags(), log.Prefix()
+    log.SetOutput(w)
+    log.SetFlags(0)
+    log.SetPrefix("")
+    return func() {
+        if log.Writer() == io.Writer(w) {
+            log.SetOutput(output)
+            log.SetFlags(flags)
+            log.SetPrefix(prefix)
+        }
+        w.Flush()
+    }
+}
@@ -0,0 +1,42 @@
+package logger_test
+
+import (
+    "bytes"
+    "log"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestRedirectStdLog(t *testing.T) {
+    l, read := newFileLogger(t, logger.LogConfig{FileLevel: "info"})
+    original := log.Writer()
+    defer log.SetOutput(original)
+    var previous bytes.Buffer
+    log.SetOutput(&previous)
+
+    restore := l.RedirectStdLog("warning")
+    log.Printf("Retrying request %d", 3)
+    restore()
+    log.Print("After restore")
+
+    if output := read(); !strings.Contains(output, "[WARNING] Retrying request 3\n") || strings.Contains(output, "After restore") {
+        t.Errorf("Expected only the redirected line in the log file, got %q", output)
+    }
+    if !strings.Contains(previous.String(), "After restore") {
+        t.Errorf("Expected the previous output to be restored, got %q", previous.String())
+    }
+}
+
+func TestRedirectStdLogInvalidLevel(t *testing.T) {
+    l, _ := newFileLogger(t, logger.LogConfig{})
+    var reported error
+    logger.SetErrorHandler(func(component string, err error) { reported = err })
+    defer logger.SetErrorHandler(nil)
+
+    l.RedirectStdLog("fatal")()
+    if reported == nil {
+        t.Errorf("Expected the invalid level to be reported")
+    }
+}
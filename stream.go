@@ -0,0 +1,218 @@
+package logger
+
+import (
+    "bufio"
+    "crypto/sha1"
+    "encoding/base64"
+    "encoding/binary"
+    "fmt"
+    "io"
+    "net"
+    "net/http"
+    "strings"
+)
+
+// websocketGUID is the key suffix defined by RFC 6455 for the handshake.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// StreamHandler returns the live streaming endpoint of the global logger, see (*Logger).StreamHandler.
+//
+// Returns:
+//   - (http.Handler): Streaming handler.
+func StreamHandler() http.Handler {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return http.NotFoundHandler()
+    }
+    return logInstance.StreamHandler()
+}
+
+// StreamHandler returns an HTTP handler streaming live entries as JSON, using WebSocket text frames
+// when the request asks for a WebSocket upgrade and Server-Sent Events otherwise, so dashboards can
+// subscribe to the logs of the service itself. Entries can be filtered with query parameters:
+//   - level: minimum level, e.g. level=warning;
+//   - field: required field value as key:value, can be repeated, e.g. field=component:db.
+//
+// Returns:
+//   - (http.Handler): Streaming handler.
+func (l *Logger) StreamHandler() http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        filter, err := l.streamFilter(r)
+        if err != nil {
+            http.Error(w, err.Error(), http.StatusBadRequest)
+            return
+        }
+        if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+            l.streamWebSocket(w, r, filter)
+            return
+        }
+        l.streamSSE(w, r, filter)
+    })
+}
+
+// streamFilter builds the entry filter from the query parameters of the request.
+func (l *Logger) streamFilter(r *http.Request) (func(Entry) bool, error) {
+    query := r.URL.Query()
+    maxLevel := -1
+    if level := query.Get("level"); level != "" {
+        value, ok := l.LogLevelMap[strings.ToLower(level)]
+        if !ok {
+            return nil, fmt.Errorf("invalid log level: %s", level)
+        }
+        maxLevel = value
+    }
+    required := map[string]string{}
+    for _, field := range query["field"] {
+        key, value, ok := strings.Cut(field, ":")
+        if !ok {
+            return nil, fmt.Errorf("invalid field filter, expected key:value: %s", field)
+        }
+        required[key] = value
+    }
+
+    return func(e Entry) bool {
+        if maxLevel >= 0 {
+            if value, ok := l.LogLevelMap[e.Level]; ok && value > maxLevel {
+                return false
+            }
+        }
+        for key, value := range required {
+            if fmt.Sprint(e.Fields[key]) != value {
+                return false
+            }
+        }
+        return true
+    }, nil
+}
+
+// streamSSE streams entries as Server-Sent Events until the client disconnects.
+func (l *Logger) streamSSE(w http.ResponseWriter, r *http.Request, filter func(Entry) bool) {
+    flusher, ok := w.(http.Flusher)
+    if !ok {
+        http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+        return
+    }
+    entries, cancel := l.Subscribe(filter)
+    defer cancel()
+
+    w.Header().Set("Content-Type", "text/event-stream")
+    w.Header().Set("Cache-Control", "no-cache")
+    w.Header().Set("Connection", "keep-alive")
+    w.WriteHeader(http.StatusOK)
+    flusher.Flush()
+
+    for {
+        select {
+        case <-r.Context().Done():
+            return
+        case entry := <-entries:
+            if _, err := fmt.Fprintf(w, "event: entry\ndata: %s\n\n", entry.formatJSON()); err != nil {
+                return
+            }
+            flusher.Flush()
+        }
+    }
+}
+
+// streamWebSocket upgrades the connection to a WebSocket and streams entries as text frames
+// until the client closes the connection.
+func (l *Logger) streamWebSocket(w http.ResponseWriter, r *http.Request, filter func(Entry) bool) {
+    key := r.Header.Get("Sec-WebSocket-Key")
+    if key == "" || !strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade") {
+        http.Error(w, "invalid websocket handshake", http.StatusBadRequest)
+        return
+    }
+    hijacker, ok := w.(http.Hijacker)
+    if !ok {
+        http.Error(w, "websocket is not supported", http.StatusInternalServerError)
+        return
+    }
+    conn, rw, err := hijacker.Hijack()
+    if err != nil {
+        return
+    }
+    defer conn.Close()
+
+    entries, cancel := l.Subscribe(filter)
+    defer cancel()
+
+    sum := sha1.Sum([]byte(key + websocketGUID))
+    fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n",
+        base64.StdEncoding.EncodeToString(sum[:]))
+    if err := rw.Flush(); err != nil {
+        return
+    }
+
+    closed := make(chan struct{})
+    go func() {
+        defer close(closed)
+        readWebSocketUntilClose(rw.Reader)
+    }()
+
+    for {
+        select {
+        case <-closed:
+            writeWebSocketFrame(conn, 0x8, nil)
+            return
+        case entry := <-entries:
+            if err := writeWebSocketFrame(conn, 0x1, []byte(entry.formatJSON())); err != nil {
+                return
+            }
+        }
+    }
+}
+
+// writeWebSocketFrame writes a single unmasked server frame with the given opcode.
+func writeWebSocketFrame(conn net.Conn, opcode byte, payload []byte) error {
+    header := []byte{0x80 | opcode}
+    switch n := len(payload); {
+    case n < 126:
+        header = append(header, byte(n))
+    case n <= 0xFFFF:
+        header = append(header, 126, 0, 0)
+        binary.BigEndian.PutUint16(header[2:], uint16(n))
+    default:
+        header = append(header, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+        binary.BigEndian.PutUint64(header[2:], uint64(n))
+    }
+    if _, err := conn.Write(header); err != nil {
+        return err
+    }
+    _, err := conn.Write(payload)
+    return err
+}
+
+// readWebSocketUntilClose reads and discards client frames until a close frame or a read error.
+func readWebSocketUntilClose(r *bufio.Reader) {
+    header := make([]byte, 2)
+    for {
+        if _, err := io.ReadFull(r, header); err != nil {
+            return
+        }
+        opcode := header[0] & 0x0F
+        length := uint64(header[1] & 0x7F)
+        switch length {
+        case 126:
+            ext := make([]byte, 2)
+            if _, err := io.ReadFull(r, ext); err != nil {
+                return
+            }
+            length = uint64(binary.BigEndian.Uint16(ext))
+        case 127:
+            ext := make([]byte, 8)
+            if _, err := io.ReadFull(r, ext); err != nil {
+                return
+            }
+            length = binary.BigEndian.Uint64(ext)
+        }
+        if header[1]&0x80 != 0 {
+            length += 4 // Masking key
+        }
+        if _, err := io.CopyN(io.Discard, r, int64(length)); err != nil {
+            return
+        }
+        if opcode == 0x8 {
+            return
+        }
+    }
+}
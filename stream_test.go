@@ -0,0 +1,89 @@
+package logger_test
+
+import (
+    "bufio"
+    "context"
+    "fmt"
+    "io"
+    "net"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+func TestStreamHandlerSSE(t *testing.T) {
+    log, _ := newFileLogger(t, logger.LogConfig{FileLevel: "debug"})
+    server := httptest.NewServer(log.StreamHandler())
+    defer server.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+    req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"?level=warning&field=component:db", nil)
+    resp, err := http.DefaultClient.Do(req)
+    if err != nil {
+        t.Fatalf("Request failed: %v", err)
+    }
+    defer resp.Body.Close()
+    if resp.Header.Get("Content-Type") != "text/event-stream" {
+        t.Fatalf("Unexpected content type: %s", resp.Header.Get("Content-Type"))
+    }
+
+    db := log.WithField("component", "db")
+    db.Info("Below the level filter")
+    log.Error("Without the component field")
+    db.Error("Streamed error")
+
+    reader := bufio.NewReader(resp.Body)
+    for {
+        line, err := reader.ReadString('\n')
+        if err != nil {
+            t.Fatalf("Failed to read event: %v", err)
+        }
+        if strings.HasPrefix(line, "data: ") {
+            if !strings.Contains(line, "Streamed error") {
+                t.Errorf("Unexpected event: %s", line)
+            }
+            return
+        }
+    }
+}
+
+func TestStreamHandlerWebSocket(t *testing.T) {
+    log, _ := newFileLogger(t, logger.LogConfig{FileLevel: "debug"})
+    server := httptest.NewServer(log.StreamHandler())
+    defer server.Close()
+
+    conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+    if err != nil {
+        t.Fatalf("Failed to connect: %v", err)
+    }
+    defer conn.Close()
+    conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+    fmt.Fprintf(conn, "GET / HTTP/1.1\r\nHost: test\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+        "Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n")
+    reader := bufio.NewReader(conn)
+    resp, err := http.ReadResponse(reader, nil)
+    if err != nil {
+        t.Fatalf("Failed to read handshake: %v", err)
+    }
+    if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Sec-WebSocket-Accept") != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+        t.Fatalf("Unexpected handshake response: %v %v", resp.Status, resp.Header)
+    }
+
+    log.Info("WebSocket message")
+
+    header := make([]byte, 2)
+    if _, err := io.ReadFull(reader, header); err != nil {
+        t.Fatalf("Failed to read frame: %v", err)
+    }
+    payload := make([]byte, header[1]&0x7F)
+    io.ReadFull(reader, payload)
+    if header[0] != 0x81 || !strings.Contains(string(payload), "WebSocket message") {
+        t.Errorf("Unexpected frame: %x %s", header, payload)
+    }
+}
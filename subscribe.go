@@ -0,0 +1,110 @@
+package logger
+
+import (
+    "sync"
+    "sync/atomic"
+)
+
+// SubscriberBufferSize is the channel buffer size of new subscriptions. Entries are dropped
+// for a subscriber whose buffer is full, so a slow subscriber never blocks logging.
+var SubscriberBufferSize = 256
+
+// globalHub holds the subscribers and hooks of the global logger; it survives re-initialization with InitLogger.
+var globalHub = &entryHub{}
+
+// entryHub distributes logged entries to hooks and subscribers.
+type entryHub struct {
+    mu          sync.RWMutex
+    subscribers map[*subscriber]struct{}
+    hooks       []*hook // Hooks added with AddHook, in order.
+}
+
+// subscriber is a single subscription created by Subscribe.
+type subscriber struct {
+    ch      chan Entry
+    filter  func(Entry) bool
+    dropped atomic.Uint64
+}
+
+// Subscribe subscribes to entries logged through the global logger, also across re-initialization
+// with InitLogger, see (*Logger).Subscribe.
+//
+// Arguments:
+//   - filter (func(Entry) bool): Function selecting the entries to receive, nil for all entries.
+//
+// Returns:
+//   - (<-chan Entry): Channel receiving the entries.
+//   - (func()): Function canceling the subscription and closing the channel.
+func Subscribe(filter func(Entry) bool) (<-chan Entry, func()) {
+    return globalHub.subscribe(filter)
+}
+
+// Subscribe returns a channel receiving the entries logged through the logger (and loggers derived
+// from it with WithFields) that pass level filtering for at least one output and the given filter.
+// This lets in-process components such as debug UIs, test assertions or self-monitoring observe entries
+// without parsing files. Delivery never blocks logging: entries are dropped if the channel is full.
+//
+// Arguments:
+//   - filter (func(Entry) bool): Function selecting the entries to receive, nil for all entries.
+//
+// Returns:
+//   - (<-chan Entry): Channel receiving the entries.
+//   - (func()): Function canceling the subscription and closing the channel.
+func (l *Logger) Subscribe(filter func(Entry) bool) (<-chan Entry, func()) {
+    return l.hub.subscribe(filter)
+}
+
+// subscribe adds a subscriber to the hub.
+func (h *entryHub) subscribe(filter func(Entry) bool) (<-chan Entry, func()) {
+    s := &subscriber{ch: make(chan Entry, SubscriberBufferSize), filter: filter}
+    h.mu.Lock()
+    if h.subscribers == nil {
+        h.subscribers = make(map[*subscriber]struct{})
+    }
+    h.subscribers[s] = struct{}{}
+    h.mu.Unlock()
+
+    var once sync.Once
+    cancel := func() {
+        once.Do(func() {
+            h.mu.Lock()
+            delete(h.subscribers, s)
+            h.mu.Unlock()
+            close(s.ch)
+        })
+    }
+    return s.ch, cancel
+}
+
+// publish calls the matching hooks, then delivers the entry to all matching subscribers without blocking.
+func (h *entryHub) publish(entry Entry) {
+    if h == nil {
+        return
+    }
+    h.mu.RLock()
+    idle := len(h.hooks) == 0 && len(h.subscribers) == 0
+    h.mu.RUnlock()
+    if idle {
+        return
+    }
+    // Hooks and subscribers may keep the entry
+    entry = entry.retain()
+    h.runHooks(entry)
+    h.mu.RLock()
+    defer h.mu.RUnlock()
+    for s := range h.subscribers {
+        if s.filter != nil {
+            keep := false
+            guard("subscriber filter", func() { keep = s.filter(entry) })
+            if !keep {
+                continue
+            }
+        }
+        select {
+        case s.ch <- entry:
+        default:
+            s.dropped.Add(1)
+            diagnose(1, DebugLevel, "subscriber", "Entry dropped, subscriber channel is full")
+        }
+    }
+}
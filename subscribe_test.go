@@ -0,0 +1,46 @@
+package logger_test
+
+import (
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestSubscribe(t *testing.T) {
+    log, _ := newFileLogger(t, logger.LogConfig{FileLevel: "debug"})
+
+    errors, cancel := log.Subscribe(func(e logger.Entry) bool { return e.Level == "error" })
+    log.WithField("component", "db").Error("Connection lost")
+    log.Info("Not an error")
+    log.Trace("Filtered by level")
+    cancel()
+    cancel() // Safe to call twice
+
+    var entries []logger.Entry
+    for e := range errors {
+        entries = append(entries, e)
+    }
+    if len(entries) != 1 {
+        t.Fatalf("Expected 1 entry, got %d: %+v", len(entries), entries)
+    }
+    if entries[0].Message != "Connection lost" || entries[0].Fields["component"] != "db" {
+        t.Errorf("Unexpected entry: %+v", entries[0])
+    }
+}
+
+func TestSubscribeGlobalAcrossInit(t *testing.T) {
+    defer resetLogger()
+    entries, cancel := logger.Subscribe(nil)
+    defer cancel()
+
+    logger.InitLogger(logger.LogConfig{ConsoleLevel: "info", ConsoleOutput: false})
+    logger.InitLogger(logger.LogConfig{FileLevel: "info", ConsoleOutput: false})
+    log, _ := newFileLogger(t, logger.LogConfig{FileLevel: "info"})
+    log.Info("Instance logger message")
+    logger.Warning("Global message")
+
+    e := <-entries
+    if e.Message != "Global message" {
+        t.Errorf("Expected only entries of the global logger, got '%s'", e.Message)
+    }
+}
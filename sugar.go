@@ -0,0 +1,180 @@
+package logger
+
+import (
+    "fmt"
+    "os"
+)
+
+// BadKey is the field name of a key/value argument that is not a key, such as the last argument of
+// an odd number of arguments.
+const BadKey = "!BADKEY"
+
+// sweeten converts alternating keys and values into fields, like the sugared logger of zap: keys
+// that are not strings are formatted with fmt.Sprint, and a final key without a value is kept
+// under BadKey.
+func sweeten(keysAndValues []interface{}) Fields {
+    if len(keysAndValues) == 0 {
+        return nil
+    }
+    fields := make(Fields, (len(keysAndValues)+1)/2)
+    for i := 0; i < len(keysAndValues); i += 2 {
+        if i+1 == len(keysAndValues) {
+            fields[BadKey] = keysAndValues[i]
+            break
+        }
+        key, ok := keysAndValues[i].(string)
+        if !ok {
+            key = fmt.Sprint(keysAndValues[i])
+        }
+        fields[key] = keysAndValues[i+1]
+    }
+    return fields
+}
+
+// logw logs the message at the level with the fields of the alternating keys and values. The fields
+// are only built for entries that are recorded, including in the ring buffer, unless debug targets
+// or escalation rules, which depend on them, are set.
+func (l *Logger) logw(level, msg string, keysAndValues []interface{}) {
+    if l.base != nil {
+        if current := l.rebase(); current != nil {
+            l = current
+        }
+    }
+    if !l.IsLevelEnabled(level) && !l.ring.accepts(level, l.LogLevelMap[level]) && !l.targets.active() && len(l.escalations) == 0 {
+        return
+    }
+    if fields := sweeten(keysAndValues); fields != nil {
+        l = l.WithFields(fields)
+    }
+    l.logSkip(4, level, msg)
+}
+
+// Tracew logs a message at the TRACE level with fields from alternating keys and values.
+//
+// Arguments:
+//   - msg (string): Message to log.
+//   - keysAndValues (...interface{}): Alternating field names and values.
+func Tracew(msg string, keysAndValues ...interface{}) {
+    ensureLoggerInitialized()
+    if logInstance != nil {
+        logInstance.Tracew(msg, keysAndValues...)
+    }
+}
+
+// Debugw logs a message at the DEBUG level with fields from alternating keys and values.
+//
+// Arguments:
+//   - msg (string): Message to log.
+//   - keysAndValues (...interface{}): Alternating field names and values.
+func Debugw(msg string, keysAndValues ...interface{}) {
+    ensureLoggerInitialized()
+    if logInstance != nil {
+        logInstance.Debugw(msg, keysAndValues...)
+    }
+}
+
+// Infow logs a message at the INFO level with fields from alternating keys and values, e.g.
+// Infow("request served", "status", 200, "path", "/users").
+//
+// Arguments:
+//   - msg (string): Message to log.
+//   - keysAndValues (...interface{}): Alternating field names and values.
+func Infow(msg string, keysAndValues ...interface{}) {
+    ensureLoggerInitialized()
+    if logInstance != nil {
+        logInstance.Infow(msg, keysAndValues...)
+    }
+}
+
+// Warningw logs a message at the WARNING level with fields from alternating keys and values.
+//
+// Arguments:
+//   - msg (string): Message to log.
+//   - keysAndValues (...interface{}): Alternating field names and values.
+func Warningw(msg string, keysAndValues ...interface{}) {
+    ensureLoggerInitialized()
+    if logInstance != nil {
+        logInstance.Warningw(msg, keysAndValues...)
+    }
+}
+
+// Errorw logs a message at the ERROR level with fields from alternating keys and values.
+//
+// Arguments:
+//   - msg (string): Message to log.
+//   - keysAndValues (...interface{}): Alternating field names and values.
+func Errorw(msg string, keysAndValues ...interface{}) {
+    ensureLoggerInitialized()
+    if logInstance != nil {
+        logInstance.Errorw(msg, keysAndValues...)
+    }
+}
+
+// Fatalw logs a message at the FATAL level with fields from alternating keys and values and
+// terminates the application.
+//
+// Arguments:
+//   - msg (string): Message to log.
+//   - keysAndValues (...interface{}): Alternating field names and values.
+func Fatalw(msg string, keysAndValues ...interface{}) {
+    ensureLoggerInitialized()
+    if logInstance != nil {
+        logInstance.Fatalw(msg, keysAndValues...)
+    }
+}
+
+// Tracew logs a message at the TRACE level with fields from alternating keys and values.
+//
+// Arguments:
+//   - msg (string): Message to log.
+//   - keysAndValues (...interface{}): Alternating field names and values.
+func (l *Logger) Tracew(msg string, keysAndValues ...interface{}) {
+    l.logw("trace", msg, keysAndValues)
+}
+
+// Debugw logs a message at the DEBUG level with fields from alternating keys and values.
+//
+// Arguments:
+//   - msg (string): Message to log.
+//   - keysAndValues (...interface{}): Alternating field names and values.
+func (l *Logger) Debugw(msg string, keysAndValues ...interface{}) {
+    l.logw("debug", msg, keysAndValues)
+}
+
+// Infow logs a message at the INFO level with fields from alternating keys and values.
+//
+// Arguments:
+//   - msg (string): Message to log.
+//   - keysAndValues (...interface{}): Alternating field names and values.
+func (l *Logger) Infow(msg string, keysAndValues ...interface{}) {
+    l.logw("info", msg, keysAndValues)
+}
+
+// Warningw logs a message at the WARNING level with fields from alternating keys and values.
+//
+// Arguments:
+//   - msg (string): Message to log.
+//   - keysAndValues (...interface{}): Alternating field names and values.
+func (l *Logger) Warningw(msg string, keysAndValues ...interface{}) {
+    l.logw("warning", msg, keysAndValues)
+}
+
+// Errorw logs a message at the ERROR level with fields from alternating keys and values.
+//
+// Arguments:
+//   - msg (string): Message to log.
+//   - keysAndValues (...interface{}): Alternating field names and values.
+func (l *Logger) Errorw(msg string, keysAndValues ...interface{}) {
+    l.logw("error", msg, keysAndValues)
+}
+
+// Fatalw logs a message at the FATAL level with fields from alternating keys and values and terminates
+// the application.
+//
+// Arguments:
+//   - msg (string): Message to log.
+//   - keysAndValues (...interface{}): Alternating field names and values.
+func (l *Logger) Fatalw(msg string, keysAndValues ...interface{}) {
+    l.logw("fatal", msg, keysAndValues)
+    os.Exit(1)
+}
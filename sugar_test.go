@@ -0,0 +1,53 @@
+package logger_test
+
+import (
+    "encoding/json"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestSugaredLogging(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel:       "info",
+        Format:          "json",
+        StackTraceLevel: logger.StackTraceNone,
+    })
+
+    log.WithField("service", "api").Infow("Request served", "status", 200, "path", "/users")
+    log.Warningw("Odd arguments", 42, "answer", "dangling")
+    log.Debugw("Dropped", "key", "value")
+
+    lines := strings.Split(strings.TrimSpace(read()), "\n")
+    if len(lines) != 2 {
+        t.Fatalf("Expected 2 entries, got %q", lines)
+    }
+    var served, odd map[string]interface{}
+    if err := json.Unmarshal([]byte(lines[0]), &served); err != nil {
+        t.Fatalf("Invalid JSON line %q: %v", lines[0], err)
+    }
+    if served["message"] != "Request served" || served["status"] != float64(200) ||
+        served["path"] != "/users" || served["service"] != "api" {
+        t.Errorf("Expected the key/value fields, got %v", served)
+    }
+    if err := json.Unmarshal([]byte(lines[1]), &odd); err != nil {
+        t.Fatalf("Invalid JSON line %q: %v", lines[1], err)
+    }
+    if odd["42"] != "answer" || odd[logger.BadKey] != "dangling" {
+        t.Errorf("Expected a formatted key and the dangling value under %s, got %v", logger.BadKey, odd)
+    }
+}
+
+func TestSugaredLoggingDisabled(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info"})
+    if allocs := testing.AllocsPerRun(100, func() { log.Debugw("Dropped", "key", "value", "attempt", 3) }); allocs != 0 {
+        t.Errorf("Expected no allocations for a disabled level, got %.1f", allocs)
+    }
+
+    log.SetDebugTargets("user_id", "42")
+    log.Debugw("Targeted", "user_id", 42)
+    if file := read(); !strings.Contains(file, "Targeted") || strings.Contains(file, "Dropped") {
+        t.Errorf("Expected only the targeted entry, got '%s'", file)
+    }
+}
@@ -0,0 +1,87 @@
+package logger
+
+import (
+    "fmt"
+    "sync"
+    "time"
+)
+
+// SyncPolicy controls when the file output is flushed to stable storage with fsync.
+// The zero value never calls fsync and leaves flushing to the operating system; the settings
+// can be combined, e.g. every 100 entries and on every error.
+type SyncPolicy struct {
+    EveryEntries int           // Sync after this many entries, 0 disables.
+    Interval     time.Duration // Sync at this interval if entries were written since the last sync, 0 disables.
+    Level        interface{}   // Sync after every entry at this level or more severe (e.g. "error"), nil disables.
+}
+
+// enabled reports whether the policy ever syncs.
+func (p SyncPolicy) enabled() bool {
+    return p.EveryEntries > 0 || p.Interval > 0 || p.Level != nil
+}
+
+// fileSyncer applies a SyncPolicy to the files of the file output.
+type fileSyncer struct {
+    policy SyncPolicy
+    level  int
+    files  []*logFile
+
+    mu      sync.Mutex
+    pending int // Entries written since the last sync.
+}
+
+// newFileSyncer creates a syncer for the given policy and starts interval syncing if configured.
+func (l *Logger) newFileSyncer(policy SyncPolicy, files []*logFile) (*fileSyncer, error) {
+    s := &fileSyncer{policy: policy, level: -1, files: files}
+    if policy.Level != nil {
+        level, err := l.parseLevel(policy.Level)
+        if err != nil {
+            return nil, fmt.Errorf("invalid sync policy level: %v", err)
+        }
+        s.level = level
+    }
+    if policy.Interval > 0 {
+        go func() {
+            ticker := time.NewTicker(policy.Interval)
+            defer ticker.Stop()
+            for {
+                select {
+                case <-l.stop.ch:
+                    return
+                case <-ticker.C:
+                    s.mu.Lock()
+                    if s.pending > 0 {
+                        s.sync()
+                    }
+                    s.mu.Unlock()
+                }
+            }
+        }()
+    }
+    return s, nil
+}
+
+// afterWrite records a written entry and syncs if the policy requires it.
+func (s *fileSyncer) afterWrite(level string, msgLevel int) {
+    if s == nil {
+        return
+    }
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.pending++
+    byLevel := s.level >= 0 && level != "print" && msgLevel <= s.level
+    byCount := s.policy.EveryEntries > 0 && s.pending >= s.policy.EveryEntries
+    if byLevel || byCount {
+        s.sync()
+    }
+}
+
+// sync flushes all files. It must be called with s.mu held.
+func (s *fileSyncer) sync() {
+    s.pending = 0
+    for _, file := range s.files {
+        if err := file.Sync(); err != nil {
+            reportError("file sync", err)
+        }
+    }
+}
@@ -0,0 +1,242 @@
+package logger
+
+import (
+    "fmt"
+    "net"
+    "os"
+    "path/filepath"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+)
+
+// SyslogConfig configures the syslog output, which sends entries to a local syslog daemon or to a
+// remote syslog server over UDP or TCP. Messages over TCP and Unix stream sockets are framed by
+// octet counting (RFC 6587). Levels map to syslog severities: TRACE and DEBUG to debug,
+// INFO to informational, WARNING to warning, ERROR to err and FATAL to crit. Connecting and writing
+// time out after five seconds; while the server is unreachable, entries are dropped and connecting is
+// retried after a delay growing up to a minute.
+type SyslogConfig struct {
+    Network  string      // "udp", "tcp", "unix" or "unixgram"; empty for the local syslog socket.
+    Address  string      // Address of the server, e.g. "logs.example.com:514"; empty for the local syslog socket.
+    Facility string      // Syslog facility, e.g. "daemon" or "local0" (default: "user").
+    Tag      string      // Tag (APP-NAME) of the messages (default: the program name).
+    Level    interface{} // Log level of the syslog output: can be a string or a number (default: "info").
+    RFC5424  bool        // Whether messages use the RFC 5424 format instead of the BSD format of RFC 3164.
+    // Dedupe replaces consecutive messages with identical content within this window by reference
+    // records, as with SinkConfig.Dedupe; 0 disables it.
+    Dedupe time.Duration
+}
+
+// syslogSinkName is the name of the syslog output in routing rules.
+const syslogSinkName = "syslog"
+
+// syslogFacilities maps facility names to their codes.
+var syslogFacilities = map[string]int{
+    "kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5, "lpr": 6, "news": 7,
+    "uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+    "local0": 16, "local1": 17, "local2": 18, "local3": 19,
+    "local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// Local syslog sockets, tried in order.
+var syslogSockets = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// syslogSink is the syslog output. It connects on first write and reconnects after a failed write,
+// see reconnectingConn.
+type syslogSink struct {
+    config   SyslogConfig
+    facility int
+    level    int
+    hostname string
+    dedupe   *dedupeFilter // Replacement of repeated messages by reference records, nil if disabled.
+
+    mu     sync.Mutex
+    conn   *reconnectingConn
+    health sinkHealth
+}
+
+// newSyslogSink validates the configuration and creates the syslog output of the logger.
+func (l *Logger) newSyslogSink(config SyslogConfig) (*syslogSink, error) {
+    facilityName := strings.ToLower(config.Facility)
+    if facilityName == "" {
+        facilityName = "user"
+    }
+    facility, ok := syslogFacilities[facilityName]
+    if !ok {
+        return nil, fmt.Errorf("invalid syslog facility: %s", config.Facility)
+    }
+    switch config.Network {
+    case "", "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6", "unix", "unixgram":
+    default:
+        return nil, fmt.Errorf("invalid syslog network: %s", config.Network)
+    }
+    if (config.Network == "") != (config.Address == "") {
+        return nil, fmt.Errorf("syslog network and address must be set together")
+    }
+
+    levelValue := config.Level
+    if levelValue == nil {
+        levelValue = "info"
+    }
+    level, err := l.parseLevel(levelValue)
+    if err != nil {
+        return nil, fmt.Errorf("invalid syslog log level: %v", err)
+    }
+
+    if config.Tag == "" {
+        config.Tag = filepath.Base(os.Args[0])
+    }
+    hostname, _ := os.Hostname()
+    if hostname == "" {
+        hostname = "-"
+    }
+    s := &syslogSink{config: config, facility: facility, level: level, hostname: hostname, dedupe: newDedupeFilter(config.Dedupe)}
+    s.conn = newReconnectingConn(s.dial)
+    return s, nil
+}
+
+// Name returns the name of the syslog output.
+func (s *syslogSink) Name() string {
+    return syslogSinkName
+}
+
+// Enabled reports whether the level of the syslog output allows the level.
+func (s *syslogSink) Enabled(level string, value int) bool {
+    return value <= s.level
+}
+
+// WriteEntry sends the entry as a syslog message, reconnecting once if the connection was lost.
+func (s *syslogSink) WriteEntry(e Entry) error {
+    msg := s.format(s.dedupe.apply(e))
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    err := s.conn.write(func(conn net.Conn) error {
+        _, err := conn.Write(msg)
+        return err
+    })
+    s.health.record(err)
+    return err
+}
+
+// state returns the state of the syslog output.
+func (s *syslogSink) state() SinkState {
+    s.mu.Lock()
+    connected := s.conn.connected()
+    s.mu.Unlock()
+    state := SinkState{Name: syslogSinkName, Level: levelName(s.level), Connected: connected}
+    s.health.fill(&state)
+    return state
+}
+
+// Close closes the connection to the syslog server.
+func (s *syslogSink) Close() error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return s.conn.close()
+}
+
+// dial connects to the configured server, or to the first available local syslog socket.
+func (s *syslogSink) dial(dialer *net.Dialer) (net.Conn, error) {
+    if s.config.Network != "" {
+        conn, err := dialer.Dial(s.config.Network, s.config.Address)
+        if err != nil {
+            return nil, fmt.Errorf("failed to connect to syslog: %v", err)
+        }
+        return conn, nil
+    }
+    for _, path := range syslogSockets {
+        for _, network := range []string{"unixgram", "unix"} {
+            if conn, err := dialer.Dial(network, path); err == nil {
+                return conn, nil
+            }
+        }
+    }
+    return nil, fmt.Errorf("no local syslog socket found")
+}
+
+// local reports whether messages go to the local syslog daemon, which adds the hostname itself.
+func (s *syslogSink) local() bool {
+    return s.config.Network == "" || strings.HasPrefix(s.config.Network, "unix")
+}
+
+// severity returns the syslog severity of a level, by its numeric value for custom levels.
+func severity(level string) int {
+    switch level {
+    case "fatal":
+        return 2
+    case "error":
+        return 3
+    case "warning":
+        return 4
+    case "info":
+        return 6
+    case "debug", "trace":
+        return 7
+    case "print":
+        return 5
+    }
+    value := levelMap()[level]
+    switch {
+    case value <= 0:
+        return 2
+    case value == 1:
+        return 3
+    case value == 2:
+        return 4
+    case value == 3:
+        return 6
+    }
+    return 7
+}
+
+// format renders the entry as a syslog message. The message part holds the message and the fields.
+func (s *syslogSink) format(e Entry) []byte {
+    pri := s.facility*8 + severity(e.Level)
+    msg := e.Message
+    if len(e.Fields) > 0 {
+        msg += formatFields(e.Fields)
+    }
+
+    buf := make([]byte, 0, 64+len(msg))
+    buf = append(buf, '<')
+    buf = strconv.AppendInt(buf, int64(pri), 10)
+    buf = append(buf, '>')
+    if s.config.RFC5424 {
+        buf = append(buf, "1 "...)
+        buf = e.Time.AppendFormat(buf, time.RFC3339Nano)
+        buf = append(buf, ' ')
+        buf = append(buf, s.hostname...)
+        buf = append(buf, ' ')
+        buf = append(buf, s.config.Tag...)
+        buf = append(buf, ' ')
+        buf = strconv.AppendInt(buf, int64(e.PID), 10)
+        buf = append(buf, " - - "...)
+    } else {
+        buf = e.Time.AppendFormat(buf, time.Stamp)
+        buf = append(buf, ' ')
+        if !s.local() {
+            buf = append(buf, s.hostname...)
+            buf = append(buf, ' ')
+        }
+        buf = append(buf, s.config.Tag...)
+        buf = append(buf, '[')
+        buf = strconv.AppendInt(buf, int64(e.PID), 10)
+        buf = append(buf, "]: "...)
+    }
+    buf = append(buf, msg...)
+    if s.stream() {
+        // Messages over stream transports are framed by octet counting (RFC 6587), so that
+        // multi-line messages arrive whole
+        framed := strconv.AppendInt(make([]byte, 0, len(buf)+8), int64(len(buf)), 10)
+        return append(append(framed, ' '), buf...)
+    }
+    return buf
+}
+
+// stream reports whether messages are sent over the configured stream transport, TCP or a Unix
+// stream socket.
+func (s *syslogSink) stream() bool {
+    return strings.HasPrefix(s.config.Network, "tcp") || s.config.Network == "unix"
+}
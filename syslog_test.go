@@ -0,0 +1,120 @@
+package logger_test
+
+import (
+    "bufio"
+    "fmt"
+    "io"
+    "net"
+    "regexp"
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+func TestSyslogUDP(t *testing.T) {
+    conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatalf("Failed to listen: %v", err)
+    }
+    defer conn.Close()
+
+    log, err := logger.NewLogger(logger.LogConfig{
+        ConsoleLevel: "fatal",
+        Syslog:       &logger.SyslogConfig{Network: "udp", Address: conn.LocalAddr().String(), Tag: "app"},
+    })
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    defer log.Close()
+    log.Debug("Not sent")
+    log.WithField("disk", "sda").Warning("Disk almost full")
+
+    buf := make([]byte, 1024)
+    conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+    n, _, err := conn.ReadFrom(buf)
+    if err != nil {
+        t.Fatalf("Failed to read syslog message: %v", err)
+    }
+    // Facility user (1), severity warning (4)
+    expected := regexp.MustCompile(`^<12>\w{3} [ \d]\d \d\d:\d\d:\d\d \S+ app\[\d+\]: Disk almost full disk=sda$`)
+    if got := string(buf[:n]); !expected.MatchString(got) {
+        t.Errorf("Unexpected syslog message: '%s'", got)
+    }
+}
+
+func TestSyslogTCP(t *testing.T) {
+    listener, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatalf("Failed to listen: %v", err)
+    }
+    defer listener.Close()
+    lines := make(chan string, 2)
+    go func() {
+        conn, err := listener.Accept()
+        if err != nil {
+            return
+        }
+        defer conn.Close()
+        // Messages are framed by octet counting: the length, a space, then the message
+        reader := bufio.NewReader(conn)
+        for {
+            var n int
+            if _, err := fmt.Fscanf(reader, "%d ", &n); err != nil {
+                return
+            }
+            msg := make([]byte, n)
+            if _, err := io.ReadFull(reader, msg); err != nil {
+                return
+            }
+            lines <- string(msg)
+        }
+    }()
+
+    log, err := logger.NewLogger(logger.LogConfig{
+        ConsoleLevel:    "fatal",
+        StackTraceLevel: logger.StackTraceNone,
+        Syslog: &logger.SyslogConfig{
+            Network:  "tcp",
+            Address:  listener.Addr().String(),
+            Facility: "local0",
+            Tag:      "app",
+            RFC5424:  true,
+        },
+    })
+    if err != nil {
+        t.Fatalf("Failed to create logger: %v", err)
+    }
+    defer log.Close()
+    log.Error("Payment failed\ncard declined")
+    log.Info("Payment retried")
+
+    // Facility local0 (16), severities err (3) and informational (6)
+    expected := []*regexp.Regexp{
+        regexp.MustCompile(`^<131>1 \d{4}-\d\d-\d\dT\S+ \S+ app \d+ - - Payment failed\ncard declined$`),
+        regexp.MustCompile(`^<134>1 \S+ \S+ app \d+ - - Payment retried$`),
+    }
+    for _, re := range expected {
+        select {
+        case line := <-lines:
+            if !re.MatchString(line) {
+                t.Errorf("Unexpected syslog message: '%s'", line)
+            }
+        case <-time.After(5 * time.Second):
+            t.Fatal("Timed out waiting for a syslog message")
+        }
+    }
+}
+
+func TestSyslogInvalid(t *testing.T) {
+    configs := []*logger.SyslogConfig{
+        {Facility: "nope"},
+        {Network: "carrier-pigeon", Address: "localhost:514"},
+        {Network: "udp"},
+    }
+    for _, config := range configs {
+        if _, err := logger.NewLogger(logger.LogConfig{Syslog: config}); err == nil {
+            t.Errorf("Expected %+v to be rejected", *config)
+        }
+    }
+}
@@ -0,0 +1,45 @@
+package logger
+
+// TagsField is the name of the field holding the tags of an entry, see (*Logger).Tags.
+const TagsField = "tags"
+
+// Tags returns a logger based on the global logger that tags every entry, see (*Logger).Tags.
+//
+// Arguments:
+//   - tags (...string): Tags to add.
+//
+// Returns:
+//   - (*Logger): Logger with the tags.
+func Tags(tags ...string) *Logger {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return nil
+    }
+    return logInstance.Tags(tags...)
+}
+
+// Tags returns a copy of the logger that tags every entry. Tags are kept in the "tags" field as an
+// array of strings, in addition to the tags of the logger itself and without duplicates:
+//
+//	log.Tags("billing", "audit").Info("Invoice sent")
+//
+// JSON output renders the field as an array, the standard format as a comma-separated list.
+//
+// Arguments:
+//   - tags (...string): Tags to add.
+//
+// Returns:
+//   - (*Logger): Logger with the tags.
+func (l *Logger) Tags(tags ...string) *Logger {
+    existing, _ := l.fields[TagsField].([]string)
+    merged := make([]string, 0, len(existing)+len(tags))
+    seen := make(map[string]struct{}, cap(merged))
+    for _, tag := range append(existing[:len(existing):len(existing)], tags...) {
+        if _, dup := seen[tag]; dup || tag == "" {
+            continue
+        }
+        seen[tag] = struct{}{}
+        merged = append(merged, tag)
+    }
+    return l.WithField(TagsField, merged)
+}
@@ -0,0 +1,40 @@
+package logger_test
+
+import (
+    "encoding/json"
+    "reflect"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestTags(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{Format: "json", FileLevel: "info"})
+    tagged := log.Tags("billing", "audit")
+    tagged.Tags("audit", "eu").Info("Invoice sent")
+    tagged.Info("Invoice paid")
+
+    lines := strings.Split(strings.TrimSpace(read()), "\n")
+    if len(lines) != 2 {
+        t.Fatalf("Expected 2 entries, got %v", lines)
+    }
+    expected := [][]interface{}{{"billing", "audit", "eu"}, {"billing", "audit"}}
+    for i, line := range lines {
+        var entry map[string]interface{}
+        if err := json.Unmarshal([]byte(line), &entry); err != nil {
+            t.Fatalf("Failed to parse entry: %v", err)
+        }
+        if !reflect.DeepEqual(entry["tags"], expected[i]) {
+            t.Errorf("Expected tags %v, got %v", expected[i], entry["tags"])
+        }
+    }
+}
+
+func TestTagsStandardFormat(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info"})
+    log.Tags("billing", "audit").Info("Invoice sent")
+    if file := strings.TrimSpace(read()); !strings.HasSuffix(file, "Invoice sent tags=billing,audit") {
+        t.Errorf("Expected comma-separated tags, got '%s'", file)
+    }
+}
@@ -0,0 +1,97 @@
+package logger
+
+import (
+    "fmt"
+    "sync"
+    "sync/atomic"
+)
+
+// debugTargets holds the field values whose entries bypass the levels of the outputs, shared by
+// the copies of a logger. The set is replaced atomically so that logging reads it without locking.
+type debugTargets struct {
+    mu     sync.Mutex // Serializes updates.
+    values atomic.Pointer[map[string]map[string]struct{}]
+}
+
+// newDebugTargets creates the targets of the configuration.
+func newDebugTargets(targets map[string][]string) *debugTargets {
+    t := &debugTargets{}
+    for key, values := range targets {
+        t.set(key, values)
+    }
+    return t
+}
+
+// set replaces the values of the field, removing the field if values is empty.
+func (t *debugTargets) set(key string, values []string) {
+    t.mu.Lock()
+    defer t.mu.Unlock()
+    next := make(map[string]map[string]struct{})
+    if current := t.values.Load(); current != nil {
+        for k, v := range *current {
+            next[k] = v
+        }
+    }
+    delete(next, key)
+    if len(values) > 0 {
+        set := make(map[string]struct{}, len(values))
+        for _, value := range values {
+            set[value] = struct{}{}
+        }
+        next[key] = set
+    }
+    t.values.Store(&next)
+}
+
+// active reports whether any field value is targeted.
+func (t *debugTargets) active() bool {
+    if t == nil {
+        return false
+    }
+    current := t.values.Load()
+    return current != nil && len(*current) > 0
+}
+
+// matches reports whether one of the fields has a targeted value.
+func (t *debugTargets) matches(fields Fields) bool {
+    if t == nil || len(fields) == 0 {
+        return false
+    }
+    current := t.values.Load()
+    if current == nil {
+        return false
+    }
+    for key, values := range *current {
+        if value, ok := fields[key]; ok {
+            if _, ok := values[fmt.Sprint(value)]; ok {
+                return true
+            }
+        }
+    }
+    return false
+}
+
+// SetDebugTargets sets the targeted values of a field for the global logger, see (*Logger).SetDebugTargets.
+//
+// Arguments:
+//   - key (string): Name of the field, e.g. "user_id".
+//   - values (...string): Targeted values of the field, none to stop targeting it.
+func SetDebugTargets(key string, values ...string) {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return
+    }
+    logInstance.SetDebugTargets(key, values...)
+}
+
+// SetDebugTargets sets the targeted values of a field, replacing the previous ones. Entries whose
+// field has a targeted value, compared as formatted with fmt.Sprint, bypass the levels of the outputs,
+// so that a single user or request can be traced in production without raising the verbosity for all.
+// Targeting applies to the logger and the loggers derived from it.
+//
+// Arguments:
+//   - key (string): Name of the field, e.g. "user_id".
+//   - values (...string): Targeted values of the field, none to stop targeting it.
+func (l *Logger) SetDebugTargets(key string, values ...string) {
+    l.targets.set(key, values)
+}
@@ -0,0 +1,40 @@
+package logger_test
+
+import (
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestDebugTargets(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{
+        FileLevel:    "warning",
+        DebugTargets: map[string][]string{"user_id": {"42"}},
+    })
+    log.WithField("user_id", 42).Trace("Cart loaded")
+    log.WithField("user_id", 7).Trace("Cart loaded")
+    log.Debug("Untargeted entry")
+
+    output := read()
+    if !strings.Contains(output, "Cart loaded user_id=42") {
+        t.Errorf("Expected the entry of the targeted user, got %q", output)
+    }
+    if strings.Contains(output, "user_id=7") || strings.Contains(output, "Untargeted entry") {
+        t.Errorf("Expected the other entries to be filtered, got %q", output)
+    }
+
+    log.SetDebugTargets("user_id", "7")
+    log.WithField("user_id", 7).Debug("Checkout started")
+    log.WithField("user_id", 42).Debug("Checkout started")
+    output = read()
+    if !strings.Contains(output, "Checkout started user_id=7") || strings.Contains(output, "Checkout started user_id=42") {
+        t.Errorf("Expected only the newly targeted user, got %q", output)
+    }
+
+    log.SetDebugTargets("user_id")
+    log.WithField("user_id", 7).Debug("Payment sent")
+    if output = read(); strings.Contains(output, "Payment sent") {
+        t.Errorf("Expected targeting to be removed, got %q", output)
+    }
+}
@@ -0,0 +1,9 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd
+
+package logger
+
+// terminalColumns returns 0: the terminal width is not obtainable on this platform, where the
+// COLUMNS environment variable is used instead.
+func terminalColumns(fd uintptr) int {
+    return 0
+}
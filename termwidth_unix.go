@@ -0,0 +1,19 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd
+
+package logger
+
+import (
+    "syscall"
+    "unsafe"
+)
+
+// terminalColumns returns the number of columns of the terminal behind the file descriptor,
+// 0 if it is not a terminal.
+func terminalColumns(fd uintptr) int {
+    var ws struct{ rows, cols, x, y uint16 }
+    _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&ws)))
+    if errno != 0 {
+        return 0
+    }
+    return int(ws.cols)
+}
@@ -0,0 +1,179 @@
+package logger
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+)
+
+// Style is a console style rendered with ANSI escape sequences.
+type Style struct {
+    Foreground string // Text color: a name such as "red" or "hi-blue", a 256-color index such as "208" or a truecolor "#rrggbb"; empty for the terminal default.
+    Background string // Background color, in the same forms as Foreground.
+    Bold       bool
+    Faint      bool
+    Italic     bool
+    Underline  bool
+    Reverse    bool // Whether the text and background colors are swapped.
+}
+
+// Theme is a set of console styles, selected with LogConfig.ColorTheme.
+type Theme struct {
+    Levels map[string]Style // Styles of the lines by level name; levels missing use the color they were registered with.
+    Key    Style            // Style of the keys in the pretty JSON format.
+    Plain  bool             // Whether levels missing from Levels are not colored instead.
+}
+
+// Themes are the theme presets by name. Applications can add their own before creating loggers.
+var Themes = map[string]Theme{
+    "default": {
+        Key: Style{Foreground: "cyan"},
+    },
+    "solarized": {
+        Levels: map[string]Style{
+            "trace":   {Foreground: "#586e75"},
+            "debug":   {Foreground: "#268bd2"},
+            "info":    {Foreground: "#859900"},
+            "warning": {Foreground: "#b58900"},
+            "error":   {Foreground: "#dc322f"},
+            "fatal":   {Foreground: "#fdf6e3", Background: "#dc322f", Bold: true},
+        },
+        Key: Style{Foreground: "#2aa198"},
+    },
+    "monochrome": {
+        Levels: map[string]Style{
+            "trace":   {Faint: true},
+            "debug":   {Faint: true},
+            "warning": {Bold: true},
+            "error":   {Bold: true, Underline: true},
+            "fatal":   {Bold: true, Reverse: true},
+        },
+        Key:   Style{Bold: true},
+        Plain: true,
+    },
+}
+
+// colorNames maps color names to their ANSI foreground codes.
+var colorNames = map[string]Color{
+    "black": FgBlack, "red": FgRed, "green": FgGreen, "yellow": FgYellow,
+    "blue": FgBlue, "magenta": FgMagenta, "cyan": FgCyan, "white": FgWhite,
+    "hi-black": FgHiBlack, "hi-red": FgHiRed, "hi-green": FgHiGreen, "hi-yellow": FgHiYellow,
+    "hi-blue": FgHiBlue, "hi-magenta": FgHiMagenta, "hi-cyan": FgHiCyan, "hi-white": FgHiWhite,
+}
+
+// consoleTheme is a compiled Theme holding the escape sequences of the styles.
+type consoleTheme struct {
+    levels map[string]string
+    key    string
+    plain  bool
+}
+
+// compileTheme compiles the named theme preset with the level styles overriding it.
+func (l *Logger) compileTheme(name string, overrides map[string]Style) (*consoleTheme, error) {
+    if name == "" {
+        name = "default"
+    }
+    theme, ok := Themes[strings.ToLower(name)]
+    if !ok {
+        return nil, fmt.Errorf("unknown color theme %q", name)
+    }
+    key, err := theme.Key.sequence()
+    if err != nil {
+        return nil, fmt.Errorf("key style: %v", err)
+    }
+    t := &consoleTheme{levels: make(map[string]string), key: key, plain: theme.Plain}
+    for _, styles := range []map[string]Style{theme.Levels, overrides} {
+        for level, style := range styles {
+            level = strings.ToLower(level)
+            if _, ok := l.LogLevelMap[level]; !ok && level != "print" {
+                return nil, fmt.Errorf("invalid level: %s", level)
+            }
+            if t.levels[level], err = style.sequence(); err != nil {
+                return nil, fmt.Errorf("%s style: %v", level, err)
+            }
+        }
+    }
+    return t, nil
+}
+
+// sequence returns the escape sequence starting the style, empty for the default style.
+func (s Style) sequence() (string, error) {
+    var codes []string
+    for _, attr := range []struct {
+        set  bool
+        code string
+    }{{s.Bold, "1"}, {s.Faint, "2"}, {s.Italic, "3"}, {s.Underline, "4"}, {s.Reverse, "7"}} {
+        if attr.set {
+            codes = append(codes, attr.code)
+        }
+    }
+    for _, c := range []struct {
+        value string
+        base  int
+    }{{s.Foreground, 0}, {s.Background, 10}} {
+        if c.value == "" {
+            continue
+        }
+        code, err := colorCode(c.value, c.base)
+        if err != nil {
+            return "", err
+        }
+        codes = append(codes, code)
+    }
+    if len(codes) == 0 {
+        return "", nil
+    }
+    return "\x1b[" + strings.Join(codes, ";") + "m", nil
+}
+
+// colorCode returns the SGR parameters of a color, for the background if base is 10.
+func colorCode(value string, base int) (string, error) {
+    value = strings.ToLower(strings.TrimSpace(value))
+    if c, ok := colorNames[value]; ok {
+        return strconv.Itoa(int(c) + base), nil
+    }
+    if hex, ok := strings.CutPrefix(value, "#"); ok {
+        rgb, err := strconv.ParseUint(hex, 16, 32)
+        if err != nil || len(hex) != 6 {
+            return "", fmt.Errorf("invalid color %q: expected #rrggbb", value)
+        }
+        return fmt.Sprintf("%d;2;%d;%d;%d", 38+base, rgb>>16, rgb>>8&0xff, rgb&0xff), nil
+    }
+    index, err := strconv.Atoi(value)
+    if err != nil || index < 0 || index > 255 {
+        return "", fmt.Errorf("invalid color %q: expected a name, a 256-color index or #rrggbb", value)
+    }
+    return fmt.Sprintf("%d;5;%d", 38+base, index), nil
+}
+
+// appendLevelStart appends the escape sequence starting the style of the level to buf, and reports
+// whether the style must be ended.
+func (t *consoleTheme) appendLevelStart(buf []byte, level string) ([]byte, bool) {
+    if t == nil || NoColor {
+        return buf, false
+    }
+    if seq, ok := t.levels[level]; ok {
+        return append(buf, seq...), seq != ""
+    }
+    if t.plain {
+        return buf, false
+    }
+    return appendColorStart(buf, levelColor(level)), true
+}
+
+// appendStyled appends text in the style of the level, or of the keys if key is set, to buf.
+func (t *consoleTheme) appendStyled(buf []byte, level string, key bool, text []byte) []byte {
+    styled := false
+    if key {
+        if t != nil && !NoColor && t.key != "" {
+            buf, styled = append(buf, t.key...), true
+        }
+    } else {
+        buf, styled = t.appendLevelStart(buf, level)
+    }
+    buf = append(buf, text...)
+    if styled {
+        buf = appendColorEnd(buf)
+    }
+    return buf
+}
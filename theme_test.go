@@ -0,0 +1,66 @@
+package logger_test
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestColorThemes(t *testing.T) {
+    noColor := logger.NoColor
+    logger.NoColor = false
+    defer func() { logger.NoColor = noColor }()
+
+    tests := []struct {
+        theme    string
+        styles   map[string]logger.Style
+        expected []string
+    }{
+        {"", nil, []string{"\x1b[33m", "\x1b[32m"}},
+        {"solarized", nil, []string{"\x1b[38;2;181;137;0m", "\x1b[38;2;133;153;0m"}},
+        {"monochrome", nil, []string{"\x1b[1m", ""}},
+        {"monochrome", map[string]logger.Style{"info": {Foreground: "208", Background: "blue", Italic: true}}, []string{"\x1b[1m", "\x1b[3;38;5;208;44m"}},
+    }
+    for _, tt := range tests {
+        log, err := logger.NewLogger(logger.LogConfig{
+            ConsoleLevel:  "info",
+            ConsoleOutput: true,
+            ColorTheme:    tt.theme,
+            ColorStyles:   tt.styles,
+        })
+        if err != nil {
+            t.Fatalf("Failed to create logger: %v", err)
+        }
+        var console bytes.Buffer
+        log.ConsoleLogger.SetOutput(&console)
+        log.Warning("Disk almost full")
+        log.Info("Request served")
+
+        lines := strings.Split(strings.TrimSuffix(console.String(), "\n"), "\n")
+        for i, prefix := range tt.expected {
+            reset := "\x1b[0m"
+            if prefix == "" {
+                prefix, reset = "[", ""
+            }
+            if !strings.HasPrefix(lines[i], prefix) || !strings.HasSuffix(lines[i], reset) {
+                t.Errorf("Theme %q: expected line %d to be styled with %q, got %q", tt.theme, i+1, prefix, lines[i])
+            }
+        }
+    }
+}
+
+func TestInvalidColorTheme(t *testing.T) {
+    configs := []logger.LogConfig{
+        {ConsoleOutput: true, ColorTheme: "neon"},
+        {ConsoleOutput: true, ColorStyles: map[string]logger.Style{"info": {Foreground: "#12345"}}},
+        {ConsoleOutput: true, ColorStyles: map[string]logger.Style{"info": {Background: "256"}}},
+        {ConsoleOutput: true, ColorStyles: map[string]logger.Style{"verbose": {Bold: true}}},
+    }
+    for _, config := range configs {
+        if _, err := logger.NewLogger(config); err == nil {
+            t.Errorf("Expected an error for %+v", config)
+        }
+    }
+}
@@ -0,0 +1,172 @@
+package logger
+
+import (
+    "bufio"
+    "bytes"
+    "compress/gzip"
+    "fmt"
+    "io"
+    "os"
+    "path/filepath"
+    "strings"
+    "time"
+)
+
+// thinningInterval is how often the background thinning job scans rotated files.
+var thinningInterval = time.Hour
+
+// thinLevel returns the least severe level kept in thinned files.
+func (rc RotationConfig) thinLevel() interface{} {
+    if rc.ThinLevel == nil {
+        return "warning"
+    }
+    return rc.ThinLevel
+}
+
+// runThinning runs ThinArchives for the logger configuration now and then every thinningInterval,
+// until the logger is stopped.
+func (l *Logger) runThinning() {
+    ticker := time.NewTicker(thinningInterval)
+    defer ticker.Stop()
+    for {
+        if _, err := ThinArchives(l.Config); err != nil {
+            reportError("thinning", err)
+        }
+        select {
+        case <-l.stop.ch:
+            return
+        case <-ticker.C:
+        }
+    }
+}
+
+// ThinArchives rewrites rotated log files older than RotationConfig.ThinAfter days so that they keep
+// only entries at RotationConfig.ThinLevel (default "warning") or more severe, shrinking long-term storage
+// while preserving the important history. Compressed files stay compressed, the current log file is never
+// modified, and lines without a recognizable level are kept with the entry they follow.
+// Loggers with rotation and ThinAfter set run this job in the background every hour.
+//
+// Arguments:
+//   - config (LogConfig): Logger configuration with FilePath and the thinning settings.
+//
+// Returns:
+//   - (int): Number of files rewritten.
+//   - error: Error if the configuration is invalid or a file cannot be rewritten.
+func ThinArchives(config LogConfig) (int, error) {
+    if config.FilePath == "" || config.RotationConfig.ThinAfter <= 0 {
+        return 0, nil
+    }
+    l := &Logger{LogLevelMap: levelMap()}
+    keepLevel, err := l.parseLevel(config.RotationConfig.thinLevel())
+    if err != nil {
+        return 0, fmt.Errorf("invalid thinning log level: %v", err)
+    }
+
+    cutoff := time.Now().AddDate(0, 0, -config.RotationConfig.ThinAfter)
+    rewritten := 0
+    for _, path := range rotatedFiles(config.FilePath) {
+        info, err := os.Stat(path)
+        if err != nil || info.ModTime().After(cutoff) {
+            continue
+        }
+        changed, err := thinFile(path, info, func(level string) bool {
+            value, ok := l.LogLevelMap[level]
+            return ok && value <= keepLevel
+        })
+        if err != nil {
+            return rewritten, err
+        }
+        if changed {
+            rewritten++
+        }
+    }
+    return rewritten, nil
+}
+
+// rotatedFiles returns the rotated backups of the log file, named like lumberjack backups:
+// "<name>-<timestamp><ext>" with an optional ".gz" suffix.
+func rotatedFiles(path string) []string {
+    ext := filepath.Ext(path)
+    prefix := strings.TrimSuffix(filepath.Base(path), ext) + "-"
+    matches, _ := filepath.Glob(filepath.Join(filepath.Dir(path), prefix+"*"))
+    var result []string
+    for _, match := range matches {
+        name := filepath.Base(match)
+        if strings.HasSuffix(name, ext) || strings.HasSuffix(name, ext+".gz") {
+            result = append(result, match)
+        }
+    }
+    return result
+}
+
+// thinFile rewrites the file keeping only the entries whose level is kept. It reports whether
+// the file was changed; files with nothing to drop are left untouched.
+func thinFile(path string, info os.FileInfo, keep func(level string) bool) (bool, error) {
+    compressed := strings.HasSuffix(path, ".gz")
+    data, err := readLogFile(path, compressed)
+    if err != nil {
+        return false, err
+    }
+
+    var out bytes.Buffer
+    dropped := false
+    keeping := true
+    scanner := bufio.NewScanner(bytes.NewReader(data))
+    scanner.Buffer(make([]byte, 64*1024), len(data)+1)
+    for scanner.Scan() {
+        line := scanner.Text()
+        if level, _ := lineRecord(line); level != "" {
+            keeping = keep(level)
+        }
+        if !keeping {
+            dropped = true
+            continue
+        }
+        out.WriteString(line)
+        out.WriteByte('\n')
+    }
+    if !dropped {
+        return false, nil
+    }
+
+    result := out.Bytes()
+    if compressed {
+        var gz bytes.Buffer
+        zw := gzip.NewWriter(&gz)
+        zw.Write(result)
+        if err := zw.Close(); err != nil {
+            return false, err
+        }
+        result = gz.Bytes()
+    }
+
+    tmp := path + ".thin.tmp"
+    if err := os.WriteFile(tmp, result, info.Mode().Perm()); err != nil {
+        return false, fmt.Errorf("failed to write thinned log file: %v", err)
+    }
+    if err := os.Rename(tmp, path); err != nil {
+        os.Remove(tmp)
+        return false, fmt.Errorf("failed to replace thinned log file: %v", err)
+    }
+    os.Chtimes(path, info.ModTime(), info.ModTime())
+    return true, nil
+}
+
+// readLogFile reads a log file, decompressing it if needed.
+func readLogFile(path string, compressed bool) ([]byte, error) {
+    file, err := os.Open(path)
+    if err != nil {
+        return nil, err
+    }
+    defer file.Close()
+    var r io.Reader = file
+    if compressed {
+        zr, err := gzip.NewReader(file)
+        if err != nil {
+            return nil, fmt.Errorf("failed to read compressed log file %s: %v", path, err)
+        }
+        defer zr.Close()
+        r = zr
+    }
+    return io.ReadAll(r)
+}
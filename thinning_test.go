@@ -0,0 +1,53 @@
+package logger_test
+
+import (
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+func TestThinArchives(t *testing.T) {
+    dir := t.TempDir()
+    current := filepath.Join(dir, "app.log")
+    old := filepath.Join(dir, "app-2024-01-01T00-00-00.000.log")
+    recent := filepath.Join(dir, "app-2024-06-01T00-00-00.000.log")
+
+    content := "[2024-01-01T00:00:00Z] [PID: 1] [main.go:1] [INFO] Routine message\n" +
+        "[2024-01-01T00:00:01Z] [PID: 1] [main.go:2] [ERROR] Important failure\n" +
+        "  continuation of the error\n" +
+        `{"level":"debug","message":"Debug detail"}` + "\n" +
+        `{"level":"warning","message":"Disk almost full"}` + "\n"
+    for _, path := range []string{current, old, recent} {
+        if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+            t.Fatalf("Failed to write file: %v", err)
+        }
+    }
+    oldTime := time.Now().AddDate(0, 0, -10)
+    os.Chtimes(old, oldTime, oldTime)
+
+    config := logger.LogConfig{FilePath: current, RotationConfig: logger.RotationConfig{ThinAfter: 7}}
+    n, err := logger.ThinArchives(config)
+    if err != nil || n != 1 {
+        t.Fatalf("Expected 1 thinned file, got %d (%v)", n, err)
+    }
+
+    data, _ := os.ReadFile(old)
+    thinned := string(data)
+    if strings.Contains(thinned, "Routine message") || strings.Contains(thinned, "Debug detail") {
+        t.Errorf("Expected INFO and DEBUG entries to be removed, got '%s'", thinned)
+    }
+    for _, kept := range []string{"Important failure", "continuation of the error", "Disk almost full"} {
+        if !strings.Contains(thinned, kept) {
+            t.Errorf("Expected '%s' to be kept, got '%s'", kept, thinned)
+        }
+    }
+    for _, path := range []string{current, recent} {
+        if data, _ := os.ReadFile(path); string(data) != content {
+            t.Errorf("File %s should not be thinned", path)
+        }
+    }
+}
@@ -0,0 +1,49 @@
+package logger
+
+import (
+    "bytes"
+    "runtime"
+    "strconv"
+    "sync"
+)
+
+// lockedGoroutines holds the IDs of goroutines locked to their OS thread through LockOSThread.
+var lockedGoroutines sync.Map
+
+// LockOSThread wires the calling goroutine to its current OS thread like runtime.LockOSThread,
+// and records it so that entries logged with LogConfig.ThreadInfo report locked_thread=true.
+// The Go runtime does not expose the lock state, so only locks taken through this function are reported.
+func LockOSThread() {
+    runtime.LockOSThread()
+    lockedGoroutines.Store(goroutineID(), struct{}{})
+}
+
+// UnlockOSThread undoes an earlier call to LockOSThread.
+func UnlockOSThread() {
+    lockedGoroutines.Delete(goroutineID())
+    runtime.UnlockOSThread()
+}
+
+// addThreadInfo adds the goroutine ID, the OS thread ID (where obtainable) and the LockOSThread
+// state to the fields of an entry.
+func addThreadInfo(fields Fields) {
+    gid := goroutineID()
+    fields["goroutine"] = gid
+    if tid := osThreadID(); tid >= 0 {
+        fields["tid"] = tid
+    }
+    _, locked := lockedGoroutines.Load(gid)
+    fields["locked_thread"] = locked
+}
+
+// goroutineID returns the ID of the calling goroutine, parsed from its stack header.
+func goroutineID() uint64 {
+    buf := make([]byte, 64)
+    buf = buf[:runtime.Stack(buf, false)]
+    buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+    if i := bytes.IndexByte(buf, ' '); i > 0 {
+        buf = buf[:i]
+    }
+    id, _ := strconv.ParseUint(string(buf), 10, 64)
+    return id
+}
@@ -0,0 +1,8 @@
+package logger
+
+import "syscall"
+
+// osThreadID returns the ID of the OS thread running the calling goroutine.
+func osThreadID() int {
+    return syscall.Gettid()
+}
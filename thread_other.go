@@ -0,0 +1,8 @@
+//go:build !linux
+
+package logger
+
+// osThreadID returns -1: the OS thread ID is not obtainable on this platform.
+func osThreadID() int {
+    return -1
+}
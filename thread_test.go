@@ -0,0 +1,28 @@
+package logger_test
+
+import (
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestThreadInfo(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info", ThreadInfo: true})
+
+    log.Info("Unlocked message")
+    logger.LockOSThread()
+    log.Info("Locked message")
+    logger.UnlockOSThread()
+
+    lines := strings.Split(strings.TrimSpace(read()), "\n")
+    if len(lines) != 2 {
+        t.Fatalf("Expected 2 entries, got %d", len(lines))
+    }
+    if !strings.Contains(lines[0], "goroutine=") || !strings.Contains(lines[0], "locked_thread=false") {
+        t.Errorf("Expected thread info in entry, got '%s'", lines[0])
+    }
+    if !strings.Contains(lines[1], "locked_thread=true") {
+        t.Errorf("Expected locked thread in entry, got '%s'", lines[1])
+    }
+}
@@ -0,0 +1,84 @@
+package logger
+
+import (
+    "runtime"
+    "strings"
+    "time"
+)
+
+// FuncField is the name of the field holding the traced function of TraceFunc entries.
+const FuncField = "func"
+
+// TraceFunc logs the entry of the calling function to the global logger and returns the function
+// logging its exit, see (*Logger).TraceFunc.
+//
+// Arguments:
+//   - fields (...Fields): Fields added to both entries, e.g. the arguments of the function.
+//
+// Returns:
+//   - (func()): Function logging the exit, to be deferred.
+func TraceFunc(fields ...Fields) func() {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return func() {}
+    }
+    return logInstance.traceFunc(fields)
+}
+
+// TraceFunc logs the entry of the calling function at the TRACE level and returns the function
+// logging its exit with the duration, for cheap execution tracing in debug sessions:
+//
+//	func (s *Store) Load(id string) error {
+//	    defer log.TraceFunc(logger.Fields{"id": id})()
+//	    ...
+//	}
+//
+// The entries carry the function name in the "func" field. When the TRACE level is not enabled,
+// nothing is logged and the function name is not resolved.
+//
+// Arguments:
+//   - fields (...Fields): Fields added to both entries, e.g. the arguments of the function.
+//
+// Returns:
+//   - (func()): Function logging the exit, to be deferred.
+func (l *Logger) TraceFunc(fields ...Fields) func() {
+    return l.traceFunc(fields)
+}
+
+// traceFunc logs the entry of the function calling the caller of traceFunc and returns the
+// function logging its exit.
+func (l *Logger) traceFunc(fields []Fields) func() {
+    slot, ok := l.LogLevelMap["trace"]
+    if !ok || !(l.levels.allow(slot) || l.enabled("trace", slot) || l.ring.accepts("trace", slot)) {
+        return func() {}
+    }
+
+    var pcs [1]uintptr
+    name := "unknown"
+    if runtime.Callers(3, pcs[:]) > 0 {
+        frame, _ := runtime.CallersFrames(pcs[:]).Next()
+        name = shortFuncName(frame.Function)
+    }
+    merged := Fields{FuncField: name}
+    for _, f := range fields {
+        for key, value := range f {
+            merged[key] = value
+        }
+    }
+
+    traced := l.WithFields(merged)
+    traced.logSkip(3, "trace", "Entering ", name)
+    start := time.Now()
+    return func() {
+        traced.WithField("duration", time.Since(start).String()).logSkip(2, "trace", "Exiting ", name)
+    }
+}
+
+// shortFuncName trims the import path of a function name, e.g. "github.com/org/app/store.(*Store).Load"
+// to "store.(*Store).Load".
+func shortFuncName(name string) string {
+    if i := strings.LastIndex(name, "/"); i >= 0 {
+        name = name[i+1:]
+    }
+    return name
+}
@@ -0,0 +1,48 @@
+package logger_test
+
+import (
+    "regexp"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+type store struct {
+    log *logger.Logger
+}
+
+func (s *store) load(id string) {
+    defer s.log.TraceFunc(logger.Fields{"id": id})()
+    s.log.Info("Loading")
+}
+
+func TestTraceFunc(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "trace"})
+    (&store{log: log}).load("42")
+
+    lines := strings.Split(strings.TrimSpace(read()), "\n")
+    if len(lines) != 3 {
+        t.Fatalf("Expected 3 entries, got %v", lines)
+    }
+    expected := []*regexp.Regexp{
+        regexp.MustCompile(`\[tracefunc_test\.go:16\] \[TRACE\] Entering logger_test\.\(\*store\)\.load .*func=logger_test\.\(\*store\)\.load`),
+        regexp.MustCompile(`\[INFO\] Loading$`),
+        regexp.MustCompile(`\[tracefunc_test\.go:18\] \[TRACE\] Exiting logger_test\.\(\*store\)\.load .*duration=\S+.*id=42`),
+    }
+    for i, re := range expected {
+        if !re.MatchString(lines[i]) {
+            t.Errorf("Unexpected entry %d: '%s'", i+1, lines[i])
+        }
+    }
+}
+
+func TestTraceFuncDisabled(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "debug"})
+    func() {
+        defer log.TraceFunc()()
+    }()
+    if file := read(); file != "" {
+        t.Errorf("Expected no entries without the TRACE level, got '%s'", file)
+    }
+}
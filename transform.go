@@ -0,0 +1,89 @@
+package logger
+
+import (
+    "fmt"
+    "regexp"
+    "strings"
+)
+
+// Transform is a declarative entry transformation that can be set in configuration files,
+// so that operators can adjust the output without code changes, for example:
+//
+//	"transforms": [
+//	    {"action": "rename", "field": "uid", "to": "user_id"},
+//	    {"action": "drop", "field": "debug_info"},
+//	    {"action": "add", "field": "env", "value": "prod"},
+//	    {"action": "replace", "pattern": "password=\\S+", "replacement": "password=***"}
+//	]
+//
+// Transforms run in order after the processors of LogConfig.Processors.
+type Transform struct {
+    Action      string      // Transformation: "rename", "drop", "add" or "replace".
+    Field       string      // Field to rename, drop or add.
+    To          string      // New name of the field for "rename".
+    Value       interface{} // Value of the field for "add".
+    Pattern     string      // Regular expression replaced in the message for "replace".
+    Replacement string      // Replacement text for "replace", may reference groups as $1.
+}
+
+// Processor compiles the transformation into a processor.
+//
+// Returns:
+//   - (Processor): Processor applying the transformation.
+//   - error: Error if the action is unknown or its arguments are missing or invalid.
+func (t Transform) Processor() (Processor, error) {
+    switch strings.ToLower(t.Action) {
+    case "rename":
+        if t.Field == "" || t.To == "" {
+            return nil, fmt.Errorf("rename transform requires \"field\" and \"to\"")
+        }
+        return func(e Entry) (Entry, bool) {
+            if value, ok := e.Fields[t.Field]; ok {
+                fields := e.CloneFields()
+                delete(fields, t.Field)
+                fields[t.To] = value
+                e.Fields = fields
+            }
+            return e, true
+        }, nil
+    case "drop":
+        if t.Field == "" {
+            return nil, fmt.Errorf("drop transform requires \"field\"")
+        }
+        return DropFields(t.Field), nil
+    case "add":
+        if t.Field == "" {
+            return nil, fmt.Errorf("add transform requires \"field\"")
+        }
+        return func(e Entry) (Entry, bool) {
+            fields := e.CloneFields()
+            fields[t.Field] = t.Value
+            e.Fields = fields
+            return e, true
+        }, nil
+    case "replace":
+        if t.Pattern == "" {
+            return nil, fmt.Errorf("replace transform requires \"pattern\"")
+        }
+        pattern, err := regexp.Compile(t.Pattern)
+        if err != nil {
+            return nil, fmt.Errorf("invalid replace transform pattern: %v", err)
+        }
+        return ReplaceMessage(pattern, t.Replacement), nil
+    default:
+        return nil, fmt.Errorf("unknown transform action %q, use \"rename\", \"drop\", \"add\" or \"replace\"", t.Action)
+    }
+}
+
+// compileTransforms compiles the transformations into processors.
+func compileTransforms(transforms []Transform) ([]Processor, error) {
+    processors := make([]Processor, 0, len(transforms))
+    for i, t := range transforms {
+        p, err := t.Processor()
+        if err != nil {
+            return nil, fmt.Errorf("transform %d: %v", i+1, err)
+        }
+        processors = append(processors, p)
+    }
+    return processors, nil
+}
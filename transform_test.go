@@ -0,0 +1,49 @@
+package logger_test
+
+import (
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestConfigTransforms(t *testing.T) {
+    config, err := logger.ParseConfig([]byte(`{
+        "fileLevel": "info",
+        "transforms": [
+            {"action": "rename", "field": "uid", "to": "user_id"},
+            {"action": "drop", "field": "debug_info"},
+            {"action": "add", "field": "env", "value": "prod"},
+            {"action": "replace", "pattern": "password=\\S+", "replacement": "password=***"}
+        ]
+    }`))
+    if err != nil {
+        t.Fatalf("Failed to parse config: %v", err)
+    }
+    log, read := newFileLogger(t, config)
+    log.WithFields(logger.Fields{"uid": 42, "debug_info": "x"}).Info("Login password=secret")
+
+    content := read()
+    if !strings.Contains(content, "Login password=*** env=prod user_id=42") {
+        t.Errorf("Expected transformed entry, got '%s'", content)
+    }
+    if strings.Contains(content, "debug_info") || strings.Contains(content, "uid=") {
+        t.Errorf("Expected fields to be dropped and renamed, got '%s'", content)
+    }
+}
+
+func TestInvalidTransforms(t *testing.T) {
+    for _, transform := range []logger.Transform{
+        {Action: "explode"},
+        {Action: "rename", Field: "a"},
+        {Action: "replace", Pattern: "("},
+    } {
+        if _, err := logger.NewLogger(logger.LogConfig{Transforms: []logger.Transform{transform}}); err == nil {
+            t.Errorf("Expected an error for transform %+v", transform)
+        }
+    }
+    report := logger.Doctor(logger.LogConfig{Transforms: []logger.Transform{{Action: "drop"}}})
+    if report.OK() {
+        t.Errorf("Expected Doctor to report the invalid transform")
+    }
+}
@@ -0,0 +1,60 @@
+package logger
+
+import (
+    "crypto/rand"
+    "encoding/binary"
+    "sync"
+    "time"
+)
+
+// EntryIDField is the name of the field holding the unique ID of an entry, see LogConfig.EntryIDs.
+const EntryIDField = "entry_id"
+
+// crockford is the Crockford base32 alphabet of ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ulidState is the state of the monotonic ULID generator: IDs generated in the same millisecond
+// increment the random part of the previous ID, so that they stay unique and sorted.
+var ulidState struct {
+    mu sync.Mutex
+    ms uint64
+    hi uint16 // High 16 bits of the 80-bit random part.
+    lo uint64 // Low 64 bits of the random part.
+}
+
+// newULID returns a new ULID for time t: 26 characters sorting by time, unique within the process
+// even for entries logged in the same millisecond.
+func newULID(t time.Time) string {
+    ms := uint64(t.UnixMilli())
+    s := &ulidState
+    s.mu.Lock()
+    if ms > s.ms {
+        var random [10]byte
+        rand.Read(random[:])
+        s.ms = ms
+        s.hi = binary.BigEndian.Uint16(random[:2])
+        s.lo = binary.BigEndian.Uint64(random[2:])
+    } else {
+        // Same millisecond, or the clock went back: keep the last time and increment
+        s.lo++
+        if s.lo == 0 {
+            s.hi++
+        }
+    }
+    ms, hi, lo := s.ms, s.hi, s.lo
+    s.mu.Unlock()
+
+    var id [26]byte
+    // 48-bit time in 10 characters
+    for i := 9; i >= 0; i-- {
+        id[i] = crockford[ms&31]
+        ms >>= 5
+    }
+    // 80-bit random part in 16 characters
+    for i := 25; i >= 10; i-- {
+        id[i] = crockford[lo&31]
+        lo = lo>>5 | uint64(hi&31)<<59
+        hi >>= 5
+    }
+    return string(id[:])
+}
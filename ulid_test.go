@@ -0,0 +1,43 @@
+package logger_test
+
+import (
+    "encoding/json"
+    "regexp"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestEntryIDs(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{Format: "json", FileLevel: "info", EntryIDs: true})
+    for i := 0; i < 100; i++ {
+        log.Info("Entry", i)
+    }
+
+    valid := regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+    previous := ""
+    for _, line := range strings.Split(strings.TrimSpace(read()), "\n") {
+        var entry map[string]interface{}
+        if err := json.Unmarshal([]byte(line), &entry); err != nil {
+            t.Fatalf("Failed to parse entry: %v", err)
+        }
+        id, _ := entry[logger.EntryIDField].(string)
+        if !valid.MatchString(id) {
+            t.Fatalf("Expected a ULID, got %q", id)
+        }
+        // IDs of entries logged in the same millisecond stay unique and sorted
+        if id <= previous {
+            t.Fatalf("Expected increasing IDs, got %s after %s", id, previous)
+        }
+        previous = id
+    }
+}
+
+func TestEntryIDsDisabled(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info"})
+    log.Info("Entry")
+    if file := read(); strings.Contains(file, logger.EntryIDField) {
+        t.Errorf("Expected no entry IDs by default, got '%s'", file)
+    }
+}
@@ -0,0 +1,221 @@
+package logger
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// Multipliers of the size units accepted by ParseSize. Decimal and binary prefixes are
+// both powers of 1024, matching the megabytes used by RotationConfig.MaxSize.
+var sizeUnits = map[string]int64{
+    "":    1,
+    "b":   1,
+    "k":   1 << 10,
+    "kb":  1 << 10,
+    "kib": 1 << 10,
+    "m":   1 << 20,
+    "mb":  1 << 20,
+    "mib": 1 << 20,
+    "g":   1 << 30,
+    "gb":  1 << 30,
+    "gib": 1 << 30,
+    "t":   1 << 40,
+    "tb":  1 << 40,
+    "tib": 1 << 40,
+}
+
+// ParseSize parses a human-friendly size such as "512KB", "100MB" or "1GiB" into bytes.
+// Units are case-insensitive and powers of 1024; a number without unit is a number of bytes.
+//
+// Arguments:
+//   - s (string): Size to parse.
+//
+// Returns:
+//   - (int64): Size in bytes.
+//   - error: Error if the size is malformed, negative or has an unknown unit.
+func ParseSize(s string) (int64, error) {
+    str := strings.TrimSpace(s)
+    i := 0
+    for i < len(str) && (str[i] >= '0' && str[i] <= '9' || str[i] == '.') {
+        i++
+    }
+    number, unit := str[:i], strings.ToLower(strings.TrimSpace(str[i:]))
+    multiplier, ok := sizeUnits[unit]
+    if number == "" || !ok {
+        return 0, fmt.Errorf("invalid size %q: expected a number with an optional unit such as KB, MB or GiB", s)
+    }
+    value, err := strconv.ParseFloat(number, 64)
+    if err != nil {
+        return 0, fmt.Errorf("invalid size %q: %v", s, err)
+    }
+    return int64(value * float64(multiplier)), nil
+}
+
+// Duration units accepted by ParseDuration in addition to those of time.ParseDuration.
+var longDurationUnits = []struct {
+    suffix string
+    name   string
+    unit   time.Duration
+}{
+    {"d", "days", 24 * time.Hour},
+    {"w", "weeks", 7 * 24 * time.Hour},
+}
+
+// ParseDuration parses a human-friendly duration. In addition to the units of time.ParseDuration
+// ("90s", "12h", "1h30m"), days and weeks are accepted as "d" and "w" suffixes, for example "7d" or "2w".
+//
+// Arguments:
+//   - s (string): Duration to parse.
+//
+// Returns:
+//   - (time.Duration): Parsed duration.
+//   - error: Error if the duration is malformed or negative.
+func ParseDuration(s string) (time.Duration, error) {
+    str := strings.TrimSpace(s)
+    for _, u := range longDurationUnits {
+        if number, ok := strings.CutSuffix(str, u.suffix); ok {
+            value, err := strconv.ParseFloat(number, 64)
+            if err != nil || value < 0 {
+                return 0, fmt.Errorf("invalid duration %q: expected a number of %s", s, u.name)
+            }
+            return time.Duration(value * float64(u.unit)), nil
+        }
+    }
+    d, err := time.ParseDuration(str)
+    if err != nil {
+        return 0, fmt.Errorf("invalid duration %q: expected a value such as 30s, 12h or 7d", s)
+    }
+    if d < 0 {
+        return 0, fmt.Errorf("invalid duration %q: must not be negative", s)
+    }
+    return d, nil
+}
+
+// unitConverter converts a human-friendly string value of a configuration field to its JSON number.
+type unitConverter func(s string) (interface{}, error)
+
+// Configuration fields accepting human-friendly strings, by section and field name (lower-case).
+var unitFields = map[string]map[string]unitConverter{
+    "rotationconfig": {
+        "maxsize":   megabytes,
+        "maxage":    days,
+        "thinafter": days,
+        "interval":  duration,
+    },
+    "filesink": {
+        "preallocate": megabytes,
+    },
+    "sync": {
+        "interval": duration,
+    },
+    "faults": {
+        "latency": duration,
+    },
+    "sampling": {
+        "tick": duration,
+    },
+    "loki": {
+        "flushinterval": duration,
+        "minbackoff":    duration,
+        "maxbackoff":    duration,
+    },
+    "repeats": {
+        "window": duration,
+    },
+    "levelwindows": {
+        "duration": duration,
+    },
+    "sinks": {
+        "dedupe": duration,
+    },
+}
+
+// megabytes converts a size to a whole number of megabytes, rounding up.
+func megabytes(s string) (interface{}, error) {
+    size, err := ParseSize(s)
+    if err != nil {
+        return nil, err
+    }
+    return (size + 1<<20 - 1) >> 20, nil
+}
+
+// days converts a duration to a number of days, which must be whole.
+func days(s string) (interface{}, error) {
+    d, err := ParseDuration(s)
+    if err != nil {
+        return nil, err
+    }
+    if d%(24*time.Hour) != 0 {
+        return nil, fmt.Errorf("invalid duration %q: must be a whole number of days", s)
+    }
+    return int64(d / (24 * time.Hour)), nil
+}
+
+// duration converts a duration to nanoseconds, the JSON form of time.Duration.
+func duration(s string) (interface{}, error) {
+    d, err := ParseDuration(s)
+    if err != nil {
+        return nil, err
+    }
+    return int64(d), nil
+}
+
+// convertUnits rewrites human-friendly string values of size and duration fields in a decoded
+// configuration object to numbers, recursing into nested sections and lists of sections.
+func convertUnits(raw map[string]json.RawMessage, path string) error {
+    for key, value := range raw {
+        name := strings.ToLower(key)
+        fieldPath := key
+        if path != "" {
+            fieldPath = path + "." + key
+        }
+        converters, ok := unitFields[name]
+        if !ok {
+            continue
+        }
+        switch trimmed := bytes.TrimSpace(value); {
+        case bytes.HasPrefix(trimmed, []byte("{")):
+            var section map[string]json.RawMessage
+            if err := json.Unmarshal(value, &section); err != nil {
+                return err
+            }
+            if err := convertSection(section, converters, fieldPath); err != nil {
+                return err
+            }
+            raw[key], _ = json.Marshal(section)
+        case bytes.HasPrefix(trimmed, []byte("[")):
+            var sections []map[string]json.RawMessage
+            if json.Unmarshal(value, &sections) != nil {
+                continue
+            }
+            for i, section := range sections {
+                if err := convertSection(section, converters, fmt.Sprintf("%s[%d]", fieldPath, i)); err != nil {
+                    return err
+                }
+            }
+            raw[key], _ = json.Marshal(sections)
+        }
+    }
+    return nil
+}
+
+// convertSection converts the fields of a section with its converters, then its nested sections.
+func convertSection(section map[string]json.RawMessage, converters map[string]unitConverter, path string) error {
+    for field, fieldValue := range section {
+        convert, ok := converters[strings.ToLower(field)]
+        var s string
+        if !ok || json.Unmarshal(fieldValue, &s) != nil {
+            continue
+        }
+        converted, err := convert(s)
+        if err != nil {
+            return fmt.Errorf("%s.%s: %v", path, field, err)
+        }
+        section[field], _ = json.Marshal(converted)
+    }
+    return convertUnits(section, path)
+}
@@ -0,0 +1,76 @@
+package logger_test
+
+import (
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/nir0k/logger"
+)
+
+func TestParseSize(t *testing.T) {
+    tests := map[string]int64{
+        "512":    512,
+        "1KB":    1024,
+        "100MB":  100 << 20,
+        "1GiB":   1 << 30,
+        "1.5 mb": 3 << 19,
+    }
+    for input, expected := range tests {
+        size, err := logger.ParseSize(input)
+        if err != nil || size != expected {
+            t.Errorf("ParseSize(%q) = %d, %v; expected %d", input, size, err, expected)
+        }
+    }
+    for _, input := range []string{"", "MB", "10XB", "-5MB"} {
+        if _, err := logger.ParseSize(input); err == nil {
+            t.Errorf("Expected an error for size %q", input)
+        }
+    }
+}
+
+func TestParseDuration(t *testing.T) {
+    tests := map[string]time.Duration{
+        "90s": 90 * time.Second,
+        "12h": 12 * time.Hour,
+        "7d":  7 * 24 * time.Hour,
+        "2w":  14 * 24 * time.Hour,
+    }
+    for input, expected := range tests {
+        d, err := logger.ParseDuration(input)
+        if err != nil || d != expected {
+            t.Errorf("ParseDuration(%q) = %v, %v; expected %v", input, d, err, expected)
+        }
+    }
+    for _, input := range []string{"", "7x", "-1h", "d"} {
+        if _, err := logger.ParseDuration(input); err == nil {
+            t.Errorf("Expected an error for duration %q", input)
+        }
+    }
+}
+
+func TestParseConfigUnits(t *testing.T) {
+    config, err := logger.ParseConfig([]byte(`{
+        "rotationConfig": {"maxSize": "1GiB", "maxAge": "2w", "thinAfter": 3},
+        "fileSink": {"preallocate": "64MB", "sync": {"interval": "1m"}}
+    }`))
+    if err != nil {
+        t.Fatalf("Failed to parse config: %v", err)
+    }
+    rc := config.RotationConfig
+    if rc.MaxSize != 1024 || rc.MaxAge != 14 || rc.ThinAfter != 3 {
+        t.Errorf("Unexpected rotation config: %+v", rc)
+    }
+    if config.FileSink.Preallocate != 64 || config.FileSink.Sync.Interval != time.Minute {
+        t.Errorf("Unexpected file sink config: %+v", config.FileSink)
+    }
+
+    _, err = logger.ParseConfig([]byte(`{"rotationConfig": {"maxAge": "12h"}}`))
+    if err == nil || !strings.Contains(err.Error(), "rotationConfig.maxAge") {
+        t.Errorf("Expected an error naming the field, got %v", err)
+    }
+    _, err = logger.ParseConfig([]byte(`{"rotationConfig": {"maxSize": "big"}}`))
+    if err == nil || !strings.Contains(err.Error(), "invalid size") {
+        t.Errorf("Expected an invalid size error, got %v", err)
+    }
+}
@@ -0,0 +1,93 @@
+package logger
+
+import "fmt"
+
+// Verbose is a klog-style verbosity logger returned by V.
+// Messages are written only if the verbosity is enabled, see V.
+type Verbose struct {
+    l       *Logger
+    level   string
+    enabled bool
+}
+
+// verbosityLevel maps a verbosity to the log level used for its messages:
+// V(0) logs at INFO, V(1)-V(3) at DEBUG and V(4) and above at TRACE.
+func verbosityLevel(n int) string {
+    switch {
+    case n <= 0:
+        return "info"
+    case n <= 3:
+        return "debug"
+    default:
+        return "trace"
+    }
+}
+
+// V returns a verbosity logger for the global logger instance.
+//
+// Arguments:
+//   - n (int): Verbosity of the messages.
+//
+// Returns:
+//   - (Verbose): Verbosity logger, enabled if n does not exceed the verbosity, see SetVerbosity.
+func V(n int) Verbose {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return Verbose{}
+    }
+    return logInstance.V(n)
+}
+
+// V returns a verbosity logger in the style of klog, for teams migrating from it.
+// The logger is enabled if n does not exceed LogConfig.Verbosity, or the verbosity set with
+// SetVerbosity, and the level it maps to (INFO for 0, DEBUG for 1-3, TRACE for 4 and above) is
+// enabled for a sink of the logger.
+//
+// Arguments:
+//   - n (int): Verbosity of the messages.
+//
+// Returns:
+//   - (Verbose): Verbosity logger.
+func (l *Logger) V(n int) Verbose {
+    level := verbosityLevel(n)
+    msgLevel := l.LogLevelMap[level]
+    enabled := int64(n) <= l.levels.verbosity.Load() && l.enabled(level, msgLevel)
+    return Verbose{l: l, level: level, enabled: enabled}
+}
+
+// Enabled reports whether messages of this verbosity are written.
+// It can be used to skip building expensive arguments.
+func (v Verbose) Enabled() bool {
+    return v.enabled
+}
+
+// Info logs a message if the verbosity is enabled.
+//
+// Arguments:
+//   - args (...interface{}): Message to log.
+func (v Verbose) Info(args ...interface{}) {
+    if v.enabled {
+        v.l.logSkip(2, v.level, args...)
+    }
+}
+
+// Infof logs a formatted message if the verbosity is enabled.
+//
+// Arguments:
+//   - format (string): Format string.
+//   - args (...interface{}): Values for formatting the message.
+func (v Verbose) Infof(format string, args ...interface{}) {
+    if v.enabled {
+        v.l.logSkip(2, v.level, sprintf(format, args))
+    }
+}
+
+// Infoln logs a message with a new line if the verbosity is enabled.
+//
+// Arguments:
+//   - args (...interface{}): Message to log.
+func (v Verbose) Infoln(args ...interface{}) {
+    if v.enabled {
+        v.l.logSkip(2, v.level, fmt.Sprintln(args...))
+    }
+}
@@ -0,0 +1,37 @@
+package logger_test
+
+import (
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestVerbosity(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "trace", Verbosity: 2})
+
+    if !log.V(2).Enabled() {
+        t.Errorf("Expected V(2) to be enabled with Verbosity 2")
+    }
+    if log.V(3).Enabled() {
+        t.Errorf("Expected V(3) to be disabled with Verbosity 2")
+    }
+
+    log.V(0).Info("Verbosity zero message")
+    log.V(2).Infof("Verbosity %d message", 2)
+    log.V(3).Info("Verbosity three message")
+
+    output := read()
+    if !strings.Contains(output, "[INFO] Verbosity zero message") {
+        t.Errorf("Expected V(0) message at INFO level, got '%s'", output)
+    }
+    if !strings.Contains(output, "[DEBUG] Verbosity 2 message") {
+        t.Errorf("Expected V(2) message at DEBUG level, got '%s'", output)
+    }
+    if !strings.Contains(output, "verbosity_test.go:") {
+        t.Errorf("Expected caller of V(n).Info in output, got '%s'", output)
+    }
+    if strings.Contains(output, "Verbosity three message") {
+        t.Errorf("V(3) message should not be written, got '%s'", output)
+    }
+}
@@ -0,0 +1,209 @@
+package logger
+
+import (
+    "bufio"
+    "encoding/json"
+    "io"
+    "net/http"
+    "os"
+    "regexp"
+    "strconv"
+    "strings"
+)
+
+// viewerTailBytes is how much of the end of the log file the viewer reads when there is no ring buffer.
+const viewerTailBytes = 256 * 1024
+
+// standardLevelPattern extracts the level from a line in the standard format.
+var standardLevelPattern = regexp.MustCompile(`\] \[([A-Z]+)\] `)
+
+// ViewerHandler returns the web log viewer of the global logger, see (*Logger).ViewerHandler.
+//
+// Returns:
+//   - (http.Handler): Web log viewer handler.
+func ViewerHandler() http.Handler {
+    ensureLoggerInitialized()
+    if logInstance == nil {
+        return http.NotFoundHandler()
+    }
+    return logInstance.ViewerHandler()
+}
+
+// ViewerHandler returns an HTTP handler serving a small web UI that shows recent entries with a
+// minimum level filter and text search, for operators who cannot access the host. Entries come from
+// the ring buffer if it is enabled, otherwise from the end of the log file.
+// The handler serves the page at "/" and the entries as JSON at "/entries", and should be mounted
+// with a trailing slash:
+//
+//	mux.Handle("/debug/logs/", http.StripPrefix("/debug/logs", log.ViewerHandler()))
+//
+// Returns:
+//   - (http.Handler): Web log viewer handler.
+func (l *Logger) ViewerHandler() http.Handler {
+    mux := http.NewServeMux()
+    mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+        if r.URL.Path != "/" && r.URL.Path != "" {
+            http.NotFound(w, r)
+            return
+        }
+        w.Header().Set("Content-Type", "text/html; charset=utf-8")
+        io.WriteString(w, viewerPage)
+    })
+    mux.HandleFunc("/entries", func(w http.ResponseWriter, r *http.Request) {
+        query := r.URL.Query()
+        limit, err := strconv.Atoi(query.Get("limit"))
+        if err != nil || limit <= 0 {
+            limit = 500
+        }
+        records := l.viewerRecords(query.Get("level"), query.Get("q"), limit)
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(records)
+    })
+    return mux
+}
+
+// viewerRecords returns up to limit recent records as JSON objects, newest last,
+// at or above the given level and containing the search text.
+func (l *Logger) viewerRecords(level, search string, limit int) []json.RawMessage {
+    maxLevel, filterLevel := l.LogLevelMap[strings.ToLower(level)]
+    search = strings.ToLower(search)
+
+    var records []json.RawMessage
+    keep := func(recordLevel string, record string) {
+        if value, ok := l.LogLevelMap[recordLevel]; filterLevel && ok && value > maxLevel {
+            return
+        }
+        if search != "" && !strings.Contains(strings.ToLower(record), search) {
+            return
+        }
+        records = append(records, json.RawMessage(record))
+    }
+
+    if l.ring != nil {
+        for _, entry := range l.ring.snapshot() {
+            keep(entry.Level, entry.formatJSON())
+        }
+    } else {
+        for _, line := range tailLines(l.Config.FilePath, viewerTailBytes) {
+            recordLevel, record := lineRecord(line)
+            keep(recordLevel, record)
+        }
+    }
+
+    if len(records) > limit {
+        records = records[len(records)-limit:]
+    }
+    return records
+}
+
+// lineRecord converts a log file line into a JSON record and returns it with its level.
+func lineRecord(line string) (string, string) {
+    var data map[string]interface{}
+    if json.Unmarshal([]byte(line), &data) == nil {
+        level, _ := data["level"].(string)
+        return level, line
+    }
+    level := ""
+    if m := standardLevelPattern.FindStringSubmatch(line); m != nil {
+        level = strings.ToLower(m[1])
+    }
+    record, _ := json.Marshal(map[string]string{"level": level, "message": line})
+    return level, string(record)
+}
+
+// tailLines returns the complete lines within the last maxBytes of the file.
+func tailLines(path string, maxBytes int64) []string {
+    if path == "" {
+        return nil
+    }
+    file, err := os.Open(path)
+    if err != nil {
+        return nil
+    }
+    defer file.Close()
+
+    info, err := file.Stat()
+    if err != nil {
+        return nil
+    }
+    offset := info.Size() - maxBytes
+    if offset < 0 {
+        offset = 0
+    }
+    if _, err := file.Seek(offset, io.SeekStart); err != nil {
+        return nil
+    }
+
+    var lines []string
+    scanner := bufio.NewScanner(file)
+    scanner.Buffer(make([]byte, 64*1024), int(maxBytes))
+    for scanner.Scan() {
+        lines = append(lines, scanner.Text())
+    }
+    if offset > 0 && len(lines) > 0 {
+        lines = lines[1:] // The first line is likely partial
+    }
+    return lines
+}
+
+// viewerPage is the web log viewer UI.
+const viewerPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Logs</title>
+<style>
+body { font-family: monospace; margin: 0; background: #1e1e1e; color: #ddd; }
+header { position: sticky; top: 0; padding: 8px; background: #333; }
+pre { margin: 0; padding: 8px; white-space: pre-wrap; }
+.trace { color: #4dd; } .debug { color: #68f; } .info { color: #6d6; }
+.warning { color: #dd4; } .error { color: #f55; } .fatal { color: #f0f; }
+</style>
+</head>
+<body>
+<header>
+<select id="level">
+<option value="">all</option><option>trace</option><option>debug</option><option>info</option>
+<option>warning</option><option>error</option><option>fatal</option>
+</select>
+<input id="q" placeholder="search">
+<label><input id="follow" type="checkbox" checked> follow</label>
+</header>
+<pre id="log"></pre>
+<script>
+function render(records) {
+  var log = document.getElementById("log");
+  log.textContent = "";
+  records.forEach(function (r) {
+    var line = document.createElement("div");
+    line.className = r.level || "";
+    var text = r.timestamp ? r.timestamp + " [" + (r.level || "").toUpperCase() + "] " + r.message : r.message;
+    Object.keys(r).forEach(function (k) {
+      if (["timestamp", "level", "message", "pid", "file", "line"].indexOf(k) < 0) {
+        text += " " + k + "=" + JSON.stringify(r[k]);
+      }
+    });
+    line.textContent = text;
+    log.appendChild(line);
+  });
+  if (document.getElementById("follow").checked) {
+    window.scrollTo(0, document.body.scrollHeight);
+  }
+}
+function refresh() {
+  var params = new URLSearchParams({
+    level: document.getElementById("level").value,
+    q: document.getElementById("q").value
+  });
+  fetch("entries?" + params).then(function (r) { return r.json(); }).then(function (records) {
+    render(records || []);
+  });
+}
+document.getElementById("level").onchange = refresh;
+document.getElementById("q").oninput = refresh;
+setInterval(refresh, 2000);
+refresh();
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,60 @@
+package logger_test
+
+import (
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestViewerHandler(t *testing.T) {
+    log, _ := newFileLogger(t, logger.LogConfig{FileLevel: "warning", RingBufferSize: 3})
+    log.Trace("Trace kept in memory")
+    log.Info("Disk full on /var")
+    log.Error("Disk full on /data")
+    log.Error("Connection refused")
+
+    entries := log.RecentEntries()
+    if len(entries) != 3 || entries[0].Message != "Disk full on /var" {
+        t.Fatalf("Expected the 3 most recent entries, got %+v", entries)
+    }
+
+    server := httptest.NewServer(log.ViewerHandler())
+    defer server.Close()
+
+    resp, err := http.Get(server.URL + "/entries?level=error&q=disk")
+    if err != nil {
+        t.Fatalf("Request failed: %v", err)
+    }
+    defer resp.Body.Close()
+
+    var records []map[string]interface{}
+    if err := json.NewDecoder(resp.Body).Decode(&records); err != nil {
+        t.Fatalf("Failed to decode records: %v", err)
+    }
+    if len(records) != 1 || records[0]["message"] != "Disk full on /data" {
+        t.Errorf("Unexpected records: %v", records)
+    }
+}
+
+func TestViewerHandlerFileFallback(t *testing.T) {
+    log, _ := newFileLogger(t, logger.LogConfig{FileLevel: "info"})
+    log.Info("From the file")
+
+    server := httptest.NewServer(log.ViewerHandler())
+    defer server.Close()
+
+    resp, err := http.Get(server.URL + "/entries")
+    if err != nil {
+        t.Fatalf("Request failed: %v", err)
+    }
+    defer resp.Body.Close()
+
+    var records []map[string]interface{}
+    json.NewDecoder(resp.Body).Decode(&records)
+    if len(records) != 1 || records[0]["level"] != "info" {
+        t.Errorf("Unexpected records: %v", records)
+    }
+}
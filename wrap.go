@@ -0,0 +1,122 @@
+package logger
+
+import (
+    "os"
+    "strconv"
+    "strings"
+    "sync/atomic"
+    "time"
+    "unicode/utf8"
+)
+
+// Handling of console lines longer than the terminal, see LogConfig.ConsoleOverflow.
+const (
+    OverflowWrap     = "wrap"     // Long lines continue on the next lines after a continuation marker.
+    OverflowTruncate = "truncate" // Long lines are cut, ending with an ellipsis.
+)
+
+// Continuation markers of wrapped and truncated lines, with their ASCII fallbacks used when
+// ASCIIGlyphs is set.
+const (
+    wrapMarker          = "  ↳ "
+    wrapMarkerASCII     = "  > "
+    truncateMarker      = "…"
+    truncateMarkerASCII = "..."
+)
+
+// widthRefresh is how often the width of the terminal is read again, so that resizes apply.
+const widthRefresh = time.Second
+
+// consoleWidth is the width console lines are fitted to: a fixed width, or the width of the terminal
+// on stdout read at most every widthRefresh.
+type consoleWidth struct {
+    fixed   int
+    mode    string
+    value   atomic.Int64
+    checked atomic.Int64 // Time of the last read in Unix nanoseconds.
+}
+
+// newConsoleWidth creates the width of the console output, nil if long lines are kept.
+func newConsoleWidth(mode string, width int) *consoleWidth {
+    mode = strings.ToLower(mode)
+    if mode == "" {
+        return nil
+    }
+    return &consoleWidth{fixed: width, mode: mode}
+}
+
+// columns returns the current width, 0 if it is unknown.
+func (w *consoleWidth) columns() int {
+    if w.fixed > 0 {
+        return w.fixed
+    }
+    now := time.Now().UnixNano()
+    if checked := w.checked.Load(); now-checked >= int64(widthRefresh) && w.checked.CompareAndSwap(checked, now) {
+        w.value.Store(int64(detectColumns()))
+    }
+    return int(w.value.Load())
+}
+
+// detectColumns returns the width of the terminal on stdout, or the COLUMNS environment variable.
+func detectColumns() int {
+    if cols := terminalColumns(os.Stdout.Fd()); cols > 0 {
+        return cols
+    }
+    cols, _ := strconv.Atoi(os.Getenv("COLUMNS"))
+    return cols
+}
+
+// appendFitted appends text to buf with each of its lines wrapped or truncated to the width.
+// Widths are counted in runes.
+func (w *consoleWidth) appendFitted(buf []byte, text string) []byte {
+    width := w.columns()
+    marker, cut := wrapMarker, truncateMarker
+    if ASCIIGlyphs {
+        marker, cut = wrapMarkerASCII, truncateMarkerASCII
+    }
+    if w.mode == OverflowWrap && width <= utf8.RuneCountInString(marker) {
+        width = 0 // Too narrow to wrap
+    }
+    for i, line := range strings.Split(text, "\n") {
+        if i > 0 {
+            buf = append(buf, '\n')
+        }
+        if width <= 0 || utf8.RuneCountInString(line) <= width {
+            buf = append(buf, line...)
+            continue
+        }
+        if w.mode == OverflowTruncate {
+            keep := width - utf8.RuneCountInString(cut)
+            if keep < 0 {
+                keep = 0
+            }
+            buf = append(append(buf, prefixRunes(line, keep)...), cut...)
+            continue
+        }
+        // Wrapped lines continue after the marker
+        first := true
+        for line != "" {
+            n := width
+            if !first {
+                buf = append(append(buf, '\n'), marker...)
+                n -= utf8.RuneCountInString(marker)
+            }
+            part := prefixRunes(line, n)
+            buf = append(buf, part...)
+            line = line[len(part):]
+            first = false
+        }
+    }
+    return buf
+}
+
+// prefixRunes returns the first n runes of s.
+func prefixRunes(s string, n int) string {
+    for i := range s {
+        if n == 0 {
+            return s[:i]
+        }
+        n--
+    }
+    return s
+}
@@ -0,0 +1,54 @@
+package logger_test
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestConsoleOverflow(t *testing.T) {
+    noColor, ascii := logger.NoColor, logger.ASCIIGlyphs
+    defer func() { logger.NoColor, logger.ASCIIGlyphs = noColor, ascii }()
+    logger.NoColor, logger.ASCIIGlyphs = true, false
+
+    message := strings.Repeat("x", 100)
+    for _, mode := range []string{logger.OverflowTruncate, logger.OverflowWrap} {
+        log, read := newFileLogger(t, logger.LogConfig{
+            FileLevel:       "info",
+            ConsoleLevel:    "info",
+            ConsoleOutput:   true,
+            ConsoleOverflow: mode,
+            ConsoleWidth:    40,
+        })
+        var console bytes.Buffer
+        log.ConsoleLogger.SetOutput(&console)
+        log.Info(message)
+
+        lines := strings.Split(strings.TrimSuffix(console.String(), "\n"), "\n")
+        for i, line := range lines {
+            if n := len([]rune(line)); n > 40 {
+                t.Errorf("%s: expected line %d to fit 40 columns, got %d: %q", mode, i+1, n, line)
+            }
+        }
+        if mode == logger.OverflowTruncate && (len(lines) != 1 || !strings.HasSuffix(lines[0], "…")) {
+            t.Errorf("Expected a single truncated line, got %q", lines)
+        }
+        if mode == logger.OverflowWrap {
+            if len(lines) < 2 || !strings.HasPrefix(lines[1], "  ↳ ") {
+                t.Errorf("Expected continuation lines, got %q", lines)
+            }
+            if joined := strings.Count(console.String(), "x"); joined != 100 {
+                t.Errorf("Expected the whole message to be wrapped, got %d characters", joined)
+            }
+        }
+        if !strings.Contains(read(), message) {
+            t.Errorf("Expected the file output to be unchanged")
+        }
+    }
+
+    if _, err := logger.NewLogger(logger.LogConfig{ConsoleOutput: true, ConsoleOverflow: "scroll"}); err == nil {
+        t.Errorf("Expected an error for an invalid overflow mode")
+    }
+}
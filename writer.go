@@ -0,0 +1,142 @@
+package logger
+
+import (
+    "bytes"
+    "fmt"
+    "strings"
+    "sync"
+)
+
+// maxWriterLine is the length after which a line written to a LineWriter is logged without waiting
+// for its newline.
+const maxWriterLine = 64 * 1024
+
+// LineWriter is an io.Writer logging every line written to it as an entry at its level, for APIs
+// that want a writer such as http.Server.ErrorLog or exec.Cmd.Stdout:
+//
+//	w, _ := log.WriterLevel("error")
+//	server := &http.Server{ErrorLog: stdlog.New(w, "", 0)}
+//
+// Incomplete lines are kept until their newline is written or the writer is flushed or closed.
+// Empty lines are not logged. A LineWriter is safe for concurrent use.
+type LineWriter struct {
+    l     *Logger // Logger of the entries, nil for the global logger.
+    level string
+
+    mu  sync.Mutex
+    buf []byte // Incomplete last line.
+}
+
+// WriterLevel returns a writer logging every line written to it at the level with the global
+// logger, see (*Logger).WriterLevel.
+//
+// Arguments:
+//   - level (string): Level of the lines; "fatal" is not allowed.
+//
+// Returns:
+//   - (*LineWriter): Writer logging lines.
+//   - error: Error if the level is invalid.
+func WriterLevel(level string) (*LineWriter, error) {
+    level = strings.ToLower(level)
+    if _, ok := levelMap()[level]; (!ok && level != "print") || level == "fatal" {
+        return nil, fmt.Errorf("invalid writer log level: %s", level)
+    }
+    return &LineWriter{level: level}, nil
+}
+
+// WriterLevel returns a writer logging every line written to it as an entry at the level.
+//
+// Arguments:
+//   - level (string): Level of the lines; "fatal" is not allowed.
+//
+// Returns:
+//   - (*LineWriter): Writer logging lines.
+//   - error: Error if the level is invalid.
+func (l *Logger) WriterLevel(level string) (*LineWriter, error) {
+    level = strings.ToLower(level)
+    if _, ok := l.LogLevelMap[level]; (!ok && level != "print") || level == "fatal" {
+        return nil, fmt.Errorf("invalid writer log level: %s", level)
+    }
+    return &LineWriter{l: l, level: level}, nil
+}
+
+// Write logs the complete lines of p and keeps an incomplete last line for the next write.
+//
+// Arguments:
+//   - p ([]byte): Written bytes.
+//
+// Returns:
+//   - (int): Number of bytes consumed, always len(p).
+//   - error: Always nil.
+func (w *LineWriter) Write(p []byte) (int, error) {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+    w.buf = append(w.buf, p...)
+    for {
+        i := bytes.IndexByte(w.buf, '\n')
+        if i < 0 {
+            break
+        }
+        w.logLine(w.buf[:i])
+        w.buf = w.buf[i+1:]
+    }
+    if len(w.buf) >= maxWriterLine {
+        w.logLine(w.buf)
+        w.buf = w.buf[:0]
+    }
+    if len(w.buf) == 0 {
+        w.buf = nil // Release the memory of long writes
+    }
+    return len(p), nil
+}
+
+// Flush logs the incomplete last line, if any.
+func (w *LineWriter) Flush() {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+    w.logLine(w.buf)
+    w.buf = nil
+}
+
+// Close logs the incomplete last line, if any.
+//
+// Returns:
+//   - error: Always nil.
+func (w *LineWriter) Close() error {
+    w.Flush()
+    return nil
+}
+
+// logLine logs a line without its trailing carriage return, skipping empty lines.
+func (w *LineWriter) logLine(line []byte) {
+    line = bytes.TrimSuffix(line, []byte{'\r'})
+    if len(line) == 0 {
+        return
+    }
+    l := w.l
+    if l == nil {
+        ensureLoggerInitialized()
+        if l = logInstance; l == nil {
+            return
+        }
+    }
+    l.logSkip(4, w.level, string(line))
+}
+
+// Write logs every line of p as an entry at the INFO level, so that the logger itself can be used
+// as an io.Writer. Unlike a LineWriter, it does not keep incomplete lines between writes.
+//
+// Arguments:
+//   - p ([]byte): Written bytes.
+//
+// Returns:
+//   - (int): Number of bytes consumed, always len(p).
+//   - error: Always nil.
+func (l *Logger) Write(p []byte) (int, error) {
+    for _, line := range strings.Split(string(p), "\n") {
+        if line = strings.TrimSuffix(line, "\r"); line != "" {
+            l.logSkip(2, "info", line)
+        }
+    }
+    return len(p), nil
+}
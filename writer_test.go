@@ -0,0 +1,54 @@
+package logger_test
+
+import (
+    "fmt"
+    stdlog "log"
+    "strings"
+    "testing"
+
+    "github.com/nir0k/logger"
+)
+
+func TestWriterLevel(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info", StackTraceLevel: logger.StackTraceNone})
+    w, err := log.WithField("source", "http").WriterLevel("error")
+    if err != nil {
+        t.Fatalf("Failed to create writer: %v", err)
+    }
+    stdlog.New(w, "", 0).Printf("http: TLS handshake error from %s", "10.0.0.1")
+    fmt.Fprint(w, "first line\r\nsecond ")
+    fmt.Fprint(w, "line\n\npartial")
+    if strings.Contains(read(), "partial") {
+        t.Errorf("Expected the incomplete line to be kept")
+    }
+    w.Close()
+
+    lines := strings.Split(strings.TrimSpace(read()), "\n")
+    expected := []string{
+        "[ERROR] http: TLS handshake error from 10.0.0.1 source=http",
+        "[ERROR] first line source=http",
+        "[ERROR] second line source=http",
+        "[ERROR] partial source=http",
+    }
+    if len(lines) != len(expected) {
+        t.Fatalf("Expected %d entries, got %v", len(expected), lines)
+    }
+    for i, line := range lines {
+        if !strings.HasSuffix(line, expected[i]) {
+            t.Errorf("Expected entry %d to end with %q, got %q", i+1, expected[i], line)
+        }
+    }
+
+    if _, err := log.WriterLevel("fatal"); err == nil {
+        t.Errorf("Expected an error for the fatal level")
+    }
+}
+
+func TestLoggerWrite(t *testing.T) {
+    log, read := newFileLogger(t, logger.LogConfig{FileLevel: "info"})
+    fmt.Fprintf(log, "Listening on %s\nReady\n", ":8080")
+    output := read()
+    if !strings.Contains(output, "[INFO] Listening on :8080\n") || !strings.Contains(output, "[INFO] Ready\n") {
+        t.Errorf("Expected one INFO entry per line, got %q", output)
+    }
+}